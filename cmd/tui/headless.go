@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/backend"
+)
+
+// runHeadless runs the service without the TUI, printing every event as a JSON line
+// to out until interrupted (or, with untilReplayEnds, until the pcap replay ends),
+// then the session report as a final JSON line of type "report"
+func runHeadless(svc *backend.Service, out io.Writer, untilReplayEnds bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := bufio.NewWriter(out)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range svc.Events {
+			writeJSONLine(w, event)
+			// Flush when idle, so piped consumers see events as they happen
+			if len(svc.Events) == 0 {
+				_ = w.Flush()
+			}
+		}
+	}()
+
+	if err := svc.Start(); err != nil {
+		return err
+	}
+	if untilReplayEnds {
+		go func() {
+			svc.Wait()
+			stop()
+		}()
+	}
+
+	<-ctx.Done()
+	svc.Stop()
+	<-done
+
+	writeJSONLine(w, backend.GameEvent{Type: backend.EventTypeReport, Timestamp: time.Now(), Data: svc.Report()})
+	return w.Flush()
+}
+
+// writeJSONLine writes an event as a JSON line, reporting unencodable events on stderr
+func writeJSONLine(w io.Writer, event backend.GameEvent) {
+	line, err := backend.FormatJSONL(event)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding %s event: %v\n", event.Type, err)
+		return
+	}
+	fmt.Fprintln(w, line)
+}
@@ -1,24 +1,60 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/cantalupo555/albion-lens/internal/tui"
 	"github.com/cantalupo555/albion-lens/pkg/backend"
 	"github.com/cantalupo555/albion-lens/pkg/capture"
+	"github.com/cantalupo555/albion-lens/pkg/format"
+	"github.com/cantalupo555/albion-lens/pkg/items"
 	"github.com/cantalupo555/albion-lens/pkg/photon"
 )
 
 func main() {
 	// Parse command line flags
 	listDevices := flag.Bool("list", false, "List available network devices")
-	deviceName := flag.String("device", "", "Specific device to capture on (captures all if not specified)")
+	var devices deviceList
+	flag.Var(&devices, "device", "Device to capture on, repeatable or comma-separated (captures all if not specified)")
+	pcapFile := flag.String("pcap", "", "Replay this pcap capture file instead of capturing live traffic")
+	savePcap := flag.String("save-pcap", "", "Save captured Albion packets to this pcap file (replayable with -pcap)")
+	fast := flag.Bool("fast", false, "With -pcap, replay as fast as possible instead of at the recorded pace")
+	speed := flag.Float64("speed", 1, "With -pcap, replay this many times faster than the recorded pace (e.g. 2)")
+	loop := flag.Bool("loop", false, "With -pcap, restart the replay at the end of the file until quit")
 	debug := flag.Bool("debug", false, "Enable debug output")
 	itemsPath := flag.String("items", "", "Path to ao-bin-dumps directory for item name resolution")
+	validateItems := flag.String("validate-items", "", "Validate this items.json file, print a coverage report and exit")
+	updateItems := flag.Bool("update-items", false, "Download the latest items.json from ao-bin-dumps into the -items directory (default ./ao-bin-dumps) before starting")
+	wsAddr := flag.String("ws-addr", "", "Stream events as JSON to WebSocket clients at this address (e.g. :8080, ws://host:8080/events)")
+	wsOrigins := flag.String("ws-origins", "", "Comma-separated web page origins allowed to connect to -ws-addr besides its own host (e.g. http://localhost:3000, * for any)")
+	metricsAddr := flag.String("metrics-addr", "", "Serve Prometheus metrics at this address (e.g. :9090, scraped at /metrics)")
+	statusFile := flag.String("status-file", "", "Write a compact one-line session status to this file every second (for stream overlays)")
+	sessionFile := flag.String("session-file", "", "Save session totals to this file and resume from it on restart")
+	autosave := flag.Duration("autosave", 30*time.Second, "How often to save the session to -session-file (0 = only on exit)")
+	strict := flag.Bool("strict", false, "Drop packets that fail CRC or length validation instead of parsing them best-effort")
+	validateCRC := flag.Bool("validate-crc", false, "Drop packets whose CRC doesn't match (e.g. corrupted on a noisy Wi-Fi link)")
+	compactStrings := flag.Bool("compact-strings", false, "Decode strings with a 7-bit length prefix (servers using the newer Protocol16.5 serialization; try it if names look garbled)")
+	profiling := flag.Bool("profile-parsing", false, "Time the processing of every packet and show the p50/p99/max in the status bar")
+	onlineTimeout := flag.Duration("online-timeout", capture.DefaultOnlineTimeout, "Show offline after this long without Albion traffic (raise it if the status flaps on high-latency connections)")
+	extraPorts := flag.String("extra-ports", "", "Comma-separated additional UDP ports to capture besides 5055/5056 (e.g. 5057,6000)")
+	chat := flag.Bool("chat", false, "Also capture TCP chat server traffic (port 4535) with stream reassembly")
+	saveDiscoveryCSV := flag.String("save-discovery-csv", "", "Track unknown events and save them to this CSV file on exit, most frequent first")
+	verboseCombat := flag.Bool("verbose-combat", false, "Show displacement/stealth events for nearby players, not only yourself")
+	eventLog := flag.String("event-log", "", "Append every game event to this file")
+	sqlitePath := flag.String("sqlite", "", "Store every game event in this SQLite database (created if missing)")
+	exportFormat := flag.String("export-format", string(backend.ExportFormatJSONL), "Event log format: jsonl, ao-loot-logger or binary")
+	reorderWindow := flag.Duration("reorder-window", 0, "Hold events this long (e.g. 20ms) and release them in receive order (0 = disabled)")
+	maxMessageLength := flag.Int("max-message-length", format.DefaultMaxMessageLength, "Truncate event messages longer than this many characters in the TUI and event log (0 = no limit)")
+	relationColors := flag.Bool("relationship-colors", false, "Color events by the relationship of the player involved (self, party, guild, hostile)")
+	jsonOutput := flag.Bool("json", false, "Run without the TUI, printing every event as a JSON line to stdout and the session report on exit")
+	maxFPS := flag.Int("fps", 0, "Redraw the TUI at most this many times per second, to save CPU on low-power devices (0 = unlimited)")
 	flag.Parse()
 
 	// List devices if requested
@@ -30,19 +66,128 @@ func main() {
 		return
 	}
 
+	// Validate an item database if requested
+	if *validateItems != "" {
+		report, err := items.Validate(*validateItems)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(report)
+		if !report.OK() {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Update the item database only on explicit request
+	if *updateItems {
+		if *itemsPath == "" {
+			*itemsPath = "ao-bin-dumps"
+		}
+		fmt.Printf("Downloading latest item data into %s...\n", *itemsPath)
+		fetched, err := items.FetchLatest(*itemsPath)
+		if err != nil {
+			// Non-fatal: the existing files are kept
+			fmt.Printf("Warning: item update failed, keeping existing data: %v\n", err)
+		}
+		for _, file := range fetched {
+			fmt.Printf("  %s (%d bytes, sha256 %s)\n", file.Path, file.Size, file.SHA256)
+		}
+	}
+
 	// Create backend service with options
 	opts := []backend.Option{
 		backend.WithDebug(*debug),
+		backend.WithStrictMode(*strict),
+		backend.WithCRCValidation(*validateCRC),
+		backend.WithCompactStrings(*compactStrings),
+		backend.WithProfiling(*profiling),
+		backend.WithChatCapture(*chat),
+		backend.WithVerboseCombat(*verboseCombat),
+		backend.WithDiscovery(*saveDiscoveryCSV != ""),
+		backend.WithEventReorderWindow(*reorderWindow),
+		backend.WithMaxMessageLength(*maxMessageLength),
+		backend.WithOnlineTimeout(*onlineTimeout),
+	}
+	if len(devices) > 0 {
+		opts = append(opts, backend.WithDevices(devices...))
 	}
-	if *deviceName != "" {
-		opts = append(opts, backend.WithDevice(*deviceName))
+	if *pcapFile != "" {
+		opts = append(opts,
+			backend.WithPcapFile(*pcapFile),
+			backend.WithReplayPaced(!*fast),
+			backend.WithReplaySpeed(*speed),
+			backend.WithReplayLoop(*loop),
+		)
+	}
+	if *savePcap != "" {
+		opts = append(opts, backend.WithPcapDump(*savePcap))
 	}
 	if *itemsPath != "" {
 		opts = append(opts, backend.WithItemDatabasePath(*itemsPath))
 	}
+	if *statusFile != "" {
+		opts = append(opts, backend.WithStatusFile(*statusFile))
+	}
+	if *metricsAddr != "" {
+		opts = append(opts, backend.WithMetricsServer(*metricsAddr))
+	}
+	if *wsAddr != "" {
+		opts = append(opts, backend.WithWebSocketServer(*wsAddr))
+		var origins []string
+		for _, origin := range strings.Split(*wsOrigins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				origins = append(origins, origin)
+			}
+		}
+		opts = append(opts, backend.WithWebSocketOrigins(origins...))
+	}
+	if *sessionFile != "" {
+		opts = append(opts, backend.WithAutosave(*autosave, *sessionFile))
+	}
+	if *extraPorts != "" {
+		ports, err := parsePorts(*extraPorts)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, backend.WithExtraPorts(ports))
+	}
+	if *eventLog != "" {
+		format, err := backend.ParseExportFormat(*exportFormat)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, backend.WithEventLogFile(*eventLog), backend.WithExportFormat(format))
+	}
+	if *sqlitePath != "" {
+		opts = append(opts, backend.WithSQLiteStore(*sqlitePath))
+	}
 
 	svc := backend.New(opts...)
 
+	if *saveDiscoveryCSV != "" {
+		// Deferred before Stop so it runs after it, with the final counts
+		defer func() {
+			if handler := svc.Handler(); handler != nil {
+				if err := handler.SaveDiscoveredEventsCSV(*saveDiscoveryCSV); err != nil {
+					fmt.Fprintf(os.Stderr, "Error saving discovered events: %v\n", err)
+				}
+			}
+		}()
+	}
+
+	// Headless mode: events go to stdout as JSON lines instead of the TUI
+	if *jsonOutput {
+		if err := runHeadless(svc, os.Stdout, *pcapFile != "" && !*loop); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting capture: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create channels for TUI communication
 	// Use a buffered channel for bulk messages
 	bulkEventChan := make(chan tui.BulkEventMsg, 5) // 5 batches of 50 = 250 events
@@ -125,7 +270,10 @@ func main() {
 	// Start backend service
 	if err := svc.Start(); err != nil {
 		fmt.Printf("Error starting capture: %v\n", err)
-		fmt.Println("Try running with sudo or as administrator.")
+		// Classified errors already carry an actionable hint
+		if !errors.Is(err, capture.ErrPcapUnavailable) && !errors.Is(err, capture.ErrPermissionDenied) {
+			fmt.Println("Try running with sudo or as administrator.")
+		}
 		os.Exit(1)
 	}
 	defer svc.Stop()
@@ -140,11 +288,50 @@ func main() {
 	}
 
 	// Create and run TUI
-	model := tui.New(svc, bulkEventChan, statsChan)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	model := tui.New(svc, bulkEventChan, statsChan).SetMaxFPS(*maxFPS).SetRelationshipColors(*relationColors)
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if *maxFPS > 0 {
+		programOpts = append(programOpts, tea.WithFPS(*maxFPS))
+	}
+	p := tea.NewProgram(model, programOpts...)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// deviceList is the -device flag, which may be given several times and/or comma-separated
+type deviceList []string
+
+// String implements flag.Value
+func (d *deviceList) String() string {
+	return strings.Join(*d, ",")
+}
+
+// Set implements flag.Value
+func (d *deviceList) Set(value string) error {
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			*d = append(*d, field)
+		}
+	}
+	return nil
+}
+
+// parsePorts parses a comma-separated list of UDP ports
+func parsePorts(list string) ([]uint16, error) {
+	var ports []uint16
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		port, err := strconv.ParseUint(field, 10, 16)
+		if err != nil || port == 0 {
+			return nil, fmt.Errorf("invalid port %q", field)
+		}
+		ports = append(ports, uint16(port))
+	}
+	return ports, nil
+}
@@ -0,0 +1,97 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cantalupo555/albion-lens/pkg/backend"
+)
+
+// DiagnosticsPanel displays capture and parser health
+type DiagnosticsPanel struct {
+	snapshot backend.DiagnosticsSnapshot
+	width    int
+	height   int
+}
+
+// NewDiagnosticsPanel creates a new DiagnosticsPanel component
+func NewDiagnosticsPanel() DiagnosticsPanel {
+	return DiagnosticsPanel{}
+}
+
+// SetSize updates the dimensions of the diagnostics panel
+func (d DiagnosticsPanel) SetSize(width, height int) DiagnosticsPanel {
+	d.width = width
+	d.height = height
+	return d
+}
+
+// Update sets the diagnostics snapshot to display
+func (d DiagnosticsPanel) Update(snapshot backend.DiagnosticsSnapshot) DiagnosticsPanel {
+	d.snapshot = snapshot
+	return d
+}
+
+// View renders the diagnostics panel
+func (d DiagnosticsPanel) View() string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Width(8)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255"))
+	okStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("42")).
+		Bold(true)
+	issueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	snap := d.snapshot
+	row := func(label, value string) string {
+		return fmt.Sprintf("%s %s", labelStyle.Render(label), valueStyle.Render(value))
+	}
+
+	status := okStyle.Render("Healthy")
+	if !snap.Healthy {
+		status = issueStyle.Render(fmt.Sprintf("%d issue(s)", len(snap.Issues)))
+	}
+
+	rows := []string{
+		status,
+		"",
+		row("Packets", fmt.Sprintf("%d", snap.PacketsReceived)),
+		row("Malform", fmt.Sprintf("%d", snap.PacketsMalformed)),
+		row("Strict", fmt.Sprintf("%d", snap.PacketsStrictRejected)),
+		row("CRC", fmt.Sprintf("%d fail", snap.PacketsCRCFailed)),
+		row("Encrypt", fmt.Sprintf("%d", snap.PacketsEncrypted)),
+		row("Dedup", fmt.Sprintf("%d", snap.PacketsDeduplicated)),
+		row("Frags", fmt.Sprintf("%d pending", snap.PendingFragments)),
+		row("Expired", fmt.Sprintf("%d", snap.FragmentsExpired)),
+		row("Dropped", fmt.Sprintf("%d", snap.EventsDropped)),
+		row("Throttle", fmt.Sprintf("%d", snap.EventsThrottled)),
+		row("Buffer", fmt.Sprintf("%d/%d", snap.BufferPeak, snap.BufferCapacity)),
+		row("Kernel", fmt.Sprintf("%d drop", snap.KernelDropped+snap.KernelIfDropped)),
+	}
+	for _, issue := range snap.Issues {
+		rows = append(rows, issueStyle.Render("! "+issue))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Width(d.width - 2).
+		Height(d.height - 2).
+		Padding(0, 1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("62")).
+		MarginBottom(1)
+
+	title := titleStyle.Render("Diagnostics")
+
+	return boxStyle.Render(
+		lipgloss.JoinVertical(lipgloss.Left, title, content),
+	)
+}
@@ -2,13 +2,13 @@ package components
 
 import (
 	"fmt"
-	"math"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cantalupo555/albion-lens/pkg/events"
+	"github.com/cantalupo555/albion-lens/pkg/format"
 	"github.com/cantalupo555/albion-lens/pkg/handlers"
 )
 
@@ -31,6 +31,11 @@ type EventLog struct {
 	height        int
 	ready         bool
 	fullNumbers   bool
+	maxLength     int // Maximum message length in runes (0 = no limit)
+
+	// Relationship coloring (disabled when relationOf is nil)
+	relationOf func(name string) handlers.Relationship
+	theme      RelationshipTheme
 }
 
 // NewEventLog creates a new EventLog component
@@ -39,6 +44,7 @@ func NewEventLog() EventLog {
 		events:        make([]Event, 0, maxEvents),
 		renderedLines: make([]string, 0, maxEvents),
 		fullNumbers:   true, // Default: show full numbers
+		maxLength:     format.DefaultMaxMessageLength,
 	}
 }
 
@@ -50,6 +56,22 @@ func (e EventLog) SetFullNumbers(full bool) EventLog {
 	return e
 }
 
+// SetMaxMessageLength sets the maximum message length in runes (0 = no limit)
+func (e EventLog) SetMaxMessageLength(length int) EventLog {
+	e.maxLength = length
+	e = e.reRenderAll()
+	return e
+}
+
+// SetRelationships colors events involving a player by their relationship to the
+// local player (self, party, guild, hostile) using theme. A nil relationOf disables it.
+func (e EventLog) SetRelationships(relationOf func(name string) handlers.Relationship, theme RelationshipTheme) EventLog {
+	e.relationOf = relationOf
+	e.theme = theme
+	e = e.reRenderAll()
+	return e
+}
+
 // SetSize updates the dimensions of the event log
 func (e EventLog) SetSize(width, height int) EventLog {
 	e.width = width
@@ -174,16 +196,43 @@ func (e EventLog) renderSingleEvent(event Event) string {
 		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 	case "loot":
 		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	case "reward":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	case "consume":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("111"))
+	case "social":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
+	case "match":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("177"))
+	case "chat":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	case "harvest":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	case "fishing":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	case "market":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("178"))
+	case "trade":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("180"))
 	case "combat", "kill", "death":
 		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	case "warning":
+		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
 	case "debug":
 		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	default:
 		msgStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
 	}
 
+	// Relationship colors take precedence over the event type color
+	if e.relationOf != nil {
+		if style, ok := e.theme.Style(e.relationOf(eventPlayer(event))); ok {
+			msgStyle = style
+		}
+	}
+
 	// Format message dynamically based on event data and fullNumbers setting
-	message := e.formatEventMessage(event)
+	message := format.Truncate(e.formatEventMessage(event), e.maxLength)
 
 	return fmt.Sprintf("%s %s",
 		timestampStyle.Render(event.Timestamp.Format("15:04:05")),
@@ -211,18 +260,119 @@ func (e EventLog) formatEventMessage(event Event) string {
 		}
 	case "loot":
 		if data, ok := event.Data.(*handlers.LootEventData); ok && data != nil {
+			itemName := data.ItemName
+			if data.EstimatedValue > 0 {
+				itemName += fmt.Sprintf(" (~%s)", formatNumber(data.EstimatedValue, e.fullNumbers))
+			}
 			return fmt.Sprintf("📦 %s looted %s (x%d) from %s",
 				data.LootedBy,
-				data.ItemName,
+				itemName,
 				data.Quantity,
 				data.LootedFrom)
 		}
+	case "reward":
+		if data, ok := event.Data.(*handlers.RewardEventData); ok && data != nil {
+			var parts []string
+			if data.Silver > 0 {
+				parts = append(parts, fmt.Sprintf("%s silver", formatNumber(data.Silver, e.fullNumbers)))
+			}
+			if data.Fame > 0 {
+				parts = append(parts, fmt.Sprintf("%s fame", formatNumber(data.Fame, e.fullNumbers)))
+			}
+			for _, item := range data.Items {
+				parts = append(parts, fmt.Sprintf("%s (x%d)", item.ItemName, item.Quantity))
+			}
+			return fmt.Sprintf("🎁 Reward: %s", strings.Join(parts, ", "))
+		}
+	case "combat":
+		if data, ok := event.Data.(*handlers.CombatEventData); ok && data != nil {
+			switch data.Kind {
+			case handlers.CombatForcedMovement:
+				return fmt.Sprintf("💨 %s was displaced", data.Name)
+			case handlers.CombatForcedMovementCancel:
+				return fmt.Sprintf("💨 %s displacement ended", data.Name)
+			case handlers.CombatCloak:
+				return fmt.Sprintf("👻 %s cloaked", data.Name)
+			case handlers.CombatUncloak:
+				return fmt.Sprintf("👻 %s uncloaked", data.Name)
+			case handlers.CombatMountLowHealth:
+				return fmt.Sprintf("🐎 %s's mount is below half health", data.Name)
+			case handlers.CombatMountDismounted:
+				return fmt.Sprintf("🐎 %s was dismounted", data.Name)
+			}
+		}
+		if data, ok := event.Data.(*handlers.DamageEventData); ok && data != nil {
+			return fmt.Sprintf("⚔️ %s hit %s for %.0f (%s)", data.Caster, data.Target, data.Amount, data.SpellName)
+		}
+	case "consume":
+		if data, ok := event.Data.(*handlers.ConsumeEventData); ok && data != nil {
+			return fmt.Sprintf("🧪 Used %s (x%d) | Session: %d", data.ItemName, data.Quantity, data.Session)
+		}
+	case "chest":
+		if data, ok := event.Data.(*handlers.ChestEventData); ok && data != nil {
+			if data.Action == handlers.ChestOpened {
+				return fmt.Sprintf("🧰 %s chest opened", data.Chest.Type)
+			}
+			return fmt.Sprintf("🧰 %s chest available (charge %d)", data.Chest.Type, data.Chest.ChargeLevel)
+		}
+	case "harvest":
+		if data, ok := event.Data.(*handlers.HarvestEventData); ok && data != nil {
+			return fmt.Sprintf("🌿 Gathered %s (x%d) | Session: %d", data.Resource, data.Amount, data.Session)
+		}
+	case "fishing":
+		if data, ok := event.Data.(*handlers.FishingEventData); ok && data != nil {
+			if !data.Caught {
+				return "🎣 The fish got away"
+			}
+			return fmt.Sprintf("🎣 Caught %s | Session: %d", data.ItemName, data.Session)
+		}
+	case "market":
+		if data, ok := event.Data.(*handlers.MarketEventData); ok && data != nil {
+			return fmt.Sprintf("📈 %s estimated at %s silver", data.ItemName, formatNumber(data.Value, e.fullNumbers))
+		}
+	case "trade":
+		if data, ok := event.Data.(*handlers.TradeEventData); ok && data != nil {
+			parts := []string{fmt.Sprintf("🤝 Traded with %s", data.Partner)}
+			if len(data.ItemsGiven) > 0 {
+				parts = append(parts, "gave "+formatTradeItems(data.ItemsGiven))
+			}
+			if len(data.ItemsReceived) > 0 {
+				parts = append(parts, "got "+formatTradeItems(data.ItemsReceived))
+			}
+			if data.SilverDelta != 0 {
+				sign := "+"
+				if data.SilverDelta < 0 {
+					sign = "-"
+				}
+				parts = append(parts, fmt.Sprintf("%s%s silver", sign, formatNumber(max(data.SilverDelta, -data.SilverDelta), e.fullNumbers)))
+			}
+			return strings.Join(parts, " | ")
+		}
+	case "chat":
+		if data, ok := event.Data.(*handlers.ChatEventData); ok && data != nil {
+			if data.Channel == handlers.ChatWhisper && data.Recipient != "" {
+				return fmt.Sprintf("💬 [whisper] %s → %s: %s", data.Sender, data.Recipient, data.Text)
+			}
+			return fmt.Sprintf("💬 [%s] %s: %s", data.Channel, data.Sender, data.Text)
+		}
 	case "kill":
 		if data, ok := event.Data.(*handlers.KillEventData); ok && data != nil {
+			switch {
+			case data.IsLocal && data.Victim != "":
+				return fmt.Sprintf("⚔️ You killed %s! (Session: %d kills)", data.Victim, data.SessionKills)
+			case !data.IsLocal && data.Killer != "" && data.Victim != "":
+				return fmt.Sprintf("⚔️ %s killed %s", data.Killer, data.Victim)
+			}
 			return fmt.Sprintf("⚔️ Player Killed! (Session: %d kills)", data.SessionKills)
 		}
 	case "death":
 		if data, ok := event.Data.(*handlers.DeathEventData); ok && data != nil {
+			if data.IsLocal {
+				if data.Killer != "" {
+					return fmt.Sprintf("💀 You died! (Killed by %s)", data.Killer)
+				}
+				return "💀 You died!"
+			}
 			if data.Killer != "" {
 				return fmt.Sprintf("💀 %s died! (Killed by %s)", data.Victim, data.Killer)
 			}
@@ -237,20 +387,18 @@ func (e EventLog) formatEventMessage(event Event) string {
 	return event.Message
 }
 
+// formatTradeItems lists traded items with their quantities
+func formatTradeItems(items []handlers.LootEventData) string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = fmt.Sprintf("%s (x%d)", item.ItemName, item.Quantity)
+	}
+	return strings.Join(names, ", ")
+}
+
 // formatNumber formats a number based on fullNumbers setting
 func formatNumber(amount int64, full bool) string {
-	if full {
-		return fmt.Sprintf("%d", amount)
-	}
-	// Abbreviated format with truncation (floor) instead of rounding
-	if amount >= 1000000 {
-		val := math.Floor(float64(amount)/100000.0) / 10.0
-		return fmt.Sprintf("%.1fM", val)
-	} else if amount >= 1000 {
-		val := math.Floor(float64(amount)/100.0) / 10.0
-		return fmt.Sprintf("%.1fk", val)
-	}
-	return fmt.Sprintf("%d", amount)
+	return format.Number(amount, full)
 }
 
 // ScrollUp scrolls the viewport up
@@ -0,0 +1,188 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+)
+
+// TestFormatLootEstimatedValue tests the loot line with and without a known market value
+func TestFormatLootEstimatedValue(t *testing.T) {
+	loot := func(value int64) Event {
+		return Event{Type: "loot", Data: &handlers.LootEventData{
+			LootedBy:       "Me",
+			ItemName:       "T6 Sword",
+			Quantity:       1,
+			LootedFrom:     "Mob",
+			EstimatedValue: value,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		value       int64
+		fullNumbers bool
+		expected    string
+	}{
+		{"abbreviated value", 12345, false, "📦 Me looted T6 Sword (~12.3k) (x1) from Mob"},
+		{"full value", 12345, true, "📦 Me looted T6 Sword (~12345) (x1) from Mob"},
+		{"unknown value", 0, false, "📦 Me looted T6 Sword (x1) from Mob"},
+	}
+
+	for _, tt := range tests {
+		e := NewEventLog().SetFullNumbers(tt.fullNumbers)
+		if got := e.formatEventMessage(loot(tt.value)); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+// TestFormatChat tests chat lines for channel messages and whispers
+func TestFormatChat(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *handlers.ChatEventData
+		expected string
+	}{
+		{"guild", &handlers.ChatEventData{Sender: "Ally", Channel: handlers.ChatGuild, Text: "hi"}, "💬 [guild] Ally: hi"},
+		{"whisper", &handlers.ChatEventData{Sender: "Ally", Recipient: "Me", Channel: handlers.ChatWhisper, Text: "psst"}, "💬 [whisper] Ally → Me: psst"},
+		{"whisper without recipient", &handlers.ChatEventData{Sender: "Ally", Channel: handlers.ChatWhisper, Text: "psst"}, "💬 [whisper] Ally: psst"},
+	}
+
+	for _, tt := range tests {
+		if got := NewEventLog().formatEventMessage(Event{Type: "chat", Data: tt.data}); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+// TestFormatHarvest tests the gathered resources line
+func TestFormatHarvest(t *testing.T) {
+	event := Event{Type: "harvest", Data: &handlers.HarvestEventData{Resource: "Iron Ore", Amount: 5, Session: 42}}
+
+	expected := "🌿 Gathered Iron Ore (x5) | Session: 42"
+	if got := NewEventLog().formatEventMessage(event); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestFormatMarket tests the estimated market value line
+func TestFormatMarket(t *testing.T) {
+	event := Event{Type: "market", Data: &handlers.MarketEventData{ItemName: "Adept's Bag", ItemID: 1234, Value: 1500}}
+
+	expected := "📈 Adept's Bag estimated at 1500 silver"
+	if got := NewEventLog().formatEventMessage(event); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestFormatTrade tests the trade line with items both ways and silver
+func TestFormatTrade(t *testing.T) {
+	event := Event{Type: "trade", Data: &handlers.TradeEventData{
+		Partner:       "Merchant",
+		ItemsGiven:    []handlers.LootEventData{{ItemName: "Adept's Bag", Quantity: 2}},
+		ItemsReceived: []handlers.LootEventData{{ItemName: "Trout", Quantity: 5}},
+		SilverDelta:   -1500,
+	}}
+
+	expected := "🤝 Traded with Merchant | gave Adept's Bag (x2) | got Trout (x5) | -1500 silver"
+	if got := NewEventLog().formatEventMessage(event); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestFormatFishing tests the fishing lines for a catch and a fish that got away
+func TestFormatFishing(t *testing.T) {
+	tests := []struct {
+		data     *handlers.FishingEventData
+		expected string
+	}{
+		{&handlers.FishingEventData{ItemID: 1, ItemName: "Trout", Caught: true, Session: 3}, "🎣 Caught Trout | Session: 3"},
+		{&handlers.FishingEventData{Session: 3}, "🎣 The fish got away"},
+	}
+
+	for _, tt := range tests {
+		if got := NewEventLog().formatEventMessage(Event{Type: "fishing", Data: tt.data}); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+// TestFormatDamage tests the line for a spell hit
+func TestFormatDamage(t *testing.T) {
+	event := Event{Type: "combat", Data: &handlers.DamageEventData{Caster: "Lens", Target: "Bandit", Amount: 152.6, SpellName: "Flameshield"}}
+
+	expected := "⚔️ Lens hit Bandit for 153 (Flameshield)"
+	if got := NewEventLog().formatEventMessage(event); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestFormatKillDeath tests that kills and deaths name the local player as "you"
+func TestFormatKillDeath(t *testing.T) {
+	tests := []struct {
+		event    Event
+		expected string
+	}{
+		{Event{Type: "kill", Data: &handlers.KillEventData{SessionKills: 2, Killer: "Me", Victim: "Bandit", IsLocal: true}}, "⚔️ You killed Bandit! (Session: 2 kills)"},
+		{Event{Type: "kill", Data: &handlers.KillEventData{SessionKills: 2, Killer: "Bandit", Victim: "Other"}}, "⚔️ Bandit killed Other"},
+		{Event{Type: "kill", Data: &handlers.KillEventData{SessionKills: 2}}, "⚔️ Player Killed! (Session: 2 kills)"},
+		{Event{Type: "death", Data: &handlers.DeathEventData{Victim: "Me", Killer: "Bandit", IsLocal: true}}, "💀 You died! (Killed by Bandit)"},
+		{Event{Type: "death", Data: &handlers.DeathEventData{Victim: "Other", Killer: "Bandit"}}, "💀 Other died! (Killed by Bandit)"},
+	}
+
+	for _, tt := range tests {
+		if got := NewEventLog().formatEventMessage(tt.event); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+// TestRelationshipThemeStyle tests the style chosen for each relationship
+func TestRelationshipThemeStyle(t *testing.T) {
+	theme := DefaultRelationshipTheme
+
+	tests := []struct {
+		relationship handlers.Relationship
+		color        lipgloss.Color
+		styled       bool
+	}{
+		{handlers.RelationshipSelf, theme.Self, true},
+		{handlers.RelationshipParty, theme.Party, true},
+		{handlers.RelationshipGuild, theme.Guild, true},
+		{handlers.RelationshipHostile, theme.Hostile, true},
+		{handlers.RelationshipNeutral, "", false},
+	}
+
+	for _, tt := range tests {
+		style, ok := theme.Style(tt.relationship)
+		if ok != tt.styled {
+			t.Errorf("%v: expected styled=%v, got %v", tt.relationship, tt.styled, ok)
+			continue
+		}
+		if ok && style.GetForeground() != tt.color {
+			t.Errorf("%v: expected color %v, got %v", tt.relationship, tt.color, style.GetForeground())
+		}
+	}
+}
+
+// TestEventPlayer tests which player an event is attributed to for relationship coloring
+func TestEventPlayer(t *testing.T) {
+	tests := []struct {
+		event    Event
+		expected string
+	}{
+		{Event{Type: "loot", Data: &handlers.LootEventData{LootedBy: "Looter"}}, "Looter"},
+		{Event{Type: "combat", Data: &handlers.CombatEventData{Name: "Fighter"}}, "Fighter"},
+		{Event{Type: "death", Data: &handlers.DeathEventData{Victim: "Victim", Killer: "Killer"}}, "Victim"},
+		{Event{Type: "chat", Data: &handlers.ChatEventData{Sender: "Talker"}}, "Talker"},
+		{Event{Type: "info", Message: "no player"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := eventPlayer(tt.event); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.event.Type, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package components
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+)
+
+// RelationshipTheme maps player relationships to event colors
+type RelationshipTheme struct {
+	Self    lipgloss.Color
+	Party   lipgloss.Color
+	Guild   lipgloss.Color
+	Hostile lipgloss.Color
+}
+
+// DefaultRelationshipTheme is the default relationship color mapping
+var DefaultRelationshipTheme = RelationshipTheme{
+	Self:    lipgloss.Color("51"),  // Bright cyan
+	Party:   lipgloss.Color("46"),  // Bright green
+	Guild:   lipgloss.Color("33"),  // Blue
+	Hostile: lipgloss.Color("160"), // Dark red
+}
+
+// Style returns the style for a relationship, and false for neutral players
+// (whose events keep the event type color)
+func (t RelationshipTheme) Style(relationship handlers.Relationship) (lipgloss.Style, bool) {
+	var color lipgloss.Color
+	switch relationship {
+	case handlers.RelationshipSelf:
+		color = t.Self
+	case handlers.RelationshipParty:
+		color = t.Party
+	case handlers.RelationshipGuild:
+		color = t.Guild
+	case handlers.RelationshipHostile:
+		color = t.Hostile
+	default:
+		return lipgloss.Style{}, false
+	}
+	return lipgloss.NewStyle().Foreground(color).Bold(relationship == handlers.RelationshipHostile), true
+}
+
+// eventPlayer returns the name of the player an event is about, empty if none
+func eventPlayer(event Event) string {
+	switch data := event.Data.(type) {
+	case *handlers.LootEventData:
+		return data.LootedBy
+	case *handlers.SilverEventData:
+		return data.LootedBy
+	case *handlers.CombatEventData:
+		return data.Name
+	case *handlers.DeathEventData:
+		return data.Victim
+	case *handlers.SocialEventData:
+		return data.From
+	case *handlers.ChatEventData:
+		return data.Sender
+	case *handlers.DamageEventData:
+		return data.Caster
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package components
+
+// sparklineLevels are the block characters used to render a sparkline, lowest to highest
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the most recent values as a unicode sparkline of at most width characters.
+// Values are scaled relative to the largest visible value; zero and negative values
+// render as the lowest level.
+func Sparkline(values []int64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	var maxVal int64
+	for _, v := range values {
+		maxVal = max(maxVal, v)
+	}
+
+	top := int64(len(sparklineLevels) - 1)
+	result := make([]rune, len(values))
+	for i, v := range values {
+		level := int64(0)
+		if maxVal > 0 && v > 0 {
+			level = v * top / maxVal
+		}
+		result[i] = sparklineLevels[level]
+	}
+	return string(result)
+}
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestSparkline tests character selection for a series of values
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int64
+		width    int
+		expected string
+	}{
+		{"ascending", []int64{0, 1, 2, 3, 4, 5, 6, 7}, 10, "▁▂▃▄▅▆▇█"},
+		{"scaled to max", []int64{0, 50, 100}, 10, "▁▄█"},
+		{"all zero", []int64{0, 0, 0}, 10, "▁▁▁"},
+		{"negative as lowest", []int64{-5, 10}, 10, "▁█"},
+		{"truncated to most recent", []int64{100, 0, 7, 14}, 3, "▁▄█"},
+		{"empty", nil, 10, ""},
+		{"zero width", []int64{1, 2}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		result := Sparkline(tt.values, tt.width)
+		if result != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, result)
+		}
+	}
+}
+
+// TestSparklineLength tests that the sparkline never exceeds the width
+func TestSparklineLength(t *testing.T) {
+	values := make([]int64, 50)
+	for i := range values {
+		values[i] = int64(i)
+	}
+
+	for _, width := range []int{1, 5, 20, 50, 80} {
+		result := Sparkline(values, width)
+		expected := min(width, len(values))
+		if n := utf8.RuneCountInString(result); n != expected {
+			t.Errorf("width %d: expected %d characters, got %d", width, expected, n)
+		}
+	}
+}
+
+// TestStatsPanelTrend tests that the stats panel rotates fame/silver gains into trend buckets
+func TestStatsPanelTrend(t *testing.T) {
+	start := time.Now()
+	panel := NewStatsPanel().SetSize(40, 20)
+
+	panel = panel.Tick(start)
+	panel = panel.SetFame(1000).SetSilver(500)
+	panel = panel.Tick(start.Add(TrendInterval / 2)) // interval not elapsed yet
+	panel = panel.Tick(start.Add(TrendInterval))
+	panel = panel.SetFame(1500)
+	panel = panel.Tick(start.Add(2 * TrendInterval))
+
+	if len(panel.fameHistory) != 2 || panel.fameHistory[0] != 1000 || panel.fameHistory[1] != 500 {
+		t.Errorf("expected fame history [1000 500], got %v", panel.fameHistory)
+	}
+	if len(panel.silverHistory) != 2 || panel.silverHistory[0] != 500 || panel.silverHistory[1] != 0 {
+		t.Errorf("expected silver history [500 0], got %v", panel.silverHistory)
+	}
+
+	for i := 0; i < TrendIntervals+5; i++ {
+		panel = panel.Tick(start.Add(time.Duration(i+3) * TrendInterval))
+	}
+	if len(panel.fameHistory) != TrendIntervals {
+		t.Errorf("expected history capped at %d, got %d", TrendIntervals, len(panel.fameHistory))
+	}
+
+	panel = panel.Reset()
+	if panel.fameHistory != nil || panel.silverHistory != nil {
+		t.Error("expected history cleared after reset")
+	}
+}
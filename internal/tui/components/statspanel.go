@@ -3,8 +3,30 @@ package components
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/cantalupo555/albion-lens/pkg/format"
+)
+
+const (
+	// TrendInterval is the duration of each sparkline bucket
+	TrendInterval = time.Minute
+	// TrendIntervals is the number of buckets kept for the sparkline
+	TrendIntervals = 30
+
+	// minSparklineWidth is the narrowest sparkline worth drawing; below it, plain numbers are shown
+	minSparklineWidth = 4
+)
+
+// StatsViewMode selects what the stats panel shows
+type StatsViewMode int
+
+const (
+	// StatsViewTotals shows session totals
+	StatsViewTotals StatsViewMode = iota
+	// StatsViewRates shows per-hour rates over the session
+	StatsViewRates
 )
 
 // StatsPanel displays session statistics
@@ -17,6 +39,18 @@ type StatsPanel struct {
 	width       int
 	height      int
 	fullNumbers bool
+	viewMode    StatsViewMode
+
+	// Session clock for per-hour rates (advanced by Tick)
+	sessionStart time.Time
+	now          time.Time
+
+	// Trend history: amount gained per TrendInterval, oldest first
+	fameHistory    []int64
+	silverHistory  []int64
+	intervalStart  time.Time
+	intervalFame   int64 // Fame total at the start of the current interval
+	intervalSilver int64 // Silver total at the start of the current interval
 }
 
 // NewStatsPanel creates a new StatsPanel component
@@ -32,6 +66,12 @@ func (s StatsPanel) SetFullNumbers(full bool) StatsPanel {
 	return s
 }
 
+// SetViewMode sets whether totals or per-hour rates are displayed
+func (s StatsPanel) SetViewMode(mode StatsViewMode) StatsPanel {
+	s.viewMode = mode
+	return s
+}
+
 // SetSize updates the dimensions of the stats panel
 func (s StatsPanel) SetSize(width, height int) StatsPanel {
 	s.width = width
@@ -69,18 +109,67 @@ func (s StatsPanel) IncrKills() StatsPanel {
 	return s
 }
 
+// SetKills sets the session kill count
+func (s StatsPanel) SetKills(kills int) StatsPanel {
+	s.kills = kills
+	return s
+}
+
 // IncrDeaths increments the death counter
 func (s StatsPanel) IncrDeaths() StatsPanel {
 	s.deaths++
 	return s
 }
 
+// SetDeaths sets the session death count
+func (s StatsPanel) SetDeaths(deaths int) StatsPanel {
+	s.deaths = deaths
+	return s
+}
+
 // IncrLoot increments the loot counter
 func (s StatsPanel) IncrLoot() StatsPanel {
 	s.lootCount++
 	return s
 }
 
+// Tick advances the session clock and rotates the trend buckets once the current interval has elapsed
+func (s StatsPanel) Tick(now time.Time) StatsPanel {
+	if s.sessionStart.IsZero() {
+		s.sessionStart = now
+	}
+	s.now = now
+
+	if s.intervalStart.IsZero() {
+		s.intervalStart = now
+		s.intervalFame = s.fame
+		s.intervalSilver = s.silver
+		return s
+	}
+	if now.Sub(s.intervalStart) < TrendInterval {
+		return s
+	}
+
+	s.fameHistory = appendTrend(s.fameHistory, max(s.fame-s.intervalFame, 0))
+	s.silverHistory = appendTrend(s.silverHistory, max(s.silver-s.intervalSilver, 0))
+
+	s.intervalStart = now
+	s.intervalFame = s.fame
+	s.intervalSilver = s.silver
+	return s
+}
+
+// appendTrend appends a value to a trend history, keeping at most TrendIntervals values.
+// A new slice is returned so copies of the panel never share history.
+func appendTrend(history []int64, value int64) []int64 {
+	if len(history) >= TrendIntervals {
+		history = history[len(history)-TrendIntervals+1:]
+	}
+	result := make([]int64, 0, len(history)+1)
+	result = append(result, history...)
+	return append(result, value)
+}
+
 // Reset clears all session stats
 func (s StatsPanel) Reset() StatsPanel {
 	s.fame = 0
@@ -88,6 +177,11 @@ func (s StatsPanel) Reset() StatsPanel {
 	s.kills = 0
 	s.deaths = 0
 	s.lootCount = 0
+	s.fameHistory = nil
+	s.silverHistory = nil
+	s.intervalStart = time.Time{}
+	s.sessionStart = time.Time{}
+	s.now = time.Time{}
 	return s
 }
 
@@ -152,6 +246,52 @@ func (s StatsPanel) View() string {
 			lootValueStyle.Render(fmt.Sprintf("%d items", s.lootCount)),
 		),
 	}
+	title := "Session Stats"
+
+	if s.viewMode == StatsViewRates {
+		elapsed := s.Elapsed()
+		rows = []string{
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Fame/h"),
+				fameValueStyle.Render(formatNum(format.PerHour(s.fame, elapsed))),
+			),
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Silver/h"),
+				silverValueStyle.Render(formatNum(format.PerHour(s.silver, elapsed))),
+			),
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Kills/h"),
+				killsValueStyle.Render(fmt.Sprintf("%d", format.PerHour(int64(s.kills), elapsed))),
+			),
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Deaths/h"),
+				deathsValueStyle.Render(fmt.Sprintf("%d", format.PerHour(int64(s.deaths), elapsed))),
+			),
+		}
+		title = "Session Rates"
+	}
+
+	// Trend rows: sparkline of gains per interval, or the last interval as a plain number if narrow
+	// Content width excludes border (2), padding (2), label (8) and separator (1)
+	sparkWidth := s.width - 13
+	trend := func(history []int64) string {
+		if sparkWidth < minSparklineWidth {
+			return formatNum(history[len(history)-1]) + "/min"
+		}
+		return Sparkline(history, sparkWidth)
+	}
+	if len(s.fameHistory) > 0 {
+		rows = append(rows,
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Fame/m"),
+				fameValueStyle.Render(trend(s.fameHistory)),
+			),
+			fmt.Sprintf("%s %s",
+				labelStyle.Render("Silv/m"),
+				silverValueStyle.Render(trend(s.silverHistory)),
+			),
+		)
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
@@ -167,13 +307,16 @@ func (s StatsPanel) View() string {
 		Foreground(lipgloss.Color("62")).
 		MarginBottom(1)
 
-	title := titleStyle.Render("Session Stats")
-
 	return boxStyle.Render(
-		lipgloss.JoinVertical(lipgloss.Left, title, content),
+		lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render(title), content),
 	)
 }
 
+// Elapsed returns the session time covered by the panel's stats
+func (s StatsPanel) Elapsed() time.Duration {
+	return s.now.Sub(s.sessionStart)
+}
+
 // formatAbbreviated formats a number in abbreviated form (e.g., 4.9k, 1.3M)
 func formatAbbreviated(amount int64) string {
 	absAmount := amount
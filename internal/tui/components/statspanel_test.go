@@ -0,0 +1,78 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStatsTestPanel creates a panel with a known session snapshot over 30 minutes
+func newStatsTestPanel() StatsPanel {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	p := NewStatsPanel().SetSize(40, 20)
+	p = p.Tick(start)
+	p = p.SetFame(6000).SetSilver(50000)
+	p = p.IncrKills().IncrKills().IncrDeaths().IncrLoot()
+	return p.Tick(start.Add(30 * time.Minute))
+}
+
+// TestStatsPanelTotalsView tests the content of the totals view
+func TestStatsPanelTotalsView(t *testing.T) {
+	view := newStatsTestPanel().View()
+
+	for _, want := range []string{"Session Stats", "+6000", "+50000", "Kills", "Deaths", "1 items"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected totals view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "/h") {
+		t.Errorf("expected no rates in totals view, got:\n%s", view)
+	}
+}
+
+// TestStatsPanelRatesView tests the content of the rates view
+func TestStatsPanelRatesView(t *testing.T) {
+	p := newStatsTestPanel().SetViewMode(StatsViewRates)
+	if p.Elapsed() != 30*time.Minute {
+		t.Fatalf("expected 30m elapsed, got %v", p.Elapsed())
+	}
+
+	view := p.View()
+	for _, want := range []string{"Session Rates", "Fame/h", "+12000", "Silver/h", "+100000", "Kills/h", "4", "Deaths/h", "2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected rates view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "+6000") {
+		t.Errorf("expected no totals in rates view, got:\n%s", view)
+	}
+}
+
+// TestStatsPanelRatesAbbreviated tests abbreviated rates
+func TestStatsPanelRatesAbbreviated(t *testing.T) {
+	view := newStatsTestPanel().SetFullNumbers(false).SetViewMode(StatsViewRates).View()
+
+	for _, want := range []string{"+12.0k", "+100.0k"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected rates view to contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+// TestStatsPanelRatesWithoutElapsed tests that rates are zero before the session clock starts
+func TestStatsPanelRatesWithoutElapsed(t *testing.T) {
+	p := NewStatsPanel().SetSize(40, 20).SetFame(6000).SetViewMode(StatsViewRates)
+
+	if view := p.View(); !strings.Contains(view, "+0") {
+		t.Errorf("expected zero rate without elapsed time, got:\n%s", view)
+	}
+}
+
+// TestStatsPanelResetClock tests that Reset restarts the session clock
+func TestStatsPanelResetClock(t *testing.T) {
+	p := newStatsTestPanel().Reset()
+	if p.Elapsed() != 0 {
+		t.Errorf("expected 0 elapsed after reset, got %v", p.Elapsed())
+	}
+}
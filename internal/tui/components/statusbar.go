@@ -2,8 +2,10 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/cantalupo555/albion-lens/pkg/backend"
 	"github.com/cantalupo555/albion-lens/pkg/photon"
 )
 
@@ -14,9 +16,18 @@ type StatusBar struct {
 	packetsPerSec  float64
 	eventsDecoded  uint64
 	eventsDropped  uint64
+	dropSeverity   backend.DropSeverity
 	bufferUsage    int
 	bufferCapacity int
 	uptime         string
+	mountHealth    float32
+	mountMaxHealth float32
+	inCombat       bool
+	zone           string
+	profiled       bool // Whether processing times were recorded (profiling enabled)
+	processingP50  time.Duration
+	processingP99  time.Duration
+	processingMax  time.Duration
 	width          int
 }
 
@@ -39,6 +50,31 @@ func (s StatusBar) SetOnline(online bool) StatusBar {
 	return s
 }
 
+// SetMountHealth updates the mount health indicator (hidden when maxHealth is 0)
+func (s StatusBar) SetMountHealth(health, maxHealth float32) StatusBar {
+	s.mountHealth = health
+	s.mountMaxHealth = maxHealth
+	return s
+}
+
+// SetInCombat updates the combat indicator (hidden out of combat)
+func (s StatusBar) SetInCombat(inCombat bool) StatusBar {
+	s.inCombat = inCombat
+	return s
+}
+
+// SetZone updates the current zone name (hidden when unknown)
+func (s StatusBar) SetZone(zone string) StatusBar {
+	s.zone = zone
+	return s
+}
+
+// SetDropSeverity updates the severity of recent event drops
+func (s StatusBar) SetDropSeverity(severity backend.DropSeverity) StatusBar {
+	s.dropSeverity = severity
+	return s
+}
+
 // UpdateStats updates the stats display
 func (s StatusBar) UpdateStats(stats *photon.Stats) StatusBar {
 	if stats != nil {
@@ -49,6 +85,10 @@ func (s StatusBar) UpdateStats(stats *photon.Stats) StatusBar {
 		s.bufferUsage = int(stats.BufferPeakDisplay)
 		s.bufferCapacity = stats.BufferCapacity
 		s.uptime = stats.FormatUptime()
+		s.profiled = stats.ProcessingCount() > 0
+		s.processingP50 = stats.ProcessingP50()
+		s.processingP99 = stats.ProcessingP99()
+		s.processingMax = stats.ProcessingMax()
 	}
 	return s
 }
@@ -84,28 +124,79 @@ func (s StatusBar) View() string {
 		bufStatus = fmt.Sprintf("│  Queue: %s", bufStyle.Render(fmt.Sprintf("%d/%d (%.0f%%)", s.bufferUsage, s.bufferCapacity, pct)))
 	}
 
+	// Mount health indicator, only while mounted
+	var mountStatus string
+	if s.mountMaxHealth > 0 {
+		pct := float64(s.mountHealth) / float64(s.mountMaxHealth) * 100
+		mountColor := "42" // Green
+		if pct < 25 {
+			mountColor = "196" // Red
+		} else if pct < 50 {
+			mountColor = "214" // Yellow
+		}
+
+		mountStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(mountColor))
+		mountStatus = fmt.Sprintf("│  🐎 %s", mountStyle.Render(fmt.Sprintf("%.0f%%", pct)))
+	}
+
+	// Combat indicator, only while in combat
+	var combatStatus string
+	if s.inCombat {
+		combatStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		combatStatus = fmt.Sprintf("│  %s", combatStyle.Render("⚔️ Combat"))
+	}
+
+	// Current zone, once known
+	var zoneStatus string
+	if s.zone != "" {
+		zoneStatus = fmt.Sprintf("│  🗺️ %s", s.zone)
+	}
+
+	// Packet processing times, only when profiling
+	var profileStatus string
+	if s.profiled {
+		profileStatus = fmt.Sprintf("│  Parse p50/p99/max: %s/%s/%s",
+			formatLatency(s.processingP50), formatLatency(s.processingP99), formatLatency(s.processingMax))
+	}
+
 	// Stats
 	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
 
 	// Format events with drop warning if needed
 	eventsDisplay := fmt.Sprintf("Events: %d", s.eventsDecoded)
 	if s.eventsDropped > 0 {
-		// RED with warning icon when drops detected
+		// Color by recent drop rate: gray for old drops, yellow for a blip, red when persistent
+		dropColor := "245" // Gray
+		switch s.dropSeverity {
+		case backend.DropSeverityWarning:
+			dropColor = "214" // Yellow
+		case backend.DropSeverityCritical:
+			dropColor = "196" // Red
+		}
 		dropStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")). // Red
-			Bold(true)
+			Foreground(lipgloss.Color(dropColor)).
+			Bold(s.dropSeverity != backend.DropSeverityOK)
+
+		dropText := fmt.Sprintf("⚠ Dropped: %d", s.eventsDropped)
+		if s.dropSeverity == backend.DropSeverityCritical {
+			dropText += fmt.Sprintf(" (%s)", s.dropSeverity.Suggestion())
+		}
 		eventsDisplay = fmt.Sprintf("Events: %d  %s",
 			s.eventsDecoded,
-			dropStyle.Render(fmt.Sprintf("⚠ Dropped: %d", s.eventsDropped)))
+			dropStyle.Render(dropText))
 	}
 
 	stats := statsStyle.Render(fmt.Sprintf(
-		"Packets: %d (%.1f/s)  │  %s  │  %s  %s",
+		"Packets: %d (%.1f/s)  │  %s  │  %s  %s  %s  %s  %s  %s",
 		s.packetsTotal,
 		s.packetsPerSec,
 		eventsDisplay,
 		s.uptime,
 		bufStatus, // Append buffer status at the end
+		zoneStatus,
+		mountStatus,
+		combatStatus,
+		profileStatus,
 	))
 
 	// Combine
@@ -131,3 +222,14 @@ func (s StatusBar) View() string {
 		BorderRight(true).
 		Render(title + "\n" + content)
 }
+
+// formatLatency formats a processing time with a unit suited to its magnitude (e.g. 12µs, 1.5ms)
+func formatLatency(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -2,12 +2,13 @@ package tui
 
 import (
 	"fmt"
-	"math"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cantalupo555/albion-lens/internal/tui/components"
 	"github.com/cantalupo555/albion-lens/pkg/backend"
+	"github.com/cantalupo555/albion-lens/pkg/format"
 	"github.com/cantalupo555/albion-lens/pkg/handlers"
 	"github.com/cantalupo555/albion-lens/pkg/photon"
 )
@@ -17,6 +18,7 @@ type Model struct {
 	statusBar  components.StatusBar
 	eventLog   components.EventLog
 	statsPanel components.StatsPanel
+	diagPanel  components.DiagnosticsPanel
 
 	// Backend service reference for runtime control
 	svc *backend.Service
@@ -33,7 +35,14 @@ type Model struct {
 	ready    bool
 
 	// Display settings
-	fullNumbers bool // Show full numbers instead of abbreviated (e.g., 4984 vs 4.9k)
+	fullNumbers     bool                     // Show full numbers instead of abbreviated (e.g., 4984 vs 4.9k)
+	showDiagnostics bool                     // Show the diagnostics panel instead of session stats
+	statsView       components.StatsViewMode // Session totals or per-hour rates in the stats panel
+
+	// Render throttling (low-power devices): the view is rendered into frame
+	// at most once per throttle interval and View returns the cached frame
+	throttle renderThrottle
+	frame    string
 }
 
 // New creates a new TUI Model
@@ -42,6 +51,7 @@ func New(svc *backend.Service, bulkEventChan chan BulkEventMsg, statsChan chan *
 		statusBar:     components.NewStatusBar(),
 		eventLog:      components.NewEventLog(),
 		statsPanel:    components.NewStatsPanel(),
+		diagPanel:     components.NewDiagnosticsPanel(),
 		svc:           svc,
 		bulkEventChan: bulkEventChan,
 		statsChan:     statsChan,
@@ -50,16 +60,40 @@ func New(svc *backend.Service, bulkEventChan chan BulkEventMsg, statsChan chan *
 	// Sync debug state from service
 	if svc != nil {
 		m.debug = svc.IsDebug()
+		m.eventLog = m.eventLog.SetMaxMessageLength(svc.MaxMessageLength())
 	}
 	return m
 }
 
+// SetRelationshipColors enables coloring events by the relationship of the player
+// involved (self, party, guild, hostile) to the local player
+func (m Model) SetRelationshipColors(enabled bool) Model {
+	if enabled && m.svc != nil {
+		m.eventLog = m.eventLog.SetRelationships(m.svc.Relationship, components.DefaultRelationshipTheme)
+	} else {
+		m.eventLog = m.eventLog.SetRelationships(nil, components.DefaultRelationshipTheme)
+	}
+	return m
+}
+
+// SetMaxFPS limits how often the view is re-rendered (fps <= 0 renders on every update).
+// Updates within the same frame are coalesced into a single render.
+func (m Model) SetMaxFPS(fps int) Model {
+	m.throttle = newRenderThrottle(fps)
+	return m
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		TickCmd(), // Start the tick timer
 	}
 
+	// Drive throttled renders
+	if m.throttle.enabled() {
+		cmds = append(cmds, RenderTickCmd(m.throttle.interval))
+	}
+
 	// Listen for events if channel provided
 	if m.bulkEventChan != nil {
 		cmds = append(cmds, WaitForBulkEvent(m.bulkEventChan))
@@ -75,6 +109,35 @@ func (m Model) Init() tea.Cmd {
 
 // Update handles messages and updates the model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !m.throttle.enabled() {
+		return m.update(msg)
+	}
+
+	now := time.Now()
+	if _, ok := msg.(RenderTickMsg); ok {
+		if m.throttle.tick(now) {
+			m.frame = m.render()
+		}
+		return m, RenderTickCmd(m.throttle.interval)
+	}
+
+	// User input and resizes render immediately; everything else is coalesced
+	var immediate bool
+	switch msg.(type) {
+	case tea.KeyMsg, tea.WindowSizeMsg:
+		immediate = true
+	}
+
+	updated, cmd := m.update(msg)
+	m = updated.(Model)
+	if m.throttle.change(now, immediate) {
+		m.frame = m.render()
+	}
+	return m, cmd
+}
+
+// update applies a message to the model
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -107,10 +170,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.fullNumbers = !m.fullNumbers
 			m.statsPanel = m.statsPanel.SetFullNumbers(m.fullNumbers)
 			m.eventLog = m.eventLog.SetFullNumbers(m.fullNumbers)
+			// Propagate to backend service (compact status line)
+			if m.svc != nil {
+				m.svc.SetFullNumbers(m.fullNumbers)
+			}
+			return m, nil
+		case "i", "I":
+			m.showDiagnostics = !m.showDiagnostics
+			m = m.refreshDiagnostics()
+			return m, nil
+		case "m", "M":
+			m = m.markSession()
+			return m, nil
+		case "p", "P":
+			m = m.tracePacket()
 			return m, nil
 		case "r", "R":
 			m.statsPanel = m.statsPanel.Reset()
 			return m, nil
+		case "v", "V":
+			if m.statsView == components.StatsViewTotals {
+				m.statsView = components.StatsViewRates
+			} else {
+				m.statsView = components.StatsViewTotals
+			}
+			m.statsPanel = m.statsPanel.SetViewMode(m.statsView)
+			return m, nil
 		case "up", "k":
 			m.eventLog = m.eventLog.ScrollUp()
 			return m, nil
@@ -147,10 +232,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			case "loot":
 				m.statsPanel = m.statsPanel.IncrLoot()
+			case "reward":
+				if data, ok := eventMsg.Data.(*handlers.RewardEventData); ok && data != nil {
+					m.statsPanel = m.statsPanel.SetFame(data.SessionFame)
+					m.statsPanel = m.statsPanel.SetSilver(data.SessionSilver)
+					for range data.Items {
+						m.statsPanel = m.statsPanel.IncrLoot()
+					}
+				}
 			case "kill":
-				m.statsPanel = m.statsPanel.IncrKills()
+				// Kills and deaths of other players don't count, so use the session totals
+				if data, ok := eventMsg.Data.(*handlers.KillEventData); ok && data != nil {
+					m.statsPanel = m.statsPanel.SetKills(data.SessionKills)
+				}
 			case "death":
-				m.statsPanel = m.statsPanel.IncrDeaths()
+				if data, ok := eventMsg.Data.(*handlers.DeathEventData); ok && data != nil {
+					m.statsPanel = m.statsPanel.SetDeaths(data.SessionDeaths)
+				}
 			}
 
 			logEvents = append(logEvents, components.Event{
@@ -174,6 +272,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case StatsUpdateMsg:
 		m.statusBar = m.statusBar.UpdateStats(msg.Stats)
 		m.statusBar = m.statusBar.SetOnline(true)
+		if m.svc != nil {
+			m.statusBar = m.statusBar.SetDropSeverity(m.svc.DropSeverity())
+		}
 
 		// Continue listening for stats
 		if m.statsChan != nil {
@@ -188,7 +289,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Periodic tick
 	case TickMsg:
-		// Refresh display periodically
+		// Rotate sparkline buckets and refresh display periodically
+		m.statsPanel = m.statsPanel.Tick(time.Time(msg))
+		m = m.refreshDiagnostics()
+		if m.svc != nil {
+			m.statusBar = m.statusBar.SetMountHealth(m.svc.MountHealth())
+			m.statusBar = m.statusBar.SetInCombat(m.svc.InCombat())
+			m.statusBar = m.statusBar.SetZone(m.svc.CurrentZone())
+		}
 		cmds = append(cmds, TickCmd())
 		return m, tea.Batch(cmds...)
 
@@ -206,6 +314,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// refreshDiagnostics updates the diagnostics panel from the service while it is shown
+func (m Model) refreshDiagnostics() Model {
+	if m.showDiagnostics && m.svc != nil {
+		m.diagPanel = m.diagPanel.Update(m.svc.Diagnostics())
+	}
+	return m
+}
+
+// markSession ends the current measurement window, logs its totals and starts a new one
+func (m Model) markSession() Model {
+	if m.svc == nil {
+		return m
+	}
+
+	totals := m.svc.StopSession().Totals
+	m.statsPanel = m.statsPanel.Reset()
+	m.eventLog = m.eventLog.AddEvents([]components.Event{{
+		Type: "info",
+		Message: fmt.Sprintf("🏁 Session ended after %s: %s fame, %s silver, %d loot, %d kills, %d deaths",
+			format.Clock(totals.Duration),
+			formatNumber(totals.Fame, m.fullNumbers),
+			formatNumber(totals.Silver, m.fullNumbers),
+			totals.Loot, totals.Kills, totals.Deaths),
+		Timestamp: time.Now(),
+	}})
+	return m
+}
+
+// tracePacket asks the service to trace the next packet to the trace file
+func (m Model) tracePacket() Model {
+	if m.svc == nil {
+		return m
+	}
+
+	msg := fmt.Sprintf("🔍 Tracing next packet to %s", backend.DefaultTraceFile)
+	if err := m.svc.TraceNextPacket(backend.DefaultTraceFile); err != nil {
+		msg = fmt.Sprintf("🔍 Packet trace unavailable: %v", err)
+	}
+	m.eventLog = m.eventLog.AddEvents([]components.Event{{
+		Type:      "info",
+		Message:   msg,
+		Timestamp: time.Now(),
+	}})
+	return m
+}
+
 // updateLayout recalculates component sizes based on window dimensions
 func (m Model) updateLayout() Model {
 	// Reserve space for status bar (4 lines) and help bar (1 line)
@@ -231,12 +385,21 @@ func (m Model) updateLayout() Model {
 	m.statusBar = m.statusBar.SetWidth(m.width)
 	m.eventLog = m.eventLog.SetSize(eventLogWidth, mainHeight)
 	m.statsPanel = m.statsPanel.SetSize(statsPanelWidth, mainHeight)
+	m.diagPanel = m.diagPanel.SetSize(statsPanelWidth, mainHeight)
 
 	return m
 }
 
-// View renders the TUI
+// View renders the TUI (the last rendered frame when throttled)
 func (m Model) View() string {
+	if m.throttle.enabled() && m.frame != "" {
+		return m.frame
+	}
+	return m.render()
+}
+
+// render renders the TUI
+func (m Model) render() string {
 	if m.quitting {
 		return "Goodbye!\n"
 	}
@@ -249,10 +412,14 @@ func (m Model) View() string {
 	statusBar := m.statusBar.View()
 
 	// Main panel (event log + stats side by side)
+	sidePanel := m.statsPanel.View()
+	if m.showDiagnostics {
+		sidePanel = m.diagPanel.View()
+	}
 	mainPanel := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		m.eventLog.View(),
-		m.statsPanel.View(),
+		sidePanel,
 	)
 
 	// Help bar (bottom)
@@ -280,7 +447,11 @@ func (m Model) renderHelpBar() string {
 		keyStyle.Render("Q"), textStyle.Render("uit  "),
 		keyStyle.Render("C"), textStyle.Render("lear  "),
 		keyStyle.Render("R"), textStyle.Render("eset stats  "),
+		keyStyle.Render("M"), textStyle.Render("ark session  "),
 		keyStyle.Render("F"), textStyle.Render("ull numbers  "),
+		keyStyle.Render("V"), textStyle.Render("iew rates  "),
+		keyStyle.Render("I"), textStyle.Render("nfo  "),
+		keyStyle.Render("P"), textStyle.Render("acket trace  "),
 		keyStyle.Render("D"), textStyle.Render("ebug"),
 	)
 
@@ -292,6 +463,9 @@ func (m Model) renderHelpBar() string {
 	if m.fullNumbers {
 		help += "  " + toggleStyle.Render("[FULL]")
 	}
+	if m.statsView == components.StatsViewRates {
+		help += "  " + toggleStyle.Render("[RATES]")
+	}
 	if m.debug {
 		help += "  " + toggleStyle.Render("[DEBUG]")
 	}
@@ -305,16 +479,5 @@ func (m Model) renderHelpBar() string {
 // If fullNumbers is true, returns the full number (e.g., 4984)
 // If fullNumbers is false, returns abbreviated form (e.g., 4.9k)
 func formatNumber(amount int64, full bool) string {
-	if full {
-		return fmt.Sprintf("%d", amount)
-	}
-	// Abbreviated format with truncation (floor) instead of rounding
-	if amount >= 1000000 {
-		val := math.Floor(float64(amount)/100000.0) / 10.0
-		return fmt.Sprintf("%.1fM", val)
-	} else if amount >= 1000 {
-		val := math.Floor(float64(amount)/100.0) / 10.0
-		return fmt.Sprintf("%.1fk", val)
-	}
-	return fmt.Sprintf("%d", amount)
+	return format.Number(amount, full)
 }
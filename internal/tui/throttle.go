@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RenderTickMsg drives throttled renders
+type RenderTickMsg time.Time
+
+// RenderTickCmd returns a command that sends a RenderTickMsg after interval
+func RenderTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return RenderTickMsg(t)
+	})
+}
+
+// renderThrottle coalesces state changes so the view is rendered at most once per interval.
+// Changes arriving within the interval only mark the view dirty; the next render tick renders them.
+type renderThrottle struct {
+	interval time.Duration // Minimum time between renders (0 = render on every change)
+	last     time.Time     // When the view was last rendered
+	dirty    bool          // Whether there are changes not rendered yet
+}
+
+// newRenderThrottle creates a throttle for at most fps renders per second (fps <= 0 disables it)
+func newRenderThrottle(fps int) renderThrottle {
+	if fps <= 0 {
+		return renderThrottle{}
+	}
+	return renderThrottle{interval: time.Second / time.Duration(fps)}
+}
+
+// enabled reports whether renders are throttled
+func (t renderThrottle) enabled() bool {
+	return t.interval > 0
+}
+
+// change records a state change at now and reports whether to render immediately.
+// Immediate changes (user input, resizes) always render so the UI stays responsive.
+func (t *renderThrottle) change(now time.Time, immediate bool) bool {
+	if immediate || now.Sub(t.last) >= t.interval {
+		t.last = now
+		t.dirty = false
+		return true
+	}
+	t.dirty = true
+	return false
+}
+
+// tick reports whether pending changes should be rendered at now
+func (t *renderThrottle) tick(now time.Time) bool {
+	if !t.dirty || now.Sub(t.last) < t.interval {
+		return false
+	}
+	t.last = now
+	t.dirty = false
+	return true
+}
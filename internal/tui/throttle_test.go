@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRenderThrottleDisabled tests that a zero FPS disables throttling
+func TestRenderThrottleDisabled(t *testing.T) {
+	for _, fps := range []int{0, -1} {
+		if newRenderThrottle(fps).enabled() {
+			t.Errorf("expected throttle disabled for fps %d", fps)
+		}
+	}
+
+	throttle := newRenderThrottle(10)
+	if !throttle.enabled() || throttle.interval != 100*time.Millisecond {
+		t.Errorf("expected 100ms interval for 10 fps, got %v", throttle.interval)
+	}
+}
+
+// TestRenderThrottleCoalesces tests which changes render immediately and which are batched
+func TestRenderThrottleCoalesces(t *testing.T) {
+	throttle := newRenderThrottle(10)
+	start := time.Now()
+
+	// First change renders immediately
+	if !throttle.change(start, false) {
+		t.Error("expected first change to render")
+	}
+
+	// Changes within the interval are batched
+	for _, offset := range []time.Duration{10, 40, 90} {
+		if throttle.change(start.Add(offset*time.Millisecond), false) {
+			t.Errorf("expected change at +%dms to be batched", offset)
+		}
+	}
+
+	// A tick before the interval has elapsed does not render
+	if throttle.tick(start.Add(95 * time.Millisecond)) {
+		t.Error("expected no render before the interval elapsed")
+	}
+
+	// The next tick renders the batched changes once
+	if !throttle.tick(start.Add(100 * time.Millisecond)) {
+		t.Error("expected tick to render batched changes")
+	}
+	if throttle.tick(start.Add(300 * time.Millisecond)) {
+		t.Error("expected no render without new changes")
+	}
+
+	// A change after the interval renders immediately
+	if !throttle.change(start.Add(400*time.Millisecond), false) {
+		t.Error("expected change after the interval to render")
+	}
+}
+
+// TestRenderThrottleImmediate tests that immediate changes (user input) always render
+func TestRenderThrottleImmediate(t *testing.T) {
+	throttle := newRenderThrottle(10)
+	start := time.Now()
+
+	throttle.change(start, false)
+	throttle.change(start.Add(10*time.Millisecond), false)
+
+	if !throttle.change(start.Add(20*time.Millisecond), true) {
+		t.Error("expected immediate change to render")
+	}
+	// The immediate render included the batched change
+	if throttle.tick(start.Add(200 * time.Millisecond)) {
+		t.Error("expected nothing left to render after an immediate render")
+	}
+}
@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// SaveSession writes the session counters (fame, silver, kills, deaths, loot) to path.
+func (s *Service) SaveSession(path string) error {
+	if s.handler == nil {
+		return fmt.Errorf("service not started")
+	}
+	return s.handler.SaveSession(path)
+}
+
+// LoadSession restores session counters saved by SaveSession, so the session
+// resumes from them instead of starting from zero.
+func (s *Service) LoadSession(path string) error {
+	if s.handler == nil {
+		return fmt.Errorf("service not started")
+	}
+	return s.handler.LoadSession(path)
+}
+
+// restoreSession resumes the session from the autosave file, if there is one
+func (s *Service) restoreSession() error {
+	if s.autosavePath == "" {
+		return nil
+	}
+	if err := s.handler.LoadSession(s.autosavePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// autosaveLoop periodically saves the session to the autosave file until the service stops.
+// Errors are non-fatal: the previous save is kept and the next tick tries again.
+func (s *Service) autosaveLoop() {
+	ticker := time.NewTicker(s.autosaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			_ = s.handler.SaveSession(s.autosavePath)
+		}
+	}
+}
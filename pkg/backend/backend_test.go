@@ -1,8 +1,29 @@
 package backend
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/capture"
+	"github.com/cantalupo555/albion-lens/pkg/events"
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+	"golang.org/x/net/websocket"
 )
 
 // ============================================
@@ -93,6 +114,78 @@ func TestWithDiscovery(t *testing.T) {
 	}
 }
 
+// TestWithStrictMode tests the WithStrictMode option
+func TestWithStrictMode(t *testing.T) {
+	s := New(WithStrictMode(true))
+
+	if s.strictMode != true {
+		t.Error("expected strictMode to be true")
+	}
+
+	s = New()
+	if s.strictMode != false {
+		t.Error("expected strictMode to default to false")
+	}
+}
+
+// TestWithChatCapture tests the WithChatCapture option
+func TestWithChatCapture(t *testing.T) {
+	s := New(WithChatCapture(true))
+
+	if s.chatCapture != true {
+		t.Error("expected chatCapture to be true")
+	}
+
+	s = New()
+	if s.chatCapture != false {
+		t.Error("expected chatCapture to default to false")
+	}
+}
+
+// TestWithProfiling tests the WithProfiling option
+func TestWithProfiling(t *testing.T) {
+	s := New(WithProfiling(true))
+
+	if s.profiling != true {
+		t.Error("expected profiling to be true")
+	}
+
+	s = New()
+	if s.profiling != false {
+		t.Error("expected profiling to default to false")
+	}
+}
+
+// TestWithVerboseCombat tests the WithVerboseCombat option
+func TestWithVerboseCombat(t *testing.T) {
+	s := New(WithVerboseCombat(true))
+
+	if s.verboseCombat != true {
+		t.Error("expected verboseCombat to be true")
+	}
+}
+
+// TestWithEventsDisabled tests the stats-only mode option
+func TestWithEventsDisabled(t *testing.T) {
+	s := New(WithEventsDisabled(true))
+	if !s.eventsDisabled {
+		t.Error("expected eventsDisabled to be true")
+	}
+}
+
+// TestWithSilverThresholds tests the silver threshold option
+func TestWithSilverThresholds(t *testing.T) {
+	s := New()
+	if s.minSilver != handlers.DefaultMinSilver || s.maxSilverGrab != handlers.DefaultMaxSilverGrab {
+		t.Errorf("expected default thresholds, got %d/%d", s.minSilver, s.maxSilverGrab)
+	}
+
+	s = New(WithSilverThresholds(10, 0))
+	if s.minSilver != 10 || s.maxSilverGrab != 0 {
+		t.Errorf("expected thresholds 10/0, got %d/%d", s.minSilver, s.maxSilverGrab)
+	}
+}
+
 // TestWithItemDatabasePath tests item database path option
 func TestWithItemDatabasePath(t *testing.T) {
 	s := New(WithItemDatabasePath("/path/to/items"))
@@ -102,6 +195,33 @@ func TestWithItemDatabasePath(t *testing.T) {
 	}
 }
 
+// TestWithWorldDatabasePath tests the world database path option
+func TestWithWorldDatabasePath(t *testing.T) {
+	s := New(WithWorldDatabasePath("/path/to/dumps"))
+
+	if s.worldDBPath != "/path/to/dumps" {
+		t.Errorf("expected '/path/to/dumps', got '%s'", s.worldDBPath)
+	}
+}
+
+// TestWithMobDatabasePath tests the mob database path option
+func TestWithMobDatabasePath(t *testing.T) {
+	s := New(WithMobDatabasePath("/path/to/dumps"))
+
+	if s.mobDBPath != "/path/to/dumps" {
+		t.Errorf("expected '/path/to/dumps', got '%s'", s.mobDBPath)
+	}
+}
+
+// TestWithOnlineTimeout tests the online timeout option
+func TestWithOnlineTimeout(t *testing.T) {
+	s := New(WithOnlineTimeout(15 * time.Second))
+
+	if s.onlineTimeout != 15*time.Second {
+		t.Errorf("expected 15s, got %v", s.onlineTimeout)
+	}
+}
+
 // TestWithBPFFilter tests BPF filter option
 func TestWithBPFFilter(t *testing.T) {
 	s := New(WithBPFFilter("udp port 5056"))
@@ -394,6 +514,10 @@ func TestServiceSessionMetricsWithoutHandler(t *testing.T) {
 	if s.SessionLoot() != 0 {
 		t.Errorf("SessionLoot: expected 0, got %d", s.SessionLoot())
 	}
+
+	if s.CurrentZone() != "" {
+		t.Errorf("CurrentZone: expected empty, got %q", s.CurrentZone())
+	}
 }
 
 // TestServiceParserStatsWithoutParser tests parser stats without parser
@@ -414,6 +538,113 @@ func TestServiceHandlerWithoutStart(t *testing.T) {
 	}
 }
 
+// TestStartUnknownDevice tests that Start fails instead of silently capturing nothing
+func TestStartUnknownDevice(t *testing.T) {
+	s := New(WithDevices("albion-lens-no-such-device"))
+	if err := s.Start(); err == nil {
+		s.Stop()
+		t.Fatal("expected error for an unknown device")
+	}
+	if s.IsRunning() {
+		t.Error("service should not be running after a failed start")
+	}
+}
+
+// TestRunStopsOnCancel tests that Run stops the service once its context is canceled
+func TestRunStopsOnCancel(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// Events is closed by the shutdown, after the replayed ones
+	var got int
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-s.Events:
+			if !ok {
+				if got == 0 {
+					t.Error("expected replayed events before shutdown")
+				}
+				if err := <-done; err != nil {
+					t.Errorf("expected nil error, got %v", err)
+				}
+				if s.IsRunning() {
+					t.Error("service should not be running after Run returns")
+				}
+				return
+			}
+			if got++; got == 1 {
+				cancel()
+			}
+		case <-timeout:
+			t.Fatal("Run did not return after cancel")
+		}
+	}
+}
+
+// TestRunReturnsOnStop tests that Run returns when Stop is called by another goroutine
+func TestRunReturnsOnStop(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(context.Background())
+	}()
+	// The first event means capture has started
+	select {
+	case <-s.Events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event replayed")
+	}
+	s.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after Stop")
+	}
+}
+
+// TestRunStartError tests that Run returns the start error
+func TestRunStartError(t *testing.T) {
+	s := New(WithDevices("albion-lens-no-such-device"))
+	if err := s.Run(context.Background()); err == nil {
+		t.Fatal("expected error for an unknown device")
+	}
+	if s.IsRunning() {
+		t.Error("service should not be running after a failed start")
+	}
+}
+
+// TestStartInvalidBPFFilter tests that Start rejects a custom filter that doesn't compile
+func TestStartInvalidBPFFilter(t *testing.T) {
+	if err := capture.ValidateFilter(capture.BPFFilter); err != nil {
+		t.Skipf("BPF compiler unavailable: %v", err)
+	}
+
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithBPFFilter("udp and port"))
+	err := s.Start()
+	if err == nil {
+		s.Stop()
+		t.Fatal("expected error for an invalid filter")
+	}
+	if !strings.Contains(err.Error(), "udp and port") {
+		t.Errorf("expected error to show the filter, got %v", err)
+	}
+	if s.IsRunning() {
+		t.Error("service should not be running after a failed start")
+	}
+}
+
 // TestDefaultBufferSizeConstants tests default buffer size constants
 func TestDefaultBufferSizeConstants(t *testing.T) {
 	if defaultEventBufferSize != 250 {
@@ -424,3 +655,1676 @@ func TestDefaultBufferSizeConstants(t *testing.T) {
 		t.Errorf("defaultStatsBufferSize: expected 10, got %d", defaultStatsBufferSize)
 	}
 }
+
+// TestEventsDisabledUpdatesSessionTotals tests that stats-only mode keeps
+// session counters updated while no events are emitted
+func TestEventsDisabledUpdatesSessionTotals(t *testing.T) {
+	s := New(WithEventsDisabled(true))
+	s.handler = s.newHandler()
+
+	// Fame gain of 1000 (FixPoint values, detailed format)
+	s.handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+		0:                     int64(1),
+		1:                     int64(50000000000),
+		2:                     int64(10000000),
+		events.ParamEventCode: int16(events.EventUpdateFame),
+	})
+
+	if s.SessionFame() != 1000 {
+		t.Errorf("expected session fame 1000, got %d", s.SessionFame())
+	}
+	if cap(s.eventsChan) != 0 {
+		t.Errorf("expected no event buffer, got capacity %d", cap(s.eventsChan))
+	}
+
+	select {
+	case event := <-s.Events:
+		t.Errorf("expected no events, got %v", event)
+	default:
+	}
+}
+
+// TestEventsDisabledServiceEvents tests that stats-only mode sends none of the
+// service's own events either (online status, fragment and device warnings)
+func TestEventsDisabledServiceEvents(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithEventsDisabled(true))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Stop()
+	s.Wait()
+
+	s.capture.OnlineCallback(true)
+	s.capture.OnlineCallback(false)
+	s.fragmentExpired(42, 2, 5)
+	s.capture.OnDeviceError("eth0", errors.New("device gone"))
+	s.capture.OnDeviceReopened("eth0")
+
+	select {
+	case event := <-s.Events:
+		t.Errorf("expected no events, got %v", event)
+	default:
+	}
+	if dropped := s.parser.Stats.GetEventsDropped(); dropped != 0 {
+		t.Errorf("expected no dropped events, got %d", dropped)
+	}
+	select {
+	case <-s.OnlineStatus:
+	default:
+		t.Error("expected the online status to keep flowing")
+	}
+}
+
+// TestEventsEnabledEmitsEvents tests that handler notifications reach the Events channel by default
+func TestEventsEnabledEmitsEvents(t *testing.T) {
+	s := New()
+	s.handler = s.newHandler()
+
+	s.handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+		0:                     int64(1),
+		1:                     int64(50000000000),
+		2:                     int64(10000000),
+		events.ParamEventCode: int16(events.EventUpdateFame),
+	})
+
+	select {
+	case event := <-s.Events:
+		if event.Type != EventTypeFame {
+			t.Errorf("expected fame event, got %q", event.Type)
+		}
+	default:
+		t.Error("expected a fame event")
+	}
+}
+
+// TestEventsCarryReliability tests that events are tagged with the delivery reliability of their command
+func TestEventsCarryReliability(t *testing.T) {
+	s := New()
+	s.handler = s.newHandler()
+	s.parser = photon.NewParser(s.handler)
+	defer s.parser.Close()
+
+	// KilledPlayer event with only the event code parameter
+	code := uint16(events.EventKilledPlayer)
+	message := []byte{243, photon.MessageTypeEventData, 0, 0, 1, events.ParamEventCode, photon.TypeShort, byte(code >> 8), byte(code)}
+
+	packet := func(commandType byte, data []byte) []byte {
+		p := make([]byte, photon.PhotonHeaderLength+photon.CommandHeaderLength, photon.PhotonHeaderLength+photon.CommandHeaderLength+len(data))
+		p[3] = 1 // command count
+		cmd := p[photon.PhotonHeaderLength:]
+		cmd[0] = commandType
+		length := photon.CommandHeaderLength + len(data)
+		cmd[4], cmd[5], cmd[6], cmd[7] = byte(length>>24), byte(length>>16), byte(length>>8), byte(length)
+		return append(p, data...)
+	}
+
+	tests := []struct {
+		name     string
+		packet   []byte
+		reliable bool
+	}{
+		{"reliable", packet(photon.CommandTypeSendReliable, message), true},
+		{"unreliable", packet(photon.CommandTypeSendUnreliable, append([]byte{0, 0, 0, 1}, message...)), false},
+		{"reliable after unreliable", packet(photon.CommandTypeSendReliable, message), true},
+	}
+
+	for _, tt := range tests {
+		if err := s.parser.ParsePacket(tt.packet); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		select {
+		case event := <-s.Events:
+			if event.Reliable != tt.reliable {
+				t.Errorf("%s: expected reliable=%v, got %v", tt.name, tt.reliable, event.Reliable)
+			}
+		default:
+			t.Fatalf("%s: expected a kill event", tt.name)
+		}
+	}
+
+	// Messages from the TCP chat stream are reliable
+	s.parser.ParseMessage(message)
+	if event := <-s.Events; !event.Reliable {
+		t.Error("expected TCP message to be reliable")
+	}
+}
+
+// TestEventsCarryReceiveTime tests that events are stamped with the time their message was received
+func TestEventsCarryReceiveTime(t *testing.T) {
+	s := New()
+	s.handler = s.newHandler()
+	s.parser = photon.NewParser(s.handler)
+	defer s.parser.Close()
+
+	receivedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.parser.SetClock(func() time.Time { return receivedAt })
+
+	code := uint16(events.EventKilledPlayer)
+	s.parser.ParseMessage([]byte{243, photon.MessageTypeEventData, 0, 0, 1, events.ParamEventCode, photon.TypeShort, byte(code >> 8), byte(code)})
+	select {
+	case event := <-s.Events:
+		if !event.Timestamp.Equal(receivedAt) {
+			t.Errorf("expected timestamp %v, got %v", receivedAt, event.Timestamp)
+		}
+	default:
+		t.Fatal("expected a kill event")
+	}
+}
+
+// TestEventMessagesTruncated tests that long messages are truncated but their data is kept
+func TestEventMessagesTruncated(t *testing.T) {
+	s := New(WithMaxMessageLength(10))
+	data := &handlers.SocialEventData{Guild: strings.Repeat("G", 50)}
+
+	s.onHandlerEvent(photon.MessageInfo{}, "social", "🤝 invited you to guild "+data.Guild, data)
+	event := <-s.Events
+	if event.Message != "🤝 invited…" {
+		t.Errorf("expected truncated message, got %q", event.Message)
+	}
+	if event.Data.(*handlers.SocialEventData).Guild != data.Guild {
+		t.Error("expected structured data to be kept in full")
+	}
+
+	s = New(WithMaxMessageLength(0))
+	s.onHandlerEvent(photon.MessageInfo{}, "info", strings.Repeat("x", 500), nil)
+	if event := <-s.Events; len(event.Message) != 500 {
+		t.Errorf("expected no truncation without a limit, got %d bytes", len(event.Message))
+	}
+}
+
+// TestFragmentExpiredWarning tests that expired fragmented messages are reported
+// as warning events
+func TestFragmentExpiredWarning(t *testing.T) {
+	s := New()
+	s.fragmentExpired(42, 2, 5)
+
+	if len(s.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(s.Events))
+	}
+	event := <-s.Events
+	if event.Type != EventTypeWarning {
+		t.Errorf("expected type %q, got %q", EventTypeWarning, event.Type)
+	}
+	if !strings.Contains(event.Message, "42") || !strings.Contains(event.Message, "2/5") {
+		t.Errorf("expected sequence and fragment counts in message, got %q", event.Message)
+	}
+}
+
+// ============================================
+// Tests for status.go
+// ============================================
+
+// TestCompactStatusLine tests compact status line formatting for a known session
+func TestCompactStatusLine(t *testing.T) {
+	uptime := 2 * time.Hour
+
+	// Abbreviated: rates are per hour over the uptime
+	line := compactStatusLine(24600, 91200, 3, 1, uptime, false)
+	expected := "⭐12.3k/h 💰45.6k/h ⚔3 💀1 | 02:00:00"
+	if line != expected {
+		t.Errorf("expected '%s', got '%s'", expected, line)
+	}
+
+	// Full numbers
+	line = compactStatusLine(24600, 91200, 3, 1, uptime, true)
+	expected = "⭐12300/h 💰45600/h ⚔3 💀1 | 02:00:00"
+	if line != expected {
+		t.Errorf("expected '%s', got '%s'", expected, line)
+	}
+}
+
+// TestCompactStatusLineNoUptime tests compact status line before any uptime
+func TestCompactStatusLineNoUptime(t *testing.T) {
+	line := compactStatusLine(5000, 1000, 0, 0, 0, false)
+	expected := "⭐0/h 💰0/h ⚔0 💀0 | 00:00:00"
+	if line != expected {
+		t.Errorf("expected '%s', got '%s'", expected, line)
+	}
+}
+
+// TestServiceCompactStatusLineWithoutStart tests the status line without a running service
+func TestServiceCompactStatusLineWithoutStart(t *testing.T) {
+	s := New()
+
+	if line := s.CompactStatusLine(); line != "⭐0/h 💰0/h ⚔0 💀0 | 00:00:00" {
+		t.Errorf("unexpected status line: '%s'", line)
+	}
+}
+
+// TestSessionSnapshot tests that the snapshot matches the individual session counters
+func TestSessionSnapshot(t *testing.T) {
+	s := New(WithEventsDisabled(true))
+	if snap := s.SessionSnapshot(); snap != (SessionSummary{}) {
+		t.Errorf("expected empty snapshot before Start, got %+v", snap)
+	}
+
+	s.handler = s.newHandler()
+	s.handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+		0:                     int64(1),
+		1:                     int64(3000 * 10000),
+		2:                     int64(3000 * 10000),
+		events.ParamEventCode: int16(events.EventUpdateFame),
+	})
+	s.handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventKilledPlayer),
+	})
+
+	snap := s.SessionSnapshot()
+	if snap.Fame != s.SessionFame() || snap.Kills != s.SessionKills() {
+		t.Errorf("expected fame %d and kills %d, got %+v", s.SessionFame(), s.SessionKills(), snap)
+	}
+	if snap.Fame != 3000 || snap.Kills != 1 {
+		t.Errorf("expected 3000 fame and 1 kill, got %+v", snap)
+	}
+}
+
+// TestWithStatusFile tests status file option
+func TestWithStatusFile(t *testing.T) {
+	s := New(WithStatusFile("/tmp/status.txt"))
+
+	if s.statusFile != "/tmp/status.txt" {
+		t.Errorf("expected '/tmp/status.txt', got '%s'", s.statusFile)
+	}
+}
+
+// TestSetFullNumbers tests the full numbers toggle
+func TestSetFullNumbers(t *testing.T) {
+	s := New()
+
+	if s.IsFullNumbers() {
+		t.Error("full numbers should default to false")
+	}
+
+	s.SetFullNumbers(true)
+	if !s.IsFullNumbers() {
+		t.Error("SetFullNumbers(true) failed")
+	}
+}
+
+// ============================================
+// Tests for export.go
+// ============================================
+
+// exportTestTime is a fixed timestamp for export tests
+var exportTestTime = time.Date(2024, 5, 1, 12, 30, 45, 123000000, time.UTC)
+
+// TestLootLoggerLoot tests the interop output for a loot event
+func TestLootLoggerLoot(t *testing.T) {
+	lines := formatLootLoggerLines(GameEvent{
+		Type:      EventTypeLoot,
+		Timestamp: exportTestTime,
+		Data: &handlers.LootEventData{
+			LootedBy:   "Player1",
+			ItemID:     1234,
+			UniqueName: "T4_BAG",
+			ItemName:   "T4 Bag",
+			Quantity:   2,
+			LootedFrom: "Player2",
+		},
+	})
+
+	expected := "2024-05-01T12:30:45.123Z;;;Player1;T4_BAG;T4 Bag;2;;;Player2"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Errorf("expected %q, got %v", expected, lines)
+	}
+
+	if fields := strings.Split(lines[0], ";"); len(fields) != len(strings.Split(lootLoggerHeader, ";")) {
+		t.Errorf("expected %d fields, got %d", len(strings.Split(lootLoggerHeader, ";")), len(fields))
+	}
+}
+
+// TestLootLoggerLootUnknownItem tests that the numeric ID is used without a unique name
+func TestLootLoggerLootUnknownItem(t *testing.T) {
+	lines := formatLootLoggerLines(GameEvent{
+		Type:      EventTypeLoot,
+		Timestamp: exportTestTime,
+		Data:      &handlers.LootEventData{LootedBy: "A;B", ItemID: 99, ItemName: "Item#99", Quantity: 1, LootedFrom: "Chest"},
+	})
+
+	expected := "2024-05-01T12:30:45.123Z;;;A,B;99;Item#99;1;;;Chest"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Errorf("expected %q, got %v", expected, lines)
+	}
+}
+
+// TestLootLoggerFame tests the interop output for a fame event
+func TestLootLoggerFame(t *testing.T) {
+	lines := formatLootLoggerLines(GameEvent{
+		Type:      EventTypeFame,
+		Timestamp: exportTestTime,
+		Data:      &handlers.FameEventData{Gained: 1500, Total: 100000, Session: 3000},
+	})
+
+	expected := "2024-05-01T12:30:45.123Z;;;;@FAME;Fame;1500;;;"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Errorf("expected %q, got %v", expected, lines)
+	}
+}
+
+// TestLootLoggerKill tests the interop output for a kill event
+func TestLootLoggerKill(t *testing.T) {
+	lines := formatLootLoggerLines(GameEvent{
+		Type:      EventTypeKill,
+		Timestamp: exportTestTime,
+		Data:      &handlers.KillEventData{SessionKills: 3},
+	})
+
+	expected := "2024-05-01T12:30:45.123Z;;;;@KILL;Kill;1;;;"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Errorf("expected %q, got %v", expected, lines)
+	}
+}
+
+// TestLootLoggerSkipsOtherEvents tests that unmapped events are not exported
+func TestLootLoggerSkipsOtherEvents(t *testing.T) {
+	lines := formatLootLoggerLines(GameEvent{Type: EventTypeInfo, Message: "hello", Timestamp: exportTestTime})
+	if len(lines) != 0 {
+		t.Errorf("expected no lines for info event, got %v", lines)
+	}
+}
+
+// TestFormatJSONLLine tests the native JSONL output
+func TestFormatJSONLLine(t *testing.T) {
+	line, err := formatJSONLLine(GameEvent{
+		Type:      EventTypeFame,
+		Timestamp: exportTestTime,
+		Data:      &handlers.FameEventData{Gained: 1500, Total: 100000, Session: 3000},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"version":1,"type":"fame","timestamp":"2024-05-01T12:30:45.123Z","data":{"Gained":1500,"Total":100000,"Session":3000}}`
+	if line != expected {
+		t.Errorf("expected %s, got %s", expected, line)
+	}
+}
+
+// TestEventExporterLootLoggerHeader tests that the header is written once per file
+func TestEventExporterLootLoggerHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loot.txt")
+	event := GameEvent{Type: EventTypeKill, Timestamp: exportTestTime, Data: &handlers.KillEventData{SessionKills: 1}}
+
+	for i := 0; i < 2; i++ {
+		exporter, err := newEventExporter(path, ExportFormatLootLogger)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := exporter.write(event); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+		if err := exporter.close(); err != nil {
+			t.Fatalf("unexpected close error: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export file: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || lines[0] != lootLoggerHeader {
+		t.Errorf("expected header and 2 rows, got %v", lines)
+	}
+}
+
+// TestEventExporterQueue tests that queued events are written in order on close
+func TestEventExporterQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	exporter, err := newEventExporter(path, ExportFormatJSONL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exporter.start(func() { t.Error("unexpected drop") })
+
+	for i := 1; i <= 3; i++ {
+		exporter.enqueue(GameEvent{Type: EventTypeKill, Timestamp: exportTestTime, Data: &handlers.KillEventData{SessionKills: i}})
+	}
+	if err := exporter.close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %v", lines)
+	}
+	for i, line := range lines {
+		var record struct {
+			Version int
+			Data    handlers.KillEventData
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if record.Version != jsonlSchemaVersion || record.Data.SessionKills != i+1 {
+			t.Errorf("expected version %d and kill %d, got %s", jsonlSchemaVersion, i+1, line)
+		}
+	}
+}
+
+// TestEventExporterOverflow tests that events are dropped and counted when the queue is full
+func TestEventExporterOverflow(t *testing.T) {
+	exporter, err := newEventExporter(filepath.Join(t.TempDir(), "events.jsonl"), ExportFormatJSONL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer exporter.close()
+
+	// Queue without the writer goroutine, so it fills up
+	dropped := 0
+	exporter.queue = make(chan GameEvent, 2)
+	exporter.onDrop = func() { dropped++ }
+
+	for i := 0; i < 5; i++ {
+		exporter.enqueue(GameEvent{Type: EventTypeInfo, Timestamp: exportTestTime})
+	}
+	if dropped != 3 {
+		t.Errorf("expected 3 dropped events, got %d", dropped)
+	}
+	exporter.queue = nil
+}
+
+// TestParseExportFormat tests export format parsing
+func TestParseExportFormat(t *testing.T) {
+	if format, err := ParseExportFormat("ao-loot-logger"); err != nil || format != ExportFormatLootLogger {
+		t.Errorf("expected %q, got %q (err=%v)", ExportFormatLootLogger, format, err)
+	}
+	if format, err := ParseExportFormat("jsonl"); err != nil || format != ExportFormatJSONL {
+		t.Errorf("expected %q, got %q (err=%v)", ExportFormatJSONL, format, err)
+	}
+	if format, err := ParseExportFormat("binary"); err != nil || format != ExportFormatBinary {
+		t.Errorf("expected %q, got %q (err=%v)", ExportFormatBinary, format, err)
+	}
+	if _, err := ParseExportFormat("csv"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+// TestWithExportOptions tests the event log export options
+func TestWithExportOptions(t *testing.T) {
+	s := New(WithEventLogFile("events.txt"), WithExportFormat(ExportFormatLootLogger))
+
+	if s.eventLogFile != "events.txt" {
+		t.Errorf("expected eventLogFile 'events.txt', got '%s'", s.eventLogFile)
+	}
+	if s.exportFormat != ExportFormatLootLogger {
+		t.Errorf("expected exportFormat %q, got %q", ExportFormatLootLogger, s.exportFormat)
+	}
+}
+
+// ============================================
+// Tests for binarylog.go
+// ============================================
+
+// binaryTestEvents is a batch of events covering the stored data types
+var binaryTestEvents = []GameEvent{
+	{Type: EventTypeFame, Timestamp: exportTestTime, Data: &handlers.FameEventData{Gained: 1000, Total: 5000000, Session: 1000}},
+	{Type: EventTypeSilver, Timestamp: exportTestTime.Add(time.Second), Data: &handlers.SilverEventData{Amount: 250, LootedBy: "Me", LootedFrom: "Mob"}},
+	{Type: EventTypeLoot, Timestamp: exportTestTime.Add(2 * time.Second), Data: &handlers.LootEventData{ItemID: 42, ItemName: "Bag", UniqueName: "T4_BAG", Quantity: 1, LootedBy: "Me"}},
+	{Type: EventTypeReward, Timestamp: exportTestTime.Add(3 * time.Second), Data: &handlers.RewardEventData{Silver: 10, Items: []handlers.RewardItem{{ItemID: 7, ItemName: "Rune", Quantity: 3}}}},
+	{Type: EventTypeInfo, Timestamp: exportTestTime.Add(4 * time.Second), Message: "connected"},
+}
+
+// TestBinaryEventLogRoundTrip tests that events decode back to equal values
+func TestBinaryEventLogRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newBinaryEventWriter(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, event := range binaryTestEvents {
+		if err := writer.write(event); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+	}
+
+	reader := NewBinaryEventReader(&buf)
+	for i, expected := range binaryTestEvents {
+		event, err := reader.Next()
+		if err != nil {
+			t.Fatalf("event %d: unexpected read error: %v", i, err)
+		}
+		if !reflect.DeepEqual(event, expected) {
+			t.Errorf("event %d: expected %+v, got %+v", i, expected, event)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+// TestBinaryEventLogAppendSegments tests reading a log appended by several sessions
+func TestBinaryEventLogAppendSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.bin")
+
+	for _, event := range binaryTestEvents[:2] {
+		exporter, err := newEventExporter(path, ExportFormatBinary)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := exporter.write(event); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+		if err := exporter.close(); err != nil {
+			t.Fatalf("unexpected close error: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer file.Close()
+
+	reader := NewBinaryEventReader(file)
+	for i, expected := range binaryTestEvents[:2] {
+		event, err := reader.Next()
+		if err != nil {
+			t.Fatalf("event %d: unexpected read error: %v", i, err)
+		}
+		if !reflect.DeepEqual(event, expected) {
+			t.Errorf("event %d: expected %+v, got %+v", i, expected, event)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+// TestBinaryEventLogUnknownDataType tests that unsupported data is dropped, not the event
+func TestBinaryEventLogUnknownDataType(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newBinaryEventWriter(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.write(GameEvent{Type: EventTypeInfo, Message: "custom", Timestamp: exportTestTime, Data: struct{ X int }{1}}); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+
+	event, err := NewBinaryEventReader(&buf).Next()
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if event.Message != "custom" || event.Data != nil {
+		t.Errorf("expected event without data, got %+v", event)
+	}
+}
+
+// TestBinaryEventReaderInvalid tests reading a file that is not a binary log
+func TestBinaryEventReaderInvalid(t *testing.T) {
+	_, err := NewBinaryEventReader(strings.NewReader(`{"type":"fame"}`)).Next()
+	if err == nil || err == io.EOF {
+		t.Errorf("expected an error for a non-binary log, got %v", err)
+	}
+}
+
+// ============================================
+// Tests for sqlite.go
+// ============================================
+
+// TestSQLiteStoreSkipsFailingEvent tests that an event that can't be written is
+// dropped and counted, without losing the rest of its batch
+func TestSQLiteStoreSkipsFailingEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	store, err := newSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dropped := 0
+	store.start(func() { dropped++ })
+
+	now := time.Now()
+	store.enqueue(GameEvent{Type: EventTypeInfo, Message: "before", Timestamp: now})
+	store.enqueue(GameEvent{Type: EventTypeCombat, Timestamp: now, Data: &handlers.DamageEventData{Amount: math.NaN()}})
+	store.enqueue(GameEvent{Type: EventTypeInfo, Message: "after", Timestamp: now})
+	if err := store.close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dropped != 1 {
+		t.Errorf("expected 1 dropped event, got %d", dropped)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM events").Scan(&count); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected the 2 other events to be stored, got %d", count)
+	}
+}
+
+// TestSQLiteStore tests that events are stored with their loot, kills and fame rows
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	store, err := newSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store.start(nil)
+
+	now := time.Now()
+	store.enqueue(GameEvent{Type: EventTypeInfo, Message: "hello", Timestamp: now})
+	store.enqueue(GameEvent{Type: EventTypeLoot, Timestamp: now, Data: &handlers.LootEventData{LootedBy: "Alice", ItemID: 1234, ItemName: "Bag", Quantity: 2}})
+	store.enqueue(GameEvent{Type: EventTypeKill, Timestamp: now, Data: &handlers.KillEventData{Killer: "Alice", Victim: "Bob", IsLocal: true}})
+	store.enqueue(GameEvent{Type: EventTypeDeath, Timestamp: now, Data: &handlers.DeathEventData{Killer: "Carol", Victim: "Alice"}})
+	store.enqueue(GameEvent{Type: EventTypeFame, Timestamp: now, Data: &handlers.FameEventData{Gained: 100, Total: 5000}})
+	if err := store.close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	expected := map[string]int{"events": 5, "loot": 1, "kills": 2, "fame": 1}
+	for table, count := range expected {
+		var got int
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != count {
+			t.Errorf("expected %d rows in %s, got %d", count, table, got)
+		}
+	}
+
+	var lootedBy string
+	var quantity int64
+	if err := db.QueryRow("SELECT looted_by, quantity FROM loot").Scan(&lootedBy, &quantity); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lootedBy != "Alice" || quantity != 2 {
+		t.Errorf("expected Alice looting 2, got %s looting %d", lootedBy, quantity)
+	}
+
+	var kind string
+	if err := db.QueryRow("SELECT kind FROM kills WHERE victim = 'Alice'").Scan(&kind); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kind != "death" {
+		t.Errorf("expected death row, got %q", kind)
+	}
+}
+
+// TestSQLiteStoreBatches tests that more events than a batch are all stored
+func TestSQLiteStoreBatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	store, err := newSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store.start(nil)
+
+	// Stay below the queue size so none are dropped
+	total := sqliteBatchSize + 10
+	for i := 0; i < total; i++ {
+		store.enqueue(GameEvent{Type: EventTypeInfo, Timestamp: time.Now()})
+	}
+	if err := store.close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	var got int
+	if err := db.QueryRow("SELECT COUNT(*) FROM events").Scan(&got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != total {
+		t.Errorf("expected %d events, got %d", total, got)
+	}
+}
+
+// TestPipelineSQLiteStore tests that a replayed session is stored on Stop
+func TestPipelineSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.db")
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithSQLiteStore(path))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Wait()
+	s.Stop()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	var gained int64
+	if err := db.QueryRow("SELECT SUM(gained) FROM fame").Scan(&gained); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gained != 100 {
+		t.Errorf("expected 100 fame stored, got %d", gained)
+	}
+}
+
+// ============================================
+// Tests for report.go
+// ============================================
+
+// TestBuildSessionReport tests that the zone and content breakdowns sum to the totals
+func TestBuildSessionReport(t *testing.T) {
+	report := buildSessionReport([]handlers.ZoneStats{
+		{Zone: "3005", ContentType: handlers.ContentOpenWorld, Fame: 1000, Silver: 500, Loot: 3, Kills: 1, Duration: time.Minute},
+		{Zone: "@RANDOMDUNGEON@a", ContentType: handlers.ContentDungeon, Fame: 2500, Silver: 800, Loot: 5, Deaths: 1, Duration: 2 * time.Minute},
+		{Zone: "3006", ContentType: handlers.ContentOpenWorld, Fame: 200, Duration: time.Minute},
+	})
+
+	if len(report.Zones) != 3 {
+		t.Fatalf("expected 3 zones, got %d", len(report.Zones))
+	}
+	if len(report.ContentTypes) != 2 {
+		t.Fatalf("expected 2 content types, got %d", len(report.ContentTypes))
+	}
+
+	openWorld := report.ContentTypes[0]
+	if openWorld.ContentType != handlers.ContentOpenWorld || openWorld.Fame != 1200 || openWorld.Duration != 2*time.Minute {
+		t.Errorf("unexpected open world entry: %+v", openWorld)
+	}
+
+	expected := ReportEntry{Fame: 3700, Silver: 1300, Loot: 8, Kills: 1, Deaths: 1, Duration: 4 * time.Minute}
+	if report.Totals != expected {
+		t.Errorf("expected totals %+v, got %+v", expected, report.Totals)
+	}
+}
+
+// TestServiceReport tests the report for a session across two zones
+func TestServiceReport(t *testing.T) {
+	s := New()
+	if report := s.Report(); len(report.Zones) != 0 {
+		t.Errorf("expected empty report before Start, got %+v", report)
+	}
+
+	s.handler = s.newHandler()
+	for _, step := range []struct {
+		zone  string
+		total int64
+		fame  int64
+	}{
+		{"3005", 1000, 1000},
+		{"@RANDOMDUNGEON@a", 4000, 3000},
+	} {
+		s.handler.OnResponse(2, 0, "", map[byte]interface{}{0: int64(1), 8: step.zone})
+		s.handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+			0:                     int64(1),
+			1:                     step.total * 10000,
+			2:                     step.fame * 10000,
+			events.ParamEventCode: int16(events.EventUpdateFame),
+		})
+	}
+
+	report := s.Report()
+	if len(report.Zones) != 2 || report.Zones[0].Fame != 1000 || report.Zones[1].Fame != 3000 {
+		t.Errorf("unexpected zone breakdown: %+v", report.Zones)
+	}
+	if report.Totals.Fame != s.SessionFame() {
+		t.Errorf("expected total fame %d, got %d", s.SessionFame(), report.Totals.Fame)
+	}
+}
+
+// TestStartStopSession tests measurement windows while the handler keeps processing events
+func TestStartStopSession(t *testing.T) {
+	s := New(WithEventsDisabled(true))
+	if report := s.StopSession(); report.Totals.Fame != 0 {
+		t.Errorf("expected empty report before Start, got %+v", report)
+	}
+
+	s.handler = s.newHandler()
+	handler := s.handler
+	gainFame := func(total, fame int64) {
+		s.handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+			0:                     int64(1),
+			1:                     total * 10000,
+			2:                     fame * 10000,
+			events.ParamEventCode: int16(events.EventUpdateFame),
+		})
+	}
+
+	s.handler.OnResponse(2, 0, "", map[byte]interface{}{0: int64(1), 8: "@RANDOMDUNGEON@a"})
+	gainFame(1000, 1000)
+	gainFame(3000, 2000)
+	s.handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventKilledPlayer),
+	})
+
+	report := s.StopSession()
+	if report.Totals.Fame != 3000 || report.Totals.Kills != 1 {
+		t.Errorf("expected 3000 fame and 1 kill in the completed session, got %+v", report.Totals)
+	}
+	if len(report.Zones) != 1 || report.Zones[0].Zone != "@RANDOMDUNGEON@a" {
+		t.Errorf("unexpected zone breakdown: %+v", report.Zones)
+	}
+
+	// Counters are reset, the zone is kept
+	if s.SessionFame() != 0 || s.SessionKills() != 0 {
+		t.Errorf("expected reset counters, got fame %d, kills %d", s.SessionFame(), s.SessionKills())
+	}
+	if zone := s.handler.GetCurrentZone(); zone != "@RANDOMDUNGEON@a" {
+		t.Errorf("expected current zone to be kept, got %q", zone)
+	}
+
+	// The same handler keeps accumulating into the new window
+	s.StartSession()
+	gainFame(3500, 500)
+	if s.handler != handler {
+		t.Error("expected the handler to be kept across sessions")
+	}
+	if s.SessionFame() != 500 {
+		t.Errorf("expected session fame 500, got %d", s.SessionFame())
+	}
+	report = s.Report()
+	if len(report.Zones) != 1 || report.Zones[0].Fame != 500 {
+		t.Errorf("expected new window in the current zone only, got %+v", report.Zones)
+	}
+}
+
+// ============================================
+// Tests for diagnostics.go
+// ============================================
+
+// TestDiagnosticsWithoutStart tests the snapshot before the service is started
+func TestDiagnosticsWithoutStart(t *testing.T) {
+	d := New().Diagnostics()
+
+	if !d.Healthy || len(d.Issues) != 0 {
+		t.Errorf("expected healthy empty snapshot, got %+v", d)
+	}
+}
+
+// TestDiagnosticsAggregatesStats tests that the snapshot aggregates parser, buffer and kernel counters
+func TestDiagnosticsAggregatesStats(t *testing.T) {
+	s := New()
+	s.parser = photon.NewParser(nil)
+	defer s.parser.Close()
+
+	stats := s.parser.Stats
+	stats.BufferCapacity = 100
+	for i := 0; i < 100; i++ {
+		stats.IncrPacketsReceived()
+	}
+	for i := 0; i < 10; i++ {
+		stats.IncrPacketsMalformed()
+	}
+	stats.IncrPacketsStrictRejected()
+	stats.IncrPacketsEncrypted()
+	stats.IncrPacketsDeduplicated()
+	stats.IncrFragmentsExpired()
+	stats.IncrEventsDropped()
+	stats.IncrEventsDropped()
+	stats.UpdateBufferPeak(95)
+	stats.SnapshotBufferPeak()
+
+	d := s.Diagnostics()
+
+	if d.PacketsReceived != 100 || d.PacketsMalformed != 10 || d.PacketsStrictRejected != 1 ||
+		d.PacketsEncrypted != 1 || d.PacketsDeduplicated != 1 || d.FragmentsExpired != 1 {
+		t.Errorf("unexpected packet counters: %+v", d)
+	}
+	if d.EventsDropped != 2 || d.BufferPeak != 95 || d.BufferCapacity != 100 {
+		t.Errorf("unexpected backpressure counters: %+v", d)
+	}
+	if d.Healthy {
+		t.Error("expected unhealthy snapshot")
+	}
+	// Dropped events, buffer pressure and malformed rate
+	if len(d.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %v", d.Issues)
+	}
+}
+
+// TestBuildDiagnosticsKernelDrops tests kernel drop and fragment reporting
+func TestBuildDiagnosticsKernelDrops(t *testing.T) {
+	d := buildDiagnostics(photon.NewStats(), 60, capture.KernelStats{Received: 1000, Dropped: 3, IfDropped: 2})
+
+	if d.KernelReceived != 1000 || d.KernelDropped != 3 || d.KernelIfDropped != 2 || d.PendingFragments != 60 {
+		t.Errorf("unexpected snapshot: %+v", d)
+	}
+	if d.Healthy || len(d.Issues) != 2 {
+		t.Errorf("expected kernel drop and fragment issues, got %v", d.Issues)
+	}
+	if !strings.Contains(d.Issues[0], "5 packets dropped") {
+		t.Errorf("expected summed kernel drops, got %q", d.Issues[0])
+	}
+}
+
+// ============================================
+// Tests for drops.go
+// ============================================
+
+// TestClassifyDropRate tests the severity classification of drop rates
+func TestClassifyDropRate(t *testing.T) {
+	tests := []struct {
+		rate     float64
+		expected DropSeverity
+	}{
+		{0, DropSeverityOK},
+		{0.1, DropSeverityWarning},
+		{4.9, DropSeverityWarning},
+		{dropRateCritical, DropSeverityCritical},
+		{100, DropSeverityCritical},
+	}
+
+	for _, tt := range tests {
+		if got := classifyDropRate(tt.rate); got != tt.expected {
+			t.Errorf("rate %v: expected %v, got %v", tt.rate, tt.expected, got)
+		}
+	}
+
+	if DropSeverityOK.Suggestion() != "" {
+		t.Error("expected no suggestion when there are no drops")
+	}
+	if DropSeverityCritical.Suggestion() == "" {
+		t.Error("expected a suggestion for critical drops")
+	}
+}
+
+// TestDropRateTracker tests the drop rate over the sliding window
+func TestDropRateTracker(t *testing.T) {
+	var tracker dropRateTracker
+	start := time.Now()
+
+	if tracker.rate() != 0 {
+		t.Errorf("expected rate 0 without samples, got %v", tracker.rate())
+	}
+
+	// A burst of 10 drops, then nothing for a while
+	tracker.record(start, 0)
+	tracker.record(start.Add(time.Second), 10)
+	if rate := tracker.rate(); rate != 10 {
+		t.Errorf("expected rate 10/s, got %v", rate)
+	}
+	if severity := classifyDropRate(tracker.rate()); severity != DropSeverityCritical {
+		t.Errorf("expected critical severity, got %v", severity)
+	}
+
+	for i := 2; i <= 5; i++ {
+		tracker.record(start.Add(time.Duration(i)*time.Second), 10)
+	}
+	if rate := tracker.rate(); rate != 2 {
+		t.Errorf("expected the burst averaged to 2/s, got %v", rate)
+	}
+
+	// Once the burst leaves the window, the rate drops to zero
+	tracker.record(start.Add(dropRateWindow+2*time.Second), 10)
+	if rate := tracker.rate(); rate != 0 {
+		t.Errorf("expected rate 0 after the window, got %v", rate)
+	}
+}
+
+// TestDropSeverityWithoutStart tests that a service that never ran reports no drops
+func TestDropSeverityWithoutStart(t *testing.T) {
+	s := New()
+	if s.DropSeverity() != DropSeverityOK || s.DropRate() != 0 {
+		t.Errorf("expected ok severity, got %v (%v/s)", s.DropSeverity(), s.DropRate())
+	}
+}
+
+// ============================================
+// Tests for reorder.go
+// ============================================
+
+// newRecordingReorderBuffer creates a reorder buffer that records emitted events
+func newRecordingReorderBuffer(window time.Duration) (*reorderBuffer, *[]GameEvent) {
+	var emitted []GameEvent
+	b := newReorderBuffer(window, func(event GameEvent) {
+		emitted = append(emitted, event)
+	})
+	return b, &emitted
+}
+
+// TestReorderBufferFlushSorted tests that out-of-order events are flushed in timestamp order
+func TestReorderBufferFlushSorted(t *testing.T) {
+	b, emitted := newRecordingReorderBuffer(20 * time.Millisecond)
+	base := time.Now()
+
+	b.add(GameEvent{Message: "c", Timestamp: base.Add(3 * time.Millisecond)})
+	b.add(GameEvent{Message: "a", Timestamp: base.Add(1 * time.Millisecond)})
+	b.add(GameEvent{Message: "d", Timestamp: base.Add(4 * time.Millisecond)})
+	b.add(GameEvent{Message: "b", Timestamp: base.Add(2 * time.Millisecond)})
+
+	b.flushAll()
+
+	var order []string
+	for _, e := range *emitted {
+		order = append(order, e.Message)
+	}
+	if strings.Join(order, "") != "abcd" {
+		t.Errorf("expected order abcd, got %v", order)
+	}
+	if len(b.pending) != 0 {
+		t.Errorf("expected no pending events, got %d", len(b.pending))
+	}
+}
+
+// TestReorderBufferTiesKeepArrivalOrder tests that events with equal timestamps keep their arrival order
+func TestReorderBufferTiesKeepArrivalOrder(t *testing.T) {
+	b, emitted := newRecordingReorderBuffer(20 * time.Millisecond)
+	ts := time.Now()
+
+	b.add(GameEvent{Message: "first", Timestamp: ts})
+	b.add(GameEvent{Message: "second", Timestamp: ts})
+	b.add(GameEvent{Message: "third", Timestamp: ts})
+
+	b.flushAll()
+
+	if len(*emitted) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(*emitted))
+	}
+	for i, want := range []string{"first", "second", "third"} {
+		if (*emitted)[i].Message != want {
+			t.Errorf("expected event %d to be %q, got %q", i, want, (*emitted)[i].Message)
+		}
+	}
+}
+
+// TestReorderBufferFlushBefore tests that events newer than the cutoff stay buffered
+func TestReorderBufferFlushBefore(t *testing.T) {
+	b, emitted := newRecordingReorderBuffer(20 * time.Millisecond)
+	base := time.Now()
+
+	b.add(GameEvent{Message: "late", Timestamp: base.Add(30 * time.Millisecond)})
+	b.add(GameEvent{Message: "early", Timestamp: base.Add(10 * time.Millisecond)})
+
+	b.flushBefore(base.Add(10 * time.Millisecond))
+
+	if len(*emitted) != 1 || (*emitted)[0].Message != "early" {
+		t.Fatalf("expected only the early event, got %v", *emitted)
+	}
+	if len(b.pending) != 1 {
+		t.Errorf("expected 1 pending event, got %d", len(b.pending))
+	}
+
+	// An event arriving later but timestamped before the pending one is released first
+	b.add(GameEvent{Message: "delayed", Timestamp: base.Add(20 * time.Millisecond)})
+	b.flushAll()
+
+	if len(*emitted) != 3 || (*emitted)[1].Message != "delayed" || (*emitted)[2].Message != "late" {
+		t.Errorf("expected delayed before late, got %v", *emitted)
+	}
+}
+
+// TestReorderBufferRun tests that the background loop releases events after the window
+func TestReorderBufferRun(t *testing.T) {
+	var mu sync.Mutex
+	var emitted []GameEvent
+	b := newReorderBuffer(5*time.Millisecond, func(event GameEvent) {
+		mu.Lock()
+		emitted = append(emitted, event)
+		mu.Unlock()
+	})
+
+	stop := make(chan struct{})
+	go b.run(stop)
+
+	now := time.Now()
+	b.add(GameEvent{Message: "b", Timestamp: now})
+	b.add(GameEvent{Message: "a", Timestamp: now.Add(-time.Millisecond)})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(emitted)
+		mu.Unlock()
+		if n == 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(stop)
+	b.wait()
+
+	if len(emitted) != 2 || emitted[0].Message != "a" || emitted[1].Message != "b" {
+		t.Errorf("expected events a, b, got %v", emitted)
+	}
+}
+
+// TestWithEventReorderWindow tests the reorder window option
+func TestWithEventReorderWindow(t *testing.T) {
+	s := New()
+	if s.reorderWindow != 0 {
+		t.Errorf("expected reordering disabled by default, got %v", s.reorderWindow)
+	}
+
+	s = New(WithEventReorderWindow(20 * time.Millisecond))
+	if s.reorderWindow != 20*time.Millisecond {
+		t.Errorf("expected reorderWindow 20ms, got %v", s.reorderWindow)
+	}
+}
+
+// ============================================
+// Pipeline integration tests
+// ============================================
+
+// TestPipelineReplay tests the capture, parse, handle and channel path by replaying
+// testdata/session.pcap (regenerate with: go run testdata/gen_session_pcap.go)
+func TestPipelineReplay(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Wait()
+
+	if s.SessionFame() != 100 {
+		t.Errorf("expected session fame 100, got %d", s.SessionFame())
+	}
+	if s.SessionSilver() != 250 {
+		t.Errorf("expected session silver 250, got %d", s.SessionSilver())
+	}
+	if s.SessionLoot() != 1 {
+		t.Errorf("expected 1 looted item, got %d", s.SessionLoot())
+	}
+	if s.SessionKills() != 1 {
+		t.Errorf("expected 1 kill, got %d", s.SessionKills())
+	}
+
+	s.Stop()
+
+	var got []GameEvent
+	for event := range s.Events {
+		got = append(got, event)
+	}
+
+	expected := []EventType{EventTypeInfo, EventTypeFame, EventTypeSilver, EventTypeLoot, EventTypeKill}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %v", len(expected), len(got), got)
+	}
+	for i, event := range got {
+		if event.Type != expected[i] {
+			t.Errorf("event %d: expected type %s, got %s", i, expected[i], event.Type)
+		}
+	}
+
+	if got[0].Message != "Albion Online detected! Capturing packets..." {
+		t.Errorf("expected online message, got %q", got[0].Message)
+	}
+	if data, ok := got[1].Data.(*handlers.FameEventData); !ok || data.Gained != 100 || data.Total != 5000 {
+		t.Errorf("expected 100 of 5000 fame, got %+v", got[1].Data)
+	}
+	if data, ok := got[2].Data.(*handlers.SilverEventData); !ok || data.Amount != 250 || data.LootedFrom != "Mob" {
+		t.Errorf("expected 250 silver from Mob, got %+v", got[2].Data)
+	}
+	if data, ok := got[3].Data.(*handlers.LootEventData); !ok || data.ItemID != 1234 || data.Quantity != 2 || data.LootedFrom != "Chest" {
+		t.Errorf("expected 2x item 1234 from Chest, got %+v", got[3].Data)
+	}
+	if data, ok := got[4].Data.(*handlers.KillEventData); !ok || data.SessionKills != 1 {
+		t.Errorf("expected first kill, got %+v", got[4].Data)
+	}
+}
+
+// TestPipelineReplayLoop tests that a looping replay feeds the session until Stop
+func TestPipelineReplayLoop(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithReplayLoop(true), WithEventsDisabled(true))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.SessionKills() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.Stop()
+
+	if s.SessionKills() < 3 {
+		t.Errorf("expected a kill per pass over at least 3 passes, got %d", s.SessionKills())
+	}
+}
+
+// TestStartStopUnderLoad tests that callbacks still firing while the service stops
+// don't send on closed channels (run with -race)
+func TestStartStopUnderLoad(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")))
+		if err := s.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					s.capture.OnlineCallback(true)
+					s.emitEvent(GameEvent{Type: EventTypeInfo, Timestamp: time.Now()})
+					s.sendStats()
+				}
+			}()
+		}
+
+		s.Stop()
+		for range s.Events {
+		}
+
+		// Late callbacks after Stop are dropped
+		s.capture.OnlineCallback(false)
+		s.emitEvent(GameEvent{Type: EventTypeInfo, Timestamp: time.Now()})
+
+		close(done)
+		wg.Wait()
+	}
+}
+
+// TestPipelineAutosaveResume tests that an autosaved session resumes on Start and is saved on Stop
+func TestPipelineAutosaveResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte(`{"session_fame": 1000, "session_kills": 2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithAutosave(0, path))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.capture.Wait()
+
+	if s.SessionFame() != 1100 {
+		t.Errorf("expected session fame to resume at 1100, got %d", s.SessionFame())
+	}
+	if s.SessionKills() != 3 {
+		t.Errorf("expected session kills to resume at 3, got %d", s.SessionKills())
+	}
+	s.Stop()
+
+	restored := New()
+	restored.handler = restored.newHandler()
+	if err := restored.LoadSession(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.SessionFame() != 1100 || restored.SessionSilver() != 250 {
+		t.Errorf("expected saved fame 1100 and silver 250, got %d and %d", restored.SessionFame(), restored.SessionSilver())
+	}
+}
+
+// ============================================
+// Tests for metrics.go
+// ============================================
+
+// TestMetricsServer tests that parser stats and session counters are served and the server stops with the service
+func TestMetricsServer(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithMetricsServer("127.0.0.1:0"))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.capture.Wait()
+
+	url := "http://" + s.MetricsAddr() + "/metrics"
+	resp, err := http.Get(url)
+	if err != nil {
+		s.Stop()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	for _, line := range []string{
+		"albion_lens_session_fame 100",
+		"albion_lens_session_silver 250",
+		"albion_lens_session_kills 1",
+		"albion_lens_packets_received_total 4",
+		"albion_lens_events_decoded_total 4",
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("expected metric %q in:\n%s", line, body)
+		}
+	}
+
+	s.Stop()
+	if _, err := http.Get(url); err == nil {
+		t.Error("expected metrics server to be stopped")
+	}
+}
+
+// TestMetricsServerAddrInUse tests that Start fails when the metrics address is taken
+func TestMetricsServerAddrInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithMetricsServer(listener.Addr().String()))
+	if err := s.Start(); err == nil {
+		s.Stop()
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestStartFailureCleansUp tests that a failed start closes the event log and store
+// and stops the goroutines started before the failure
+func TestStartFailureCleansUp(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	s := New(
+		WithPcapFile(filepath.Join("testdata", "session.pcap")),
+		WithEventLogFile(filepath.Join(t.TempDir(), "events.jsonl")),
+		WithSQLiteStore(filepath.Join(t.TempDir(), "events.db")),
+		WithEventReorderWindow(50*time.Millisecond),
+		WithMetricsServer(listener.Addr().String()),
+	)
+	if err := s.Start(); err == nil {
+		s.Stop()
+		t.Fatal("expected error, got nil")
+	}
+
+	select {
+	case <-s.stopChan:
+	default:
+		t.Error("expected the background goroutines to be signaled to stop")
+	}
+	if s.store != nil {
+		t.Error("expected the SQLite store to be closed")
+	}
+	if s.exporter != nil {
+		t.Error("expected the event log to be closed")
+	}
+	if s.IsRunning() {
+		t.Error("service should not be running after a failed start")
+	}
+}
+
+// ============================================
+// Tests for websocket.go
+// ============================================
+
+// dialEventStream connects to the event stream of s and waits until the client is registered
+func dialEventStream(t *testing.T, s *Service) *websocket.Conn {
+	t.Helper()
+	addr := s.WebSocketAddr()
+	conn, err := websocket.Dial("ws://"+addr+"/events", "", "http://"+addr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		s.websocket.mu.Lock()
+		n := len(s.websocket.clients)
+		s.websocket.mu.Unlock()
+		if n > 0 {
+			return conn
+		}
+	}
+	conn.Close()
+	t.Fatal("client was not registered")
+	return nil
+}
+
+// TestWebSocketBroadcast tests that events reach both WebSocket clients and the Events channel
+func TestWebSocketBroadcast(t *testing.T) {
+	ws, err := startWebSocketServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := New()
+	s.websocket = ws
+	defer ws.close()
+
+	conn := dialEventStream(t, s)
+	defer conn.Close()
+
+	timestamp := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.emitEvent(GameEvent{
+		Type:      EventTypeFame,
+		Message:   "fame",
+		Timestamp: timestamp,
+		Data:      &handlers.FameEventData{Gained: 100, Total: 1100},
+	})
+
+	select {
+	case event := <-s.Events:
+		if event.Type != EventTypeFame {
+			t.Errorf("expected fame event on the Events channel, got %q", event.Type)
+		}
+	default:
+		t.Error("expected event on the Events channel")
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg string
+	if err := websocket.Message.Receive(conn, &msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var record struct {
+		Type      EventType
+		Timestamp time.Time
+		Message   string
+		Data      handlers.FameEventData
+	}
+	if err := json.Unmarshal([]byte(msg), &record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Type != EventTypeFame || record.Message != "fame" || !record.Timestamp.Equal(timestamp) {
+		t.Errorf("expected fame event at %v, got %s", timestamp, msg)
+	}
+	if record.Data.Gained != 100 || record.Data.Total != 1100 {
+		t.Errorf("expected fame data 100/1100, got %s", msg)
+	}
+}
+
+// TestWebSocketOrigin tests that browser clients are only accepted from the server's
+// own host or an allowed origin, and clients without an Origin header always are
+func TestWebSocketOrigin(t *testing.T) {
+	allowed := []string{"http://localhost:3000"}
+
+	tests := []struct {
+		name   string
+		origin string
+		ok     bool
+	}{
+		{"no origin", "", true},
+		{"same host", "http://127.0.0.1:8080", true},
+		{"same host other port", "http://127.0.0.1:1234", true},
+		{"allowed origin", "http://localhost:3000", true},
+		{"other origin", "https://example.com", false},
+		{"other port of allowed host", "http://localhost:3001", false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/events", nil)
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		err := checkOrigin(&websocket.Config{}, req, allowed)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: expected accepted=%v, got error %v", tt.name, tt.ok, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/events", nil)
+	req.Header.Set("Origin", "https://example.com")
+	if err := checkOrigin(&websocket.Config{}, req, []string{"*"}); err != nil {
+		t.Errorf("expected any origin to be accepted with \"*\", got %v", err)
+	}
+}
+
+// TestWebSocketRejectsOtherOrigin tests that the server refuses a browser client from another site
+func TestWebSocketRejectsOtherOrigin(t *testing.T) {
+	ws, err := startWebSocketServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer ws.close()
+
+	addr := ws.listener.Addr().String()
+	if conn, err := websocket.Dial("ws://"+addr+"/events", "", "https://example.com"); err == nil {
+		conn.Close()
+		t.Error("expected a client from another origin to be rejected")
+	}
+}
+
+// TestWebSocketSlowClient tests that a client that doesn't read never blocks broadcasting
+func TestWebSocketSlowClient(t *testing.T) {
+	ws, err := startWebSocketServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := New(WithEventBufferSize(1))
+	s.websocket = ws
+	defer ws.close()
+
+	conn := dialEventStream(t, s)
+	defer conn.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10*wsClientBuffer; i++ {
+			s.emitEvent(GameEvent{Type: EventTypeInfo, Message: strings.Repeat("x", 1024), Timestamp: time.Now()})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("broadcast blocked on a slow client")
+	}
+}
+
+// TestWebSocketStop tests that Stop disconnects clients and closes the server
+func TestWebSocketStop(t *testing.T) {
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithWebSocketServer("127.0.0.1:0"))
+	if err := s.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addr := s.WebSocketAddr()
+	conn := dialEventStream(t, s)
+	defer conn.Close()
+
+	s.Stop()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg string
+	for {
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				t.Error("expected client to be disconnected on stop")
+			}
+			break
+		}
+	}
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Error("expected WebSocket server to be stopped")
+	}
+}
+
+// TestWebSocketAddrInUse tests that Start fails when the WebSocket address is taken
+func TestWebSocketAddrInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	s := New(WithPcapFile(filepath.Join("testdata", "session.pcap")), WithWebSocketServer(listener.Addr().String()))
+	if err := s.Start(); err == nil {
+		s.Stop()
+		t.Error("expected error, got nil")
+	}
+}
+
+// ============================================
+// Tests for filter.go
+// ============================================
+
+// TestEventFilter tests that only the filtered event types reach the Events channel
+func TestEventFilter(t *testing.T) {
+	s := New(WithEventFilter(EventTypeFame, EventTypeSilver))
+
+	for _, eventType := range []EventType{EventTypeInfo, EventTypeFame, EventTypeCombat, EventTypeSilver} {
+		s.emitEvent(GameEvent{Type: eventType, Timestamp: time.Now()})
+	}
+	if len(s.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(s.Events))
+	}
+	if event := <-s.Events; event.Type != EventTypeFame {
+		t.Errorf("expected fame event, got %s", event.Type)
+	}
+	if event := <-s.Events; event.Type != EventTypeSilver {
+		t.Errorf("expected silver event, got %s", event.Type)
+	}
+	if len(s.EventFilter()) != 2 {
+		t.Errorf("expected 2 filtered types, got %v", s.EventFilter())
+	}
+}
+
+// TestWithEventRateLimit tests that rate limited events are throttled before the Events
+// channel and counted apart from dropped events
+func TestWithEventRateLimit(t *testing.T) {
+	s := New(WithEventRateLimit(EventTypeKill, 2))
+	s.handler = s.newHandler()
+	s.parser = photon.NewParser(s.handler)
+	s.handler.SetThrottleCallback(s.parser.Stats.IncrEventsThrottled)
+
+	for i := 0; i < 5; i++ {
+		s.handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{
+			events.ParamEventCode: int16(events.EventKilledPlayer),
+		})
+	}
+
+	if len(s.Events) != 2 {
+		t.Errorf("expected 2 kill events, got %d", len(s.Events))
+	}
+	if throttled := s.parser.Stats.GetEventsThrottled(); throttled != 3 {
+		t.Errorf("expected 3 throttled events, got %d", throttled)
+	}
+	if dropped := s.parser.Stats.GetEventsDropped(); dropped != 0 {
+		t.Errorf("expected no dropped events, got %d", dropped)
+	}
+	if s.SessionKills() != 5 {
+		t.Errorf("expected throttling to keep counting kills, got %d", s.SessionKills())
+	}
+}
+
+// TestSetEventFilter tests changing the filter at runtime, and clearing it
+func TestSetEventFilter(t *testing.T) {
+	s := New()
+	if s.EventFilter() != nil {
+		t.Errorf("expected no filter by default, got %v", s.EventFilter())
+	}
+
+	s.SetEventFilter(EventTypeKill)
+	s.emitEvent(GameEvent{Type: EventTypeFame, Timestamp: time.Now()})
+	s.emitEvent(GameEvent{Type: EventTypeKill, Timestamp: time.Now()})
+	if len(s.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(s.Events))
+	}
+	<-s.Events
+
+	s.SetEventFilter()
+	if s.EventFilter() != nil {
+		t.Errorf("expected filter to be cleared, got %v", s.EventFilter())
+	}
+	s.emitEvent(GameEvent{Type: EventTypeFame, Timestamp: time.Now()})
+	if len(s.Events) != 1 {
+		t.Errorf("expected 1 event after clearing the filter, got %d", len(s.Events))
+	}
+}
@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+)
+
+// binaryLogMagic starts every segment of a binary event log.
+// Each segment is a gob stream written by one session; appending to an existing log
+// starts a new segment. The first byte can never start a gob message, so a segment
+// boundary is never mistaken for a record.
+var binaryLogMagic = []byte{0x89, 'A', 'L', 'E', 'V', '1', '\r', '\n'}
+
+// binaryDataTypes are the GameEvent.Data types that can be stored in a binary log.
+// Events with other data types are stored without data.
+var binaryDataTypes = []interface{}{
+	&handlers.FameEventData{},
+	&handlers.SilverEventData{},
+	&handlers.LootEventData{},
+	&handlers.KillEventData{},
+	&handlers.DeathEventData{},
+	&handlers.RewardEventData{},
+	&handlers.CombatEventData{},
+	&handlers.ConsumeEventData{},
+	&handlers.ChestEventData{},
+	&handlers.SocialEventData{},
+	&handlers.MatchEventData{},
+	&handlers.ChatEventData{},
+	&handlers.HarvestEventData{},
+	&handlers.FishingEventData{},
+	&handlers.MarketEventData{},
+	&handlers.DamageEventData{},
+	events.EventCode(0),
+	&SessionReport{},
+}
+
+// binaryDataTypeSet is the set of binaryDataTypes for lookups
+var binaryDataTypeSet = make(map[reflect.Type]bool)
+
+func init() {
+	for _, v := range binaryDataTypes {
+		gob.Register(v)
+		binaryDataTypeSet[reflect.TypeOf(v)] = true
+	}
+}
+
+// binaryRecord is the binary log representation of a GameEvent
+type binaryRecord struct {
+	Type      EventType
+	Timestamp time.Time
+	Message   string
+	Data      interface{}
+}
+
+// binaryEventWriter encodes GameEvents as one binary log segment
+type binaryEventWriter struct {
+	enc *gob.Encoder
+}
+
+// newBinaryEventWriter starts a new segment on w
+func newBinaryEventWriter(w io.Writer) (*binaryEventWriter, error) {
+	if _, err := w.Write(binaryLogMagic); err != nil {
+		return nil, err
+	}
+	return &binaryEventWriter{enc: gob.NewEncoder(w)}, nil
+}
+
+// write encodes a single event
+func (b *binaryEventWriter) write(event GameEvent) error {
+	data := event.Data
+	if data != nil && !binaryDataTypeSet[reflect.TypeOf(data)] {
+		data = nil
+	}
+	return b.enc.Encode(binaryRecord{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Message:   event.Message,
+		Data:      data,
+	})
+}
+
+// BinaryEventReader reads GameEvents from a binary event log (ExportFormatBinary)
+type BinaryEventReader struct {
+	r   *bufio.Reader
+	dec *gob.Decoder
+}
+
+// NewBinaryEventReader creates a reader for a binary event log
+func NewBinaryEventReader(r io.Reader) *BinaryEventReader {
+	return &BinaryEventReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event in the log, or io.EOF at the end of the log.
+// A log truncated mid-record (e.g. by a crash) returns io.ErrUnexpectedEOF.
+func (b *BinaryEventReader) Next() (GameEvent, error) {
+	if b.dec == nil || b.atSegmentStart() {
+		if err := b.readMagic(); err != nil {
+			return GameEvent{}, err
+		}
+		b.dec = gob.NewDecoder(b.r)
+	}
+
+	var record binaryRecord
+	if err := b.dec.Decode(&record); err != nil {
+		return GameEvent{}, err
+	}
+	return GameEvent{
+		Type:      record.Type,
+		Timestamp: record.Timestamp,
+		Message:   record.Message,
+		Data:      record.Data,
+	}, nil
+}
+
+// atSegmentStart reports whether the next bytes start a new segment
+func (b *BinaryEventReader) atSegmentStart() bool {
+	next, err := b.r.Peek(1)
+	return err == nil && next[0] == binaryLogMagic[0]
+}
+
+// readMagic consumes a segment header
+func (b *BinaryEventReader) readMagic() error {
+	header := make([]byte, len(binaryLogMagic))
+	n, err := io.ReadFull(b.r, header)
+	if n == 0 && errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(header, binaryLogMagic) {
+		return fmt.Errorf("not a binary event log")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cantalupo555/albion-lens/pkg/capture"
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+)
+
+// Diagnostics thresholds
+const (
+	// bufferPressureRatio is the event buffer usage above which backpressure is reported
+	bufferPressureRatio = 0.9
+	// malformedRatio is the share of malformed packets above which parsing is reported unhealthy
+	malformedRatio = 0.05
+	// maxHealthyPendingFragments is the number of incomplete fragmented messages above
+	// which fragment loss is reported
+	maxHealthyPendingFragments = 50
+)
+
+// DiagnosticsSnapshot gathers parser health and backpressure indicators in one place
+type DiagnosticsSnapshot struct {
+	// Packet pipeline
+	PacketsReceived       uint64 `json:"packets_received"`
+	PacketsMalformed      uint64 `json:"packets_malformed"`
+	PacketsStrictRejected uint64 `json:"packets_strict_rejected"`
+	PacketsCRCFailed      uint64 `json:"packets_crc_failed"`
+	PacketsEncrypted      uint64 `json:"packets_encrypted"`
+	PacketsDeduplicated   uint64 `json:"packets_deduplicated"`
+
+	// Fragment reassembly
+	PendingFragments int    `json:"pending_fragments"`
+	FragmentsExpired uint64 `json:"fragments_expired"`
+
+	// Backpressure
+	EventsDropped   uint64 `json:"events_dropped"`
+	EventsThrottled uint64 `json:"events_throttled"`
+	BufferPeak      int64  `json:"buffer_peak"`
+	BufferCapacity  int    `json:"buffer_capacity"`
+
+	// Kernel (pcap) counters
+	KernelReceived  uint64 `json:"kernel_received"`
+	KernelDropped   uint64 `json:"kernel_dropped"`
+	KernelIfDropped uint64 `json:"kernel_if_dropped"`
+
+	// Overall health
+	Healthy bool     `json:"healthy"`
+	Issues  []string `json:"issues,omitempty"`
+}
+
+// Diagnostics returns a snapshot of capture and parser health.
+// Before Start, the snapshot is empty and healthy.
+func (s *Service) Diagnostics() DiagnosticsSnapshot {
+	var stats *photon.Stats
+	var pending int
+	if s.parser != nil {
+		stats = s.parser.Stats
+		pending = s.parser.PendingFragmentsCount()
+	}
+
+	var kernel capture.KernelStats
+	if s.capture != nil {
+		kernel = s.capture.KernelStats()
+	}
+
+	return buildDiagnostics(stats, pending, kernel)
+}
+
+// buildDiagnostics aggregates the individual counters into a snapshot and evaluates health
+func buildDiagnostics(stats *photon.Stats, pendingFragments int, kernel capture.KernelStats) DiagnosticsSnapshot {
+	d := DiagnosticsSnapshot{
+		PendingFragments: pendingFragments,
+		KernelReceived:   kernel.Received,
+		KernelDropped:    kernel.Dropped,
+		KernelIfDropped:  kernel.IfDropped,
+	}
+	if stats != nil {
+		d.PacketsReceived = stats.GetPacketsReceived()
+		d.PacketsMalformed = stats.GetPacketsMalformed()
+		d.PacketsStrictRejected = stats.GetPacketsStrictRejected()
+		d.PacketsCRCFailed = stats.GetPacketsCRCFailed()
+		d.PacketsEncrypted = stats.GetPacketsEncrypted()
+		d.PacketsDeduplicated = stats.GetPacketsDeduplicated()
+		d.FragmentsExpired = stats.GetFragmentsExpired()
+		d.EventsDropped = stats.GetEventsDropped()
+		d.EventsThrottled = stats.GetEventsThrottled()
+		d.BufferPeak = atomic.LoadInt64(&stats.BufferPeakDisplay)
+		d.BufferCapacity = stats.BufferCapacity
+	}
+
+	if d.EventsDropped > 0 {
+		d.Issues = append(d.Issues, fmt.Sprintf("%d events dropped (frontend too slow)", d.EventsDropped))
+	}
+	if d.BufferCapacity > 0 && float64(d.BufferPeak) >= float64(d.BufferCapacity)*bufferPressureRatio {
+		d.Issues = append(d.Issues, fmt.Sprintf("event buffer near capacity (%d/%d)", d.BufferPeak, d.BufferCapacity))
+	}
+	if kernelDrops := d.KernelDropped + d.KernelIfDropped; kernelDrops > 0 {
+		d.Issues = append(d.Issues, fmt.Sprintf("%d packets dropped by the capture driver", kernelDrops))
+	}
+	if d.PacketsReceived > 0 && float64(d.PacketsMalformed) > float64(d.PacketsReceived)*malformedRatio {
+		d.Issues = append(d.Issues, fmt.Sprintf("%d of %d packets malformed", d.PacketsMalformed, d.PacketsReceived))
+	}
+	if d.PendingFragments > maxHealthyPendingFragments {
+		d.Issues = append(d.Issues, fmt.Sprintf("%d incomplete fragmented messages", d.PendingFragments))
+	}
+	d.Healthy = len(d.Issues) == 0
+
+	return d
+}
@@ -0,0 +1,109 @@
+package backend
+
+import (
+	"sync"
+	"time"
+)
+
+// Drop rate thresholds (dropped events per second over dropRateWindow)
+const (
+	// dropRateWindow is the recent window the drop rate is measured over
+	dropRateWindow = 10 * time.Second
+	// dropRateCritical is the drop rate above which drops are a persistent problem
+	dropRateCritical = 5.0
+)
+
+// DropSeverity classifies the recent event drop rate
+type DropSeverity int
+
+const (
+	DropSeverityOK       DropSeverity = iota // No drops in the recent window
+	DropSeverityWarning                      // Occasional drops (a transient blip)
+	DropSeverityCritical                     // Sustained drops, events are being lost continuously
+)
+
+// String returns the name of the severity
+func (d DropSeverity) String() string {
+	switch d {
+	case DropSeverityWarning:
+		return "warning"
+	case DropSeverityCritical:
+		return "critical"
+	}
+	return "ok"
+}
+
+// Suggestion returns actionable guidance for the severity, empty when there is nothing to do
+func (d DropSeverity) Suggestion() string {
+	switch d {
+	case DropSeverityWarning:
+		return "transient drops, watch if they persist"
+	case DropSeverityCritical:
+		return "increase the event buffer size or lower -fps"
+	}
+	return ""
+}
+
+// classifyDropRate classifies a drop rate (dropped events per second)
+func classifyDropRate(rate float64) DropSeverity {
+	switch {
+	case rate <= 0:
+		return DropSeverityOK
+	case rate < dropRateCritical:
+		return DropSeverityWarning
+	}
+	return DropSeverityCritical
+}
+
+// dropSample is the dropped event counter at a point in time
+type dropSample struct {
+	at      time.Time
+	dropped uint64
+}
+
+// dropRateTracker measures the drop rate over a sliding window of counter samples
+type dropRateTracker struct {
+	samples []dropSample
+	mu      sync.Mutex
+}
+
+// record adds a sample of the dropped event counter and discards samples outside the window
+func (t *dropRateTracker) record(now time.Time, dropped uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.samples = append(t.samples, dropSample{at: now, dropped: dropped})
+
+	// Keep one sample at or before the window start as the baseline
+	cutoff := now.Add(-dropRateWindow)
+	for len(t.samples) > 2 && !t.samples[1].at.After(cutoff) {
+		t.samples = t.samples[1:]
+	}
+}
+
+// rate returns the drops per second between the oldest and newest samples
+func (t *dropRateTracker) rate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.samples) < 2 {
+		return 0
+	}
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 || last.dropped <= first.dropped {
+		return 0
+	}
+	return float64(last.dropped-first.dropped) / elapsed
+}
+
+// DropRate returns the events dropped per second over the last few seconds
+func (s *Service) DropRate() float64 {
+	return s.drops.rate()
+}
+
+// DropSeverity classifies the recent drop rate, telling a transient blip
+// (DropSeverityWarning) from a persistent problem (DropSeverityCritical)
+func (s *Service) DropSeverity() DropSeverity {
+	return classifyDropRate(s.DropRate())
+}
@@ -8,12 +8,25 @@ import "time"
 type EventType string
 
 const (
-	EventTypeFame   EventType = "fame"
-	EventTypeSilver EventType = "silver"
-	EventTypeLoot   EventType = "loot"
-	EventTypeKill   EventType = "kill"
-	EventTypeDeath  EventType = "death"
-	EventTypeInfo   EventType = "info"
+	EventTypeFame    EventType = "fame"
+	EventTypeSilver  EventType = "silver"
+	EventTypeLoot    EventType = "loot"
+	EventTypeKill    EventType = "kill"
+	EventTypeDeath   EventType = "death"
+	EventTypeInfo    EventType = "info"
+	EventTypeReward  EventType = "reward"
+	EventTypeCombat  EventType = "combat"
+	EventTypeConsume EventType = "consume"
+	EventTypeChest   EventType = "chest"
+	EventTypeSocial  EventType = "social"
+	EventTypeMatch   EventType = "match"
+	EventTypeChat    EventType = "chat"
+	EventTypeHarvest EventType = "harvest"
+	EventTypeFishing EventType = "fishing"
+	EventTypeMarket  EventType = "market"
+	EventTypeTrade   EventType = "trade"
+	EventTypeWarning EventType = "warning"
+	EventTypeReport  EventType = "report"
 )
 
 // GameEvent represents a game event for display in frontends
@@ -22,6 +35,7 @@ type GameEvent struct {
 	Message   string      // Formatted message to display
 	Timestamp time.Time   // When the event occurred
 	Data      interface{} // Optional structured data for specific event types
+	Reliable  bool        // Whether the event was delivered reliably (unreliable events are best-effort)
 }
 
 // FameData contains fame-specific event data
@@ -41,7 +55,7 @@ type SilverData struct {
 type LootData struct {
 	ItemName string // Name of the item
 	ItemID   int32  // Item ID
-	Quantity int32  // Number of items
+	Quantity int64  // Number of items
 	LootedBy string // Player who looted
 	From     string // Source of loot
 }
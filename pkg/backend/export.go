@@ -0,0 +1,305 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+)
+
+// ExportFormat selects how events are written to the event log file
+type ExportFormat string
+
+const (
+	// ExportFormatJSONL writes one JSON object per event (native format):
+	//   {"version":1,"type":"loot","timestamp":"...","message":"...","data":{...}}
+	// version is jsonlSchemaVersion and changes whenever the record layout does.
+	ExportFormatJSONL ExportFormat = "jsonl"
+
+	// ExportFormatLootLogger writes semicolon-separated lines using the column layout
+	// of ao-loot-logger, so the file can be loaded by tools that read its logs:
+	//   timestamp_utc;looted_by__alliance;looted_by__guild;looted_by__name;item_id;item_name;quantity;looted_from__alliance;looted_from__guild;looted_from__name
+	//
+	// Mapping (alliance and guild columns are always empty):
+	//   loot   -> looted_by=LootedBy, item_id=UniqueName (numeric ID if unknown), item_name, quantity, looted_from=LootedFrom
+	//   silver -> item_id="@SILVER", item_name="Silver", quantity=Amount, looted_by/looted_from as looted
+	//   fame   -> item_id="@FAME", item_name="Fame", quantity=Gained
+	//   reward -> one row per item, plus "@SILVER"/"@FAME" rows; looted_from="Reward"
+	//   kill   -> item_id="@KILL", item_name="Kill", quantity=1
+	//   death  -> item_id="@DEATH", item_name="Death", quantity=1, looted_by=Killer, looted_from=Victim
+	// Other event types are not exported in this format.
+	ExportFormatLootLogger ExportFormat = "ao-loot-logger"
+
+	// ExportFormatBinary writes a compact gob-encoded log that is much faster to
+	// read back than JSONL for multi-hour sessions. Read it with BinaryEventReader.
+	ExportFormatBinary ExportFormat = "binary"
+)
+
+// jsonlSchemaVersion is the version written in every JSONL record
+const jsonlSchemaVersion = 1
+
+const (
+	// eventLogBufferSize is how many events may wait for the writer goroutine
+	// before new ones are dropped
+	eventLogBufferSize = 1024
+
+	// eventLogFlushInterval is how often buffered events are flushed to the file
+	eventLogFlushInterval = time.Second
+)
+
+// lootLoggerHeader is the header line of the ao-loot-logger format
+const lootLoggerHeader = "timestamp_utc;looted_by__alliance;looted_by__guild;looted_by__name;item_id;item_name;quantity;looted_from__alliance;looted_from__guild;looted_from__name"
+
+// ParseExportFormat converts a string into an ExportFormat
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch ExportFormat(format) {
+	case ExportFormatJSONL, ExportFormatLootLogger, ExportFormatBinary:
+		return ExportFormat(format), nil
+	}
+	return "", fmt.Errorf("unknown export format: %q (expected %q, %q or %q)", format, ExportFormatJSONL, ExportFormatLootLogger, ExportFormatBinary)
+}
+
+// eventExporter writes GameEvents to a file in the selected format.
+// Once started, events are queued and written by a dedicated goroutine so
+// the capture path never waits on the disk.
+type eventExporter struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	format ExportFormat
+	binary *binaryEventWriter
+
+	queue    chan GameEvent
+	onDrop   func()
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newEventExporter opens (or creates) the event log file for appending
+func newEventExporter(path string, format ExportFormat) (*eventExporter, error) {
+	if format == "" {
+		format = ExportFormatJSONL
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &eventExporter{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		format: format,
+	}
+
+	switch format {
+	case ExportFormatLootLogger:
+		// Write the header when starting a new ao-loot-logger file
+		if info, err := file.Stat(); err == nil && info.Size() == 0 {
+			_, _ = e.writer.WriteString(lootLoggerHeader + "\n")
+		}
+	case ExportFormatBinary:
+		// Every session appends a new segment
+		e.binary, err = newBinaryEventWriter(e.writer)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// start launches the writer goroutine; onDrop is called for each event dropped
+// because the queue is full
+func (e *eventExporter) start(onDrop func()) {
+	e.queue = make(chan GameEvent, eventLogBufferSize)
+	e.onDrop = onDrop
+	e.done = make(chan struct{})
+	go e.run()
+}
+
+// run writes queued events, flushing periodically, until the queue is closed
+func (e *eventExporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(eventLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-e.queue:
+			if !ok {
+				return
+			}
+			_ = e.write(event)
+		case <-ticker.C:
+			_ = e.flush()
+		}
+	}
+}
+
+// enqueue hands an event to the writer goroutine without blocking
+func (e *eventExporter) enqueue(event GameEvent) {
+	select {
+	case e.queue <- event:
+	default:
+		if e.onDrop != nil {
+			e.onDrop()
+		}
+	}
+}
+
+// stop waits for the writer goroutine to write the queued events (no-op if not started)
+func (e *eventExporter) stop() {
+	if e.queue == nil {
+		return
+	}
+	e.stopOnce.Do(func() {
+		close(e.queue)
+		<-e.done
+	})
+}
+
+// write exports a single event (buffered, see flush)
+func (e *eventExporter) write(event GameEvent) error {
+	if e.binary != nil {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		return e.binary.write(event)
+	}
+
+	var lines []string
+	switch e.format {
+	case ExportFormatLootLogger:
+		lines = formatLootLoggerLines(event)
+	default:
+		line, err := formatJSONLLine(event)
+		if err != nil {
+			return err
+		}
+		lines = []string{line}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, line := range lines {
+		if _, err := e.writer.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush writes buffered events to the file
+func (e *eventExporter) flush() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.writer.Flush()
+}
+
+// close writes the queued events, flushes and closes the file
+func (e *eventExporter) close() error {
+	e.stop()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.writer.Flush(); err != nil {
+		e.file.Close()
+		return err
+	}
+	return e.file.Close()
+}
+
+// jsonlRecord is the native JSONL representation of a GameEvent
+type jsonlRecord struct {
+	Version   int         `json:"version"`
+	Type      EventType   `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Message   string      `json:"message,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// FormatJSONL formats an event as a single JSON line in the jsonl event log format
+// (see ExportFormatJSONL), e.g. to stream events to another process
+func FormatJSONL(event GameEvent) (string, error) {
+	return formatJSONLLine(event)
+}
+
+// formatJSONLLine formats an event as a single JSON line
+func formatJSONLLine(event GameEvent) (string, error) {
+	data, err := json.Marshal(jsonlRecord{
+		Version:   jsonlSchemaVersion,
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Message:   event.Message,
+		Data:      event.Data,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// formatLootLoggerLines formats an event as ao-loot-logger lines (see ExportFormatLootLogger)
+func formatLootLoggerLines(event GameEvent) []string {
+	timestamp := event.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z")
+	row := func(lootedBy, itemID, itemName string, quantity int64, lootedFrom string) string {
+		return strings.Join([]string{
+			timestamp,
+			"", "", sanitizeLootLoggerField(lootedBy),
+			sanitizeLootLoggerField(itemID),
+			sanitizeLootLoggerField(itemName),
+			strconv.FormatInt(quantity, 10),
+			"", "", sanitizeLootLoggerField(lootedFrom),
+		}, ";")
+	}
+
+	switch data := event.Data.(type) {
+	case *handlers.LootEventData:
+		itemID := data.UniqueName
+		if itemID == "" {
+			itemID = strconv.Itoa(int(data.ItemID))
+		}
+		return []string{row(data.LootedBy, itemID, data.ItemName, data.Quantity, data.LootedFrom)}
+	case *handlers.SilverEventData:
+		return []string{row(data.LootedBy, "@SILVER", "Silver", data.Amount, data.LootedFrom)}
+	case *handlers.FameEventData:
+		return []string{row("", "@FAME", "Fame", data.Gained, "")}
+	case *handlers.RewardEventData:
+		var lines []string
+		for _, item := range data.Items {
+			lines = append(lines, row("", strconv.Itoa(int(item.ItemID)), item.ItemName, int64(item.Quantity), "Reward"))
+		}
+		if data.Silver > 0 {
+			lines = append(lines, row("", "@SILVER", "Silver", data.Silver, "Reward"))
+		}
+		if data.Fame > 0 {
+			lines = append(lines, row("", "@FAME", "Fame", data.Fame, "Reward"))
+		}
+		return lines
+	case *handlers.KillEventData:
+		return []string{row("", "@KILL", "Kill", 1, "")}
+	case *handlers.DeathEventData:
+		return []string{row(data.Killer, "@DEATH", "Death", 1, data.Victim)}
+	}
+	return nil
+}
+
+// sanitizeLootLoggerField removes characters that would break the line format
+func sanitizeLootLoggerField(s string) string {
+	return strings.NewReplacer(";", ",", "\n", " ", "\r", " ").Replace(s)
+}
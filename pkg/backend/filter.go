@@ -0,0 +1,50 @@
+package backend
+
+// newEventFilter builds the set of types forwarded to Events, nil if types is empty (all types)
+func newEventFilter(types []EventType) map[EventType]struct{} {
+	if len(types) == 0 {
+		return nil
+	}
+	filter := make(map[EventType]struct{}, len(types))
+	for _, t := range types {
+		filter[t] = struct{}{}
+	}
+	return filter
+}
+
+// SetEventFilter changes which event types are sent on the Events channel.
+// No types means all types. The event log and WebSocket clients still receive every event.
+func (s *Service) SetEventFilter(types ...EventType) {
+	filter := newEventFilter(types)
+
+	s.filterMu.Lock()
+	s.eventFilter = filter
+	s.filterMu.Unlock()
+}
+
+// EventFilter returns the event types sent on the Events channel, nil if all types are sent
+func (s *Service) EventFilter() []EventType {
+	s.filterMu.RLock()
+	defer s.filterMu.RUnlock()
+
+	if s.eventFilter == nil {
+		return nil
+	}
+	types := make([]EventType, 0, len(s.eventFilter))
+	for t := range s.eventFilter {
+		types = append(types, t)
+	}
+	return types
+}
+
+// acceptsEvent returns whether events of type t are sent on the Events channel
+func (s *Service) acceptsEvent(t EventType) bool {
+	s.filterMu.RLock()
+	defer s.filterMu.RUnlock()
+
+	if s.eventFilter == nil {
+		return true
+	}
+	_, ok := s.eventFilter[t]
+	return ok
+}
@@ -0,0 +1,142 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace prefixes every exported metric name
+const metricsNamespace = "albion_lens"
+
+// metricsShutdownTimeout bounds how long Stop waits for in-flight scrapes
+const metricsShutdownTimeout = 2 * time.Second
+
+// statsCounters maps the parser Stats counters onto Prometheus counters
+var statsCounters = []struct {
+	name  string
+	help  string
+	value func(*photon.Stats) uint64
+}{
+	{"packets_received_total", "Photon packets received.", (*photon.Stats).GetPacketsReceived},
+	{"packets_processed_total", "Photon packets processed.", (*photon.Stats).GetPacketsProcessed},
+	{"packets_encrypted_total", "Encrypted packets skipped.", (*photon.Stats).GetPacketsEncrypted},
+	{"packets_malformed_total", "Malformed packets.", (*photon.Stats).GetPacketsMalformed},
+	{"packets_crc_failed_total", "Packets dropped because their CRC didn't match.", (*photon.Stats).GetPacketsCRCFailed},
+	{"packets_deduplicated_total", "Packets dropped as duplicates captured on another interface.", (*photon.Stats).GetPacketsDeduplicated},
+	{"bytes_received_total", "Bytes of Photon packets received.", (*photon.Stats).GetBytesReceived},
+	{"fragments_received_total", "Fragments received.", (*photon.Stats).GetFragmentsReceived},
+	{"fragments_completed_total", "Fragmented messages reassembled.", (*photon.Stats).GetFragmentsCompleted},
+	{"fragments_expired_total", "Fragments expired before reassembly.", (*photon.Stats).GetFragmentsExpired},
+	{"events_decoded_total", "Game events decoded.", (*photon.Stats).GetEventsDecoded},
+	{"events_dropped_total", "Events dropped because the frontend was too slow.", (*photon.Stats).GetEventsDropped},
+	{"events_throttled_total", "Events dropped by a per-type rate limit.", (*photon.Stats).GetEventsThrottled},
+	{"event_log_dropped_total", "Events not written because the event log writer fell behind.", (*photon.Stats).GetEventLogDropped},
+}
+
+// sessionGauges maps the session counters onto Prometheus gauges (they reset with the session)
+var sessionGauges = []struct {
+	name  string
+	help  string
+	value func(*Service) int64
+}{
+	{"session_fame", "Fame gained this session.", (*Service).SessionFame},
+	{"session_silver", "Silver looted this session.", (*Service).SessionSilver},
+	{"session_kills", "Players killed this session.", func(s *Service) int64 { return int64(s.SessionKills()) }},
+	{"session_deaths", "Deaths this session.", func(s *Service) int64 { return int64(s.SessionDeaths()) }},
+	{"session_loot", "Items looted this session.", func(s *Service) int64 { return int64(s.SessionLoot()) }},
+}
+
+// metricsCollector reads the parser stats and session counters on every scrape
+type metricsCollector struct {
+	s *Service
+}
+
+// metricDesc describes a metric exported by metricsCollector
+func metricDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", name), help, nil, nil)
+}
+
+// Describe implements prometheus.Collector
+func (c metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range statsCounters {
+		ch <- metricDesc(m.name, m.help)
+	}
+	for _, m := range sessionGauges {
+		ch <- metricDesc(m.name, m.help)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	if stats := c.s.ParserStats(); stats != nil {
+		for _, m := range statsCounters {
+			ch <- prometheus.MustNewConstMetric(metricDesc(m.name, m.help), prometheus.CounterValue, float64(m.value(stats)))
+		}
+	}
+	for _, m := range sessionGauges {
+		ch <- prometheus.MustNewConstMetric(metricDesc(m.name, m.help), prometheus.GaugeValue, float64(m.value(c.s)))
+	}
+}
+
+// metricsServer serves the Prometheus metrics endpoint
+type metricsServer struct {
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+}
+
+// startMetricsServer listens on addr and serves the metrics of s at /metrics
+func startMetricsServer(s *Service, addr string) (*metricsServer, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(metricsCollector{s: s}); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	m := &metricsServer{
+		server:   &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second},
+		listener: listener,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(m.done)
+		// Serve returns http.ErrServerClosed on close; other errors only mean no more
+		// scrapes, which doesn't affect capture
+		_ = m.server.Serve(listener)
+	}()
+
+	return m, nil
+}
+
+// close shuts the server down and waits for it to exit
+func (m *metricsServer) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+
+	if err := m.server.Shutdown(ctx); err != nil {
+		_ = m.server.Close()
+	}
+	<-m.done
+}
+
+// MetricsAddr returns the address the metrics server listens on, empty if it is not running
+func (s *Service) MetricsAddr() string {
+	if s.metrics == nil {
+		return ""
+	}
+	return s.metrics.listener.Addr().String()
+}
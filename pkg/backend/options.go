@@ -1,6 +1,11 @@
 // Package backend provides a unified service layer for Albion Online packet capture and event processing.
 package backend
 
+import (
+	"slices"
+	"time"
+)
+
 // Option configures the Service using functional options pattern
 type Option func(*Service)
 
@@ -11,6 +16,14 @@ func WithDevice(device string) Option {
 	}
 }
 
+// WithDevices captures from the given network devices only (e.g. to skip VPN and
+// virtual adapters). Start fails if any of them doesn't exist or can't be opened.
+func WithDevices(devices ...string) Option {
+	return func(s *Service) {
+		s.devices = slices.Clone(devices)
+	}
+}
+
 // WithDebug enables debug output in the handler
 func WithDebug(debug bool) Option {
 	return func(s *Service) {
@@ -25,6 +38,145 @@ func WithDiscovery(discovery bool) Option {
 	}
 }
 
+// WithEventFilter only sends events of the given types on the Events channel,
+// e.g. WithEventFilter(EventTypeFame, EventTypeSilver). No types means all types.
+// The filter can be changed at runtime with Service.SetEventFilter.
+func WithEventFilter(types ...EventType) Option {
+	return func(s *Service) {
+		s.eventFilter = newEventFilter(types)
+	}
+}
+
+// WithEventRateLimit limits events of the given type to perSecond before they are
+// emitted, e.g. WithEventRateLimit(EventTypeCombat, 5). Throttled events are counted
+// in Stats.EventsThrottled, apart from events dropped by a full Events channel.
+func WithEventRateLimit(eventType EventType, perSecond int) Option {
+	return func(s *Service) {
+		if s.eventRateLimits == nil {
+			s.eventRateLimits = make(map[EventType]int)
+		}
+		s.eventRateLimits[eventType] = perSecond
+	}
+}
+
+// WithStrictMode enables strict packet validation in the parser.
+// Packets failing CRC or length checks are dropped instead of parsed on a best-effort basis.
+func WithStrictMode(strict bool) Option {
+	return func(s *Service) {
+		s.strictMode = strict
+	}
+}
+
+// WithCRCValidation drops packets with CRC enabled whose CRC doesn't match
+// (e.g. corrupted on a noisy link) before they reach the handlers.
+func WithCRCValidation(validate bool) Option {
+	return func(s *Service) {
+		s.validateCRC = validate
+	}
+}
+
+// WithCompactStrings decodes strings with a variable-length (7-bit) length prefix,
+// for servers using the newer "Protocol16.5" serialization.
+func WithCompactStrings(compact bool) Option {
+	return func(s *Service) {
+		s.compactStrings = compact
+	}
+}
+
+// WithProfiling times the processing of every packet, for the p50/p99/max processing
+// times in the parser stats (see photon.Stats.ProcessingP99). Disabled by default.
+func WithProfiling(profiling bool) Option {
+	return func(s *Service) {
+		s.profiling = profiling
+	}
+}
+
+// WithChatCapture enables TCP capture and stream reassembly of the chat server traffic (port 4535).
+// Disabled by default since stream reassembly is heavier than UDP-only capture.
+func WithChatCapture(enabled bool) Option {
+	return func(s *Service) {
+		s.chatCapture = enabled
+	}
+}
+
+// WithVerboseCombat enables combat awareness events for nearby players,
+// not only the local player
+func WithVerboseCombat(verbose bool) Option {
+	return func(s *Service) {
+		s.verboseCombat = verbose
+	}
+}
+
+// WithEventLogFile sets a file that receives every game event, appended in the export format
+func WithEventLogFile(path string) Option {
+	return func(s *Service) {
+		s.eventLogFile = path
+	}
+}
+
+// WithSQLiteStore sets a SQLite database that receives every game event, with loot,
+// kills and fame also in their own tables (see sqliteSchema). The database is created
+// if it doesn't exist; rows are inserted in batches, off the capture path.
+func WithSQLiteStore(path string) Option {
+	return func(s *Service) {
+		s.sqlitePath = path
+	}
+}
+
+// WithExportFormat selects the event log file format (default: ExportFormatJSONL)
+func WithExportFormat(format ExportFormat) Option {
+	return func(s *Service) {
+		s.exportFormat = format
+	}
+}
+
+// WithEventReorderWindow holds events for the given window and releases them sorted
+// by receive time, smoothing out-of-order events from fragmented messages at the cost
+// of that much latency. Disabled by default (0).
+func WithEventReorderWindow(window time.Duration) Option {
+	return func(s *Service) {
+		s.reorderWindow = window
+	}
+}
+
+// WithEventsDisabled runs the service in stats-only mode for dashboards.
+// No GameEvents are created or buffered (the Events channel never receives and the
+// event log is not written), but handlers still update the session totals and
+// parser stats keep flowing on the Stats channel.
+func WithEventsDisabled(disabled bool) Option {
+	return func(s *Service) {
+		s.eventsDisabled = disabled
+	}
+}
+
+// WithSilverThresholds sets the range of silver accepted from a single grab (whole silver).
+// Smaller grabs are ignored as noise, larger ones rejected as misparsed (0 = no upper limit).
+// Defaults: handlers.DefaultMinSilver and handlers.DefaultMaxSilverGrab.
+func WithSilverThresholds(minSilver, maxSilverGrab int64) Option {
+	return func(s *Service) {
+		s.minSilver = minSilver
+		s.maxSilverGrab = maxSilverGrab
+	}
+}
+
+// WithFameThreshold sets the smallest total fame (FixPoint, 10000 = 1 fame) accepted from
+// a fame event; lower it for characters with little total fame (0 = any positive total).
+// Default: handlers.DefaultFameThreshold.
+func WithFameThreshold(threshold int64) Option {
+	return func(s *Service) {
+		s.fameThreshold = threshold
+	}
+}
+
+// WithMaxMessageLength sets the maximum event message length in runes; longer messages
+// are truncated with an ellipsis (0 = no limit). The structured Data field is never truncated.
+// Default: format.DefaultMaxMessageLength.
+func WithMaxMessageLength(length int) Option {
+	return func(s *Service) {
+		s.maxMessageLen = length
+	}
+}
+
 // WithItemDatabasePath sets the path to the ao-bin-dumps item database
 func WithItemDatabasePath(path string) Option {
 	return func(s *Service) {
@@ -32,13 +184,95 @@ func WithItemDatabasePath(path string) Option {
 	}
 }
 
-// WithBPFFilter sets a custom BPF filter for packet capture
+// WithSpellDatabasePath sets the path to the ao-bin-dumps spell database
+// (defaults to the item database path)
+func WithSpellDatabasePath(path string) Option {
+	return func(s *Service) {
+		s.spellDBPath = path
+	}
+}
+
+// WithWorldDatabasePath sets the path to the ao-bin-dumps world database (zone names,
+// defaults to the item database path)
+func WithWorldDatabasePath(path string) Option {
+	return func(s *Service) {
+		s.worldDBPath = path
+	}
+}
+
+// WithMobDatabasePath sets the path to the ao-bin-dumps mob database (creature names,
+// defaults to the item database path)
+func WithMobDatabasePath(path string) Option {
+	return func(s *Service) {
+		s.mobDBPath = path
+	}
+}
+
+// WithPcapFile replays a pcap capture file instead of capturing live traffic.
+// Useful to analyze recorded sessions and to test the pipeline without capture privileges.
+func WithPcapFile(path string) Option {
+	return func(s *Service) {
+		s.pcapFile = path
+	}
+}
+
+// WithReplayPaced replays the pcap file (see WithPcapFile) with its recorded
+// inter-packet timing instead of as fast as possible.
+func WithReplayPaced(paced bool) Option {
+	return func(s *Service) {
+		s.replayPaced = paced
+	}
+}
+
+// WithReplaySpeed scales the recorded timing of a paced replay (see WithReplayPaced),
+// e.g. 2.0 replays twice as fast. 0 keeps the recorded pace.
+func WithReplaySpeed(speed float64) Option {
+	return func(s *Service) {
+		s.replaySpeed = speed
+	}
+}
+
+// WithReplayLoop restarts the pcap file replay (see WithPcapFile) at the end of the
+// file until the service is stopped, e.g. to stress-test a frontend.
+func WithReplayLoop(loop bool) Option {
+	return func(s *Service) {
+		s.replayLoop = loop
+	}
+}
+
+// WithPcapDump saves every captured Albion packet to a pcap file, e.g. to share a
+// reproduction of a parsing issue. The file can be replayed with WithPcapFile.
+// If the file can't be created, capture continues without it and an info event is emitted.
+func WithPcapDump(path string) Option {
+	return func(s *Service) {
+		s.pcapDump = path
+	}
+}
+
+// WithBPFFilter sets a custom BPF filter for packet capture, replacing the one built
+// from the chat and extra port settings. It is validated on Start.
 func WithBPFFilter(filter string) Option {
 	return func(s *Service) {
 		s.bpfFilter = filter
 	}
 }
 
+// WithExtraPorts captures additional UDP ports besides the default 5055/5056
+// (e.g., regional servers). The BPF filter is built from them and validated on Start.
+func WithExtraPorts(ports []uint16) Option {
+	return func(s *Service) {
+		s.extraPorts = ports
+	}
+}
+
+// WithOnlineTimeout sets how long without Albion traffic before going offline
+// (default capture.DefaultOnlineTimeout). Raise it if the status flaps on high-latency connections.
+func WithOnlineTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.onlineTimeout = timeout
+	}
+}
+
 // WithEventBufferSize sets the buffer size for the events channel
 func WithEventBufferSize(size int) Option {
 	return func(s *Service) {
@@ -46,9 +280,53 @@ func WithEventBufferSize(size int) Option {
 	}
 }
 
+// WithAutosave saves the session counters to path every interval and when the service
+// stops. On Start, the session resumes from path if it exists, so totals survive a crash
+// or restart. An interval of 0 only saves on stop.
+func WithAutosave(interval time.Duration, path string) Option {
+	return func(s *Service) {
+		s.autosaveInterval = interval
+		s.autosavePath = path
+	}
+}
+
+// WithMetricsServer serves Prometheus metrics (parser stats and session counters) at
+// http://addr/metrics while the service runs, e.g. ":9090" for headless monitoring.
+func WithMetricsServer(addr string) Option {
+	return func(s *Service) {
+		s.metricsAddr = addr
+	}
+}
+
+// WithWebSocketServer streams every event as JSON ({"type","timestamp","message","data"})
+// to WebSocket clients connected to ws://addr/events, e.g. ":8080" for web frontends.
+// Clients get their own copy of each event, the Events channel is unaffected.
+func WithWebSocketServer(addr string) Option {
+	return func(s *Service) {
+		s.websocketAddr = addr
+	}
+}
+
+// WithWebSocketOrigins allows web pages from the given origins (e.g.
+// "http://localhost:3000", "*" for any) to connect to the WebSocket server. By
+// default only pages from the server's own host and non-browser clients may.
+func WithWebSocketOrigins(origins ...string) Option {
+	return func(s *Service) {
+		s.websocketOrigins = origins
+	}
+}
+
 // WithStatsBufferSize sets the buffer size for the stats channel
 func WithStatsBufferSize(size int) Option {
 	return func(s *Service) {
 		s.statsBufferSize = size
 	}
 }
+
+// WithStatusFile sets a file that receives the compact status line on every stats tick.
+// Useful as a text source for stream overlays (e.g., OBS).
+func WithStatusFile(path string) Option {
+	return func(s *Service) {
+		s.statusFile = path
+	}
+}
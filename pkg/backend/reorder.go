@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// reorderBuffer holds events for a short window and releases them sorted by timestamp.
+//
+// Events from fragmented messages are timestamped when their first fragment arrived,
+// but are only emitted once the last fragment completes, which can be after events
+// from later packets. Holding events for a few milliseconds smooths out that order.
+type reorderBuffer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending []reorderEntry
+	seq     uint64
+	emit    func(GameEvent)
+	done    chan struct{}
+}
+
+// reorderEntry is a buffered event with its arrival sequence (tie-breaker)
+type reorderEntry struct {
+	event GameEvent
+	seq   uint64
+}
+
+// newReorderBuffer creates a reorder buffer that releases events to emit
+func newReorderBuffer(window time.Duration, emit func(GameEvent)) *reorderBuffer {
+	return &reorderBuffer{
+		window: window,
+		emit:   emit,
+		done:   make(chan struct{}),
+	}
+}
+
+// add buffers an event
+func (b *reorderBuffer) add(event GameEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	b.pending = append(b.pending, reorderEntry{event: event, seq: b.seq})
+}
+
+// flushBefore releases, in timestamp order, all events with a timestamp at or before cutoff
+func (b *reorderBuffer) flushBefore(cutoff time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slices.SortFunc(b.pending, func(x, y reorderEntry) int {
+		if c := x.event.Timestamp.Compare(y.event.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.seq, y.seq)
+	})
+
+	n := 0
+	for n < len(b.pending) && !b.pending[n].event.Timestamp.After(cutoff) {
+		b.emit(b.pending[n].event)
+		n++
+	}
+	b.pending = slices.Delete(b.pending, 0, n)
+}
+
+// flushAll releases all buffered events in timestamp order
+func (b *reorderBuffer) flushAll() {
+	b.flushBefore(time.Unix(1<<62, 0))
+}
+
+// run periodically releases events older than the window until stop is closed
+func (b *reorderBuffer) run(stop <-chan struct{}) {
+	defer close(b.done)
+
+	interval := max(b.window/2, time.Millisecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			b.flushBefore(now.Add(-b.window))
+		}
+	}
+}
+
+// wait blocks until run has returned
+func (b *reorderBuffer) wait() {
+	<-b.done
+}
@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+)
+
+// ReportEntry contains the session gains for one zone, content type, or the whole session
+type ReportEntry struct {
+	Zone        string        `json:"zone,omitempty"`
+	ContentType string        `json:"content_type,omitempty"`
+	Fame        int64         `json:"fame"`
+	Silver      int64         `json:"silver"`
+	Loot        int           `json:"loot"`
+	Kills       int           `json:"kills"`
+	Deaths      int           `json:"deaths"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// SessionReport breaks the session gains down by zone and by content type.
+// Zones are in order of first visit; content types in order of first appearance.
+// Both breakdowns sum to Totals.
+type SessionReport struct {
+	Totals       ReportEntry   `json:"totals"`
+	Zones        []ReportEntry `json:"zones"`
+	ContentTypes []ReportEntry `json:"content_types"`
+}
+
+// Report returns the session breakdown by zone and content type
+func (s *Service) Report() *SessionReport {
+	if s.handler == nil {
+		return &SessionReport{}
+	}
+	return buildSessionReport(s.handler.GetZoneStats())
+}
+
+// StartSession starts a new measurement window: session counters are reset
+// while capture keeps running.
+func (s *Service) StartSession() {
+	if s.handler != nil {
+		s.handler.ResetSession()
+	}
+}
+
+// StopSession ends the current measurement window and returns its report.
+// The report is also written to the event log, and counters are reset so
+// the next window starts immediately.
+func (s *Service) StopSession() *SessionReport {
+	report := s.Report()
+	if s.exporter != nil {
+		s.exporter.enqueue(GameEvent{Type: EventTypeReport, Timestamp: time.Now(), Data: report})
+	}
+	s.StartSession()
+	return report
+}
+
+// buildSessionReport aggregates per-zone stats into a SessionReport
+func buildSessionReport(zones []handlers.ZoneStats) *SessionReport {
+	report := &SessionReport{
+		Zones:        make([]ReportEntry, 0, len(zones)),
+		ContentTypes: make([]ReportEntry, 0),
+	}
+	contentIndex := make(map[string]int)
+
+	for _, zs := range zones {
+		entry := ReportEntry{
+			Zone:        zs.Zone,
+			ContentType: zs.ContentType,
+			Fame:        zs.Fame,
+			Silver:      zs.Silver,
+			Loot:        zs.Loot,
+			Kills:       zs.Kills,
+			Deaths:      zs.Deaths,
+			Duration:    zs.Duration,
+		}
+		report.Zones = append(report.Zones, entry)
+
+		i, ok := contentIndex[zs.ContentType]
+		if !ok {
+			i = len(report.ContentTypes)
+			contentIndex[zs.ContentType] = i
+			report.ContentTypes = append(report.ContentTypes, ReportEntry{ContentType: zs.ContentType})
+		}
+		report.ContentTypes[i].add(entry)
+		report.Totals.add(entry)
+	}
+	return report
+}
+
+// add adds the gains of other to e
+func (e *ReportEntry) add(other ReportEntry) {
+	e.Fame += other.Fame
+	e.Silver += other.Silver
+	e.Loot += other.Loot
+	e.Kills += other.Kills
+	e.Deaths += other.Deaths
+	e.Duration += other.Duration
+}
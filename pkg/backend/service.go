@@ -3,14 +3,17 @@
 package backend
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cantalupo555/albion-lens/pkg/capture"
+	"github.com/cantalupo555/albion-lens/pkg/format"
 	"github.com/cantalupo555/albion-lens/pkg/handlers"
 	"github.com/cantalupo555/albion-lens/pkg/photon"
 )
@@ -24,19 +27,72 @@ const (
 // It provides channels for frontend communication and can be used by TUI, Wails, or Web API.
 type Service struct {
 	// Configuration
-	device          string
-	debug           bool
-	discovery       bool
-	itemDBPath      string
-	bpfFilter       string
-	eventBufferSize int
-	statsBufferSize int
+	device           string
+	devices          []string
+	pcapFile         string
+	replayPaced      bool
+	replaySpeed      float64
+	replayLoop       bool
+	pcapDump         string
+	debug            bool
+	discovery        bool
+	strictMode       bool
+	validateCRC      bool
+	compactStrings   bool
+	profiling        bool
+	chatCapture      bool
+	verboseCombat    bool
+	eventLogFile     string
+	sqlitePath       string
+	exportFormat     ExportFormat
+	reorderWindow    time.Duration
+	eventsDisabled   bool
+	minSilver        int64
+	maxSilverGrab    int64
+	fameThreshold    int64
+	itemDBPath       string
+	spellDBPath      string
+	worldDBPath      string
+	mobDBPath        string
+	maxMessageLen    int
+	bpfFilter        string
+	extraPorts       []uint16
+	onlineTimeout    time.Duration
+	eventBufferSize  int
+	statsBufferSize  int
+	statusFile       string
+	metricsAddr      string
+	fullNumbers      bool
+	websocketAddr    string
+	websocketOrigins []string
+
+	// Session autosave (see WithAutosave)
+	autosavePath     string
+	autosaveInterval time.Duration
 
 	// Internal components
 	handler  *handlers.AlbionHandler
 	parser   *photon.Parser
 	capture  *capture.Capture
-	stopChan chan struct{}
+	exporter *eventExporter
+	store    *sqliteStore
+	reorder  *reorderBuffer
+	drops    dropRateTracker
+	metrics  *metricsServer
+
+	// Done when the service stops: canceled by Stop, or by the context given to Run
+	stopChan <-chan struct{}
+	cancel   context.CancelFunc
+	unlink   func() bool // Detaches the Run context (see context.AfterFunc)
+
+	websocket *wsServer
+
+	// Event types sent on Events, nil for all (see SetEventFilter)
+	eventFilter map[EventType]struct{}
+	filterMu    sync.RWMutex
+
+	// Events per second by event type (see WithEventRateLimit)
+	eventRateLimits map[EventType]int
 
 	// Public channels (read-only for frontends)
 	Events       <-chan GameEvent
@@ -48,6 +104,11 @@ type Service struct {
 	statsChan        chan *photon.Stats
 	onlineStatusChan chan bool
 
+	// Channel sends in flight; once closed is set no new sends start (see beginSend)
+	sends  sync.WaitGroup
+	sendMu sync.RWMutex
+	closed atomic.Bool
+
 	// State
 	running bool
 	mu      sync.RWMutex
@@ -58,6 +119,10 @@ func New(opts ...Option) *Service {
 	s := &Service{
 		eventBufferSize: defaultEventBufferSize,
 		statsBufferSize: defaultStatsBufferSize,
+		minSilver:       handlers.DefaultMinSilver,
+		maxSilverGrab:   handlers.DefaultMaxSilverGrab,
+		fameThreshold:   handlers.DefaultFameThreshold,
+		maxMessageLen:   format.DefaultMaxMessageLength,
 	}
 
 	// Apply options
@@ -65,11 +130,17 @@ func New(opts ...Option) *Service {
 		opt(s)
 	}
 
-	// Create channels
-	s.eventsChan = make(chan GameEvent, s.eventBufferSize)
+	// Create channels (no event buffer in stats-only mode; Events never receives)
+	eventBufferSize := s.eventBufferSize
+	if s.eventsDisabled {
+		eventBufferSize = 0
+	}
+	s.eventsChan = make(chan GameEvent, eventBufferSize)
 	s.statsChan = make(chan *photon.Stats, s.statsBufferSize)
 	s.onlineStatusChan = make(chan bool, 1)
-	s.stopChan = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopChan = ctx.Done()
+	s.cancel = cancel
 
 	// Expose read-only channels
 	s.Events = s.eventsChan
@@ -79,61 +150,155 @@ func New(opts ...Option) *Service {
 	return s
 }
 
+// Run starts the service and blocks until ctx is canceled (or Stop is called), then
+// stops it. Returns an error if capture fails to start, nil after a clean shutdown.
+func (s *Service) Run(ctx context.Context) error {
+	if err := s.start(ctx); err != nil {
+		return err
+	}
+	<-s.stopChan
+	s.Stop()
+	return nil
+}
+
 // Start initializes and starts the packet capture and event processing.
 // Returns an error if capture fails to start.
 func (s *Service) Start() error {
+	return s.start(context.Background())
+}
+
+// start starts the service; canceling ctx signals the background goroutines to stop
+// like Stop does, but the shutdown itself is left to Stop (see Run)
+func (s *Service) start(ctx context.Context) (err error) {
 	s.mu.Lock()
 	if s.running {
 		s.mu.Unlock()
 		return fmt.Errorf("service already running")
 	}
 	s.running = true
+	s.unlink = context.AfterFunc(ctx, s.cancel)
 	s.mu.Unlock()
-
-	// Create handler
-	s.handler = handlers.NewAlbionHandler()
-	s.handler.SetDebug(s.debug)
-	s.handler.SetDiscoveryMode(s.discovery)
-
-	// Set event callback to send events to channel
-	s.handler.SetEventCallback(func(eventType, message string, data interface{}) {
-		event := GameEvent{
-			Type:      EventType(eventType),
-			Message:   message,
-			Timestamp: time.Now(),
-			Data:      data,
+	defer func() {
+		if err != nil {
+			s.abortStart()
 		}
-		
-		// Update peak buffer usage stats before sending
-		if s.parser != nil && s.parser.Stats != nil {
-			s.parser.Stats.UpdateBufferPeak(len(s.eventsChan))
+	}()
+
+	// Reject a custom filter, or extra ports, that don't produce a valid capture filter
+	if s.bpfFilter != "" || len(s.extraPorts) > 0 {
+		if err := capture.ValidateFilter(s.captureFilter()); err != nil {
+			return fmt.Errorf("failed to build capture filter: %w", err)
 		}
+	}
 
-		select {
-		case s.eventsChan <- event:
-		default:
-			// Channel full, drop event
+	// Open event log file (if configured)
+	if s.eventLogFile != "" && !s.eventsDisabled {
+		exporter, err := newEventExporter(s.eventLogFile, s.exportFormat)
+		if err != nil {
+			return fmt.Errorf("failed to open event log: %w", err)
+		}
+		exporter.start(func() {
 			if s.parser != nil && s.parser.Stats != nil {
-				s.parser.Stats.IncrEventsDropped()
+				s.parser.Stats.IncrEventLogDropped()
 			}
+		})
+		s.exporter = exporter
+	}
+
+	// Open SQLite store (if configured)
+	if s.sqlitePath != "" && !s.eventsDisabled {
+		store, err := newSQLiteStore(s.sqlitePath)
+		if err != nil {
+			return fmt.Errorf("failed to open SQLite store: %w", err)
 		}
-	})
+		store.start(func() {
+			if s.parser != nil && s.parser.Stats != nil {
+				s.parser.Stats.IncrEventLogDropped()
+			}
+		})
+		s.store = store
+	}
+
+	// Create handler, resuming the autosaved session (if any)
+	s.handler = s.newHandler()
+	if err := s.restoreSession(); err != nil {
+		return fmt.Errorf("failed to restore session: %w", err)
+	}
 
-	// Load item database (errors are non-fatal)
+	// Start event reordering (if configured)
+	if s.reorderWindow > 0 && !s.eventsDisabled {
+		s.reorder = newReorderBuffer(s.reorderWindow, s.emitEvent)
+		go s.reorder.run(s.stopChan)
+	}
+
+	// Load item, spell, world and mob databases (errors are non-fatal)
 	_ = s.loadItemDatabase()
+	_ = s.loadSpellDatabase()
+	_ = s.loadWorldDatabase()
+	_ = s.loadMobDatabase()
 
 	// Create parser
 	s.parser = photon.NewParser(s.handler)
 	s.parser.Stats.BufferCapacity = cap(s.eventsChan) // Set once at startup
+	s.parser.SetStrictMode(s.strictMode)
+	s.parser.SetValidateCRC(s.validateCRC)
+	s.parser.SetCompactStrings(s.compactStrings)
+	s.parser.SetProfiling(s.profiling)
+	s.handler.SetThrottleCallback(s.parser.Stats.IncrEventsThrottled)
+	if !s.eventsDisabled {
+		s.parser.SetFragmentExpiredCallback(s.fragmentExpired)
+	}
 	// Note: Parser debug is not enabled because it uses fmt.Printf which interferes with TUI
 
 	// Create capture
 	s.capture = capture.NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
 		_ = s.parser.ParsePacket(payload)
 	})
+	if s.chatCapture {
+		// Chat messages use the same Photon message format, framed over TCP
+		s.capture.EnableChatCapture(s.parser.ParseMessage)
+	}
+	s.capture.SetExtraPorts(s.extraPorts)
+	s.capture.SetOnlineTimeout(s.onlineTimeout)
+	s.capture.SetBPFFilter(s.bpfFilter)
+	if s.pcapDump != "" {
+		// Capture works without the dump, so the error is only reported
+		if err := s.capture.SetPcapDump(s.pcapDump); err != nil {
+			s.emitEvent(GameEvent{
+				Type:      EventTypeInfo,
+				Message:   fmt.Sprintf("Not saving packets: %v", err),
+				Timestamp: time.Now(),
+			})
+		}
+	}
+
+	// Count packets dropped as cross-interface duplicates
+	s.capture.DuplicateCallback = s.parser.Stats.IncrPacketsDeduplicated
+
+	// Report devices failing and recovering (e.g. adapter reset, sleep/resume)
+	s.capture.OnDeviceError = func(device string, err error) {
+		s.emitEvent(GameEvent{
+			Type:      EventTypeInfo,
+			Message:   fmt.Sprintf("Capture on %s failed: %v, reopening...", device, err),
+			Timestamp: time.Now(),
+		})
+	}
+	s.capture.OnDeviceReopened = func(device string) {
+		s.emitEvent(GameEvent{
+			Type:      EventTypeInfo,
+			Message:   fmt.Sprintf("Capture on %s reopened", device),
+			Timestamp: time.Now(),
+		})
+	}
 
 	// Set online/offline callback
 	s.capture.OnlineCallback = func(online bool) {
+		// The online checker may still fire while the service is stopping
+		if !s.beginSend() {
+			return
+		}
+		defer s.sends.Done()
+
 		select {
 		case s.onlineStatusChan <- online:
 		default:
@@ -145,6 +310,9 @@ func (s *Service) Start() error {
 		if online {
 			msg = "Albion Online detected! Capturing packets..."
 		}
+		if s.eventsDisabled || !s.acceptsEvent(EventTypeInfo) {
+			return
+		}
 		select {
 		case s.eventsChan <- GameEvent{
 			Type:      EventTypeInfo,
@@ -162,24 +330,95 @@ func (s *Service) Start() error {
 	// Start stats updater
 	go s.statsUpdater()
 
+	// Start session autosave (if configured)
+	if s.autosavePath != "" && s.autosaveInterval > 0 {
+		go s.autosaveLoop()
+	}
+
+	// Start metrics server (if configured)
+	if s.metricsAddr != "" {
+		metrics, err := startMetricsServer(s, s.metricsAddr)
+		if err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		s.metrics = metrics
+	}
+
+	// Start WebSocket event stream (if configured)
+	if s.websocketAddr != "" {
+		ws, err := startWebSocketServer(s.websocketAddr, s.websocketOrigins...)
+		if err != nil {
+			return fmt.Errorf("failed to start WebSocket server: %w", err)
+		}
+		s.websocket = ws
+	}
+
 	// Start capture
-	var err error
-	if s.device != "" {
+	if s.pcapFile != "" {
+		err = s.capture.StartFromFileWithOptions(s.pcapFile, capture.ReplayOptions{
+			Speed:      s.replaySpeed,
+			Loop:       s.replayLoop,
+			RealTiming: s.replayPaced,
+		})
+	} else if len(s.devices) > 0 {
+		err = s.capture.StartOnDevices(s.devices)
+	} else if s.device != "" {
 		err = s.capture.StartOnDevice(s.device)
 	} else {
 		err = s.capture.Start()
 	}
 
 	if err != nil {
-		s.mu.Lock()
-		s.running = false
-		s.mu.Unlock()
 		return fmt.Errorf("failed to start capture: %w", err)
 	}
 
 	return nil
 }
 
+// abortStart undoes a failed start: it stops the background goroutines and
+// releases everything opened before the failure. Like after Stop, the service
+// can't be started again.
+func (s *Service) abortStart() {
+	s.unlink()
+	s.cancel()
+
+	if s.reorder != nil {
+		s.reorder.wait()
+		s.reorder = nil
+	}
+	if s.parser != nil {
+		s.parser.Close()
+	}
+	if s.metrics != nil {
+		s.metrics.close()
+		s.metrics = nil
+	}
+	if s.websocket != nil {
+		s.websocket.close()
+		s.websocket = nil
+	}
+	if s.store != nil {
+		_ = s.store.close()
+		s.store = nil
+	}
+	if s.exporter != nil {
+		_ = s.exporter.close()
+		s.exporter = nil
+	}
+
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+}
+
+// Wait blocks until a pcap file replay (see WithPcapFile) has been fully read, or
+// until Stop for live capture. Returns immediately if the service is not started.
+func (s *Service) Wait() {
+	if s.capture != nil {
+		s.capture.Wait()
+	}
+}
+
 // Stop stops the service and cleans up resources.
 func (s *Service) Stop() {
 	s.mu.Lock()
@@ -191,24 +430,197 @@ func (s *Service) Stop() {
 	s.mu.Unlock()
 
 	// Signal stop
-	close(s.stopChan)
+	s.unlink()
+	s.cancel()
 
 	// Stop capture
 	if s.capture != nil {
 		s.capture.Stop()
 	}
 
+	// Stop metrics server
+	if s.metrics != nil {
+		s.metrics.close()
+	}
+
+	// Disconnect WebSocket clients
+	if s.websocket != nil {
+		s.websocket.close()
+	}
+
 	// Close parser
 	if s.parser != nil {
 		s.parser.Close()
 	}
 
+	// Release events still held for reordering
+	if s.reorder != nil {
+		s.reorder.wait()
+		s.reorder.flushAll()
+	}
+
+	// Drop late callbacks and wait for in-flight sends before closing the channels
+	s.sendMu.Lock()
+	s.closed.Store(true)
+	s.sendMu.Unlock()
+	s.sends.Wait()
+
+	// Save the final session state
+	if s.autosavePath != "" {
+		_ = s.handler.SaveSession(s.autosavePath)
+	}
+
+	// Close event log file, ending it with the session report
+	if s.exporter != nil {
+		s.exporter.stop()
+		_ = s.exporter.write(GameEvent{Type: EventTypeReport, Timestamp: time.Now(), Data: s.Report()})
+		_ = s.exporter.close()
+	}
+
+	// Commit pending rows and close the SQLite store
+	if s.store != nil {
+		_ = s.store.close()
+	}
+
 	// Close channels
 	close(s.eventsChan)
 	close(s.statsChan)
 	close(s.onlineStatusChan)
 }
 
+// newHandler creates the game event handler configured from the service options
+func (s *Service) newHandler() *handlers.AlbionHandler {
+	h := handlers.NewAlbionHandler()
+	h.SetDebug(s.debug)
+	h.SetVerboseCombat(s.verboseCombat)
+	h.SetDiscoveryMode(s.discovery)
+	h.SetSilverThresholds(s.minSilver, s.maxSilverGrab)
+	h.SetFameThreshold(s.fameThreshold)
+	for eventType, perSecond := range s.eventRateLimits {
+		h.SetEventRateLimit(string(eventType), perSecond)
+	}
+
+	// In stats-only mode, handlers still update session totals but nothing is emitted
+	if !s.eventsDisabled {
+		h.SetEventInfoCallback(s.onHandlerEvent)
+	}
+	return h
+}
+
+// onHandlerEvent wraps a handler notification into a GameEvent and emits it
+func (s *Service) onHandlerEvent(info photon.MessageInfo, eventType, message string, data interface{}) {
+	// Use the time the message was received (first fragment for fragmented messages)
+	timestamp := info.ReceivedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	// Events passed without message info are assumed reliable
+	reliable := info.Reliable || info.ReceivedAt.IsZero()
+
+	event := GameEvent{
+		Type:      EventType(eventType),
+		Message:   format.Truncate(message, s.maxMessageLen),
+		Timestamp: timestamp,
+		Data:      data,
+		Reliable:  reliable,
+	}
+
+	if s.reorder != nil {
+		s.reorder.add(event)
+		return
+	}
+	s.emitEvent(event)
+}
+
+// captureFilter returns the BPF filter used for capture: the custom one if set,
+// otherwise the one built from the chat and extra port settings
+func (s *Service) captureFilter() string {
+	if s.bpfFilter != "" {
+		return s.bpfFilter
+	}
+	return capture.BuildFilter(s.chatCapture, s.extraPorts)
+}
+
+// beginSend registers a send on the service channels, returning false once Stop
+// has started closing them. Callers must call s.sends.Done when the send is done.
+func (s *Service) beginSend() bool {
+	s.sendMu.RLock()
+	defer s.sendMu.RUnlock()
+	if s.closed.Load() {
+		return false
+	}
+	s.sends.Add(1)
+	return true
+}
+
+// emitEvent exports an event and sends it to the events channel
+func (s *Service) emitEvent(event GameEvent) {
+	// Stats-only mode creates no events (see WithEventsDisabled)
+	if s.eventsDisabled || !s.beginSend() {
+		return
+	}
+	defer s.sends.Done()
+
+	// Export to event log file (queued, errors are non-fatal)
+	if s.exporter != nil {
+		s.exporter.enqueue(event)
+	}
+
+	// Store in the SQLite database (queued)
+	if s.store != nil {
+		s.store.enqueue(event)
+	}
+
+	// Fan out to WebSocket clients (never blocks)
+	if s.websocket != nil {
+		s.websocket.broadcast(event)
+	}
+
+	// Only the Events channel is filtered
+	if !s.acceptsEvent(event.Type) {
+		return
+	}
+
+	// Update peak buffer usage stats before sending
+	if s.parser != nil && s.parser.Stats != nil {
+		s.parser.Stats.UpdateBufferPeak(len(s.eventsChan))
+	}
+
+	select {
+	case s.eventsChan <- event:
+	default:
+		// Channel full, drop event
+		if s.parser != nil && s.parser.Stats != nil {
+			s.parser.Stats.IncrEventsDropped()
+		}
+	}
+}
+
+// fragmentExpired reports a fragmented message dropped incomplete by the parser,
+// usually caused by packet loss
+func (s *Service) fragmentExpired(seq int32, received, total int) {
+	s.emitEvent(GameEvent{
+		Type:      EventTypeWarning,
+		Message:   fmt.Sprintf("⚠️ Fragmented message %d dropped (%d/%d fragments received)", seq, received, total),
+		Timestamp: time.Now(),
+	})
+}
+
+// sendStats sends the parser stats to the stats channel unless the service is stopping
+func (s *Service) sendStats() {
+	if !s.beginSend() {
+		return
+	}
+	defer s.sends.Done()
+
+	select {
+	case s.statsChan <- s.parser.Stats:
+	default:
+		// Stats channel full - this is less critical than events
+		// We don't increment EventsDropped for stats updates
+	}
+}
+
 // statsUpdater periodically sends stats to the channel.
 func (s *Service) statsUpdater() {
 	ticker := time.NewTicker(time.Second)
@@ -222,14 +634,10 @@ func (s *Service) statsUpdater() {
 			if s.parser != nil {
 				// Snapshot buffer metrics (Peak usage in last interval)
 				s.parser.Stats.SnapshotBufferPeak()
-
-				select {
-				case s.statsChan <- s.parser.Stats:
-				default:
-					// Stats channel full - this is less critical than events
-					// We don't increment EventsDropped for stats updates
-				}
+				s.drops.record(time.Now(), s.parser.Stats.GetEventsDropped())
+				s.sendStats()
 			}
+			s.writeStatusFile()
 		}
 	}
 }
@@ -256,6 +664,84 @@ func (s *Service) loadItemDatabase() error {
 	return nil
 }
 
+// loadSpellDatabase loads the spell database from the configured path, falling back
+// to the item database directory (spells.json ships next to items.json in ao-bin-dumps)
+func (s *Service) loadSpellDatabase() error {
+	if s.spellDBPath != "" {
+		return s.handler.LoadSpellDatabase(s.spellDBPath)
+	}
+
+	// Try auto-detection
+	commonPaths := []string{
+		"../ao-bin-dumps",
+		"../../ao-bin-dumps",
+		filepath.Join(os.Getenv("HOME"), "Documents/albion/ao-bin-dumps"),
+	}
+	if s.itemDBPath != "" {
+		commonPaths = append([]string{s.itemDBPath}, commonPaths...)
+	}
+
+	for _, path := range commonPaths {
+		if _, err := os.Stat(filepath.Join(path, "spells.json")); err == nil {
+			return s.handler.LoadSpellDatabase(path)
+		}
+	}
+
+	return nil
+}
+
+// loadWorldDatabase loads the world database from the configured path, falling back
+// to the item database directory (world.json ships in its cluster directory)
+func (s *Service) loadWorldDatabase() error {
+	if s.worldDBPath != "" {
+		return s.handler.LoadWorldDatabase(s.worldDBPath)
+	}
+
+	// Try auto-detection
+	commonPaths := []string{
+		"../ao-bin-dumps",
+		"../../ao-bin-dumps",
+		filepath.Join(os.Getenv("HOME"), "Documents/albion/ao-bin-dumps"),
+	}
+	if s.itemDBPath != "" {
+		commonPaths = append([]string{s.itemDBPath}, commonPaths...)
+	}
+
+	for _, path := range commonPaths {
+		if _, err := os.Stat(filepath.Join(path, "cluster", "world.json")); err == nil {
+			return s.handler.LoadWorldDatabase(path)
+		}
+	}
+
+	return nil
+}
+
+// loadMobDatabase loads the mob database from the configured path, falling back
+// to the item database directory. Without it, mobs are named by type ID.
+func (s *Service) loadMobDatabase() error {
+	if s.mobDBPath != "" {
+		return s.handler.LoadMobDatabase(s.mobDBPath)
+	}
+
+	// Try auto-detection
+	commonPaths := []string{
+		"../ao-bin-dumps",
+		"../../ao-bin-dumps",
+		filepath.Join(os.Getenv("HOME"), "Documents/albion/ao-bin-dumps"),
+	}
+	if s.itemDBPath != "" {
+		commonPaths = append([]string{s.itemDBPath}, commonPaths...)
+	}
+
+	for _, path := range commonPaths {
+		if _, err := os.Stat(filepath.Join(path, "mobs.json")); err == nil {
+			return s.handler.LoadMobDatabase(path)
+		}
+	}
+
+	return nil
+}
+
 // IsRunning returns whether the service is currently running.
 func (s *Service) IsRunning() bool {
 	s.mu.RLock()
@@ -287,6 +773,22 @@ func (s *Service) SessionSilver() int64 {
 	return s.handler.GetSessionSilver()
 }
 
+// SessionHarvested returns the number of resources gathered in this session.
+func (s *Service) SessionHarvested() int {
+	if s.handler == nil {
+		return 0
+	}
+	return s.handler.GetSessionHarvested()
+}
+
+// SessionFishCaught returns the number of fish caught in this session.
+func (s *Service) SessionFishCaught() int {
+	if s.handler == nil {
+		return 0
+	}
+	return s.handler.GetSessionFishCaught()
+}
+
 // SessionKills returns the number of kills in this session.
 func (s *Service) SessionKills() int {
 	if s.handler == nil {
@@ -311,6 +813,76 @@ func (s *Service) SessionLoot() int {
 	return s.handler.GetSessionLoot()
 }
 
+// SessionConsumables returns the quantity used per consumable (by item name) in this session.
+func (s *Service) SessionConsumables() map[string]int {
+	if s.handler == nil {
+		return map[string]int{}
+	}
+	return s.handler.GetSessionConsumables()
+}
+
+// SessionConsumableCost returns the estimated market value (silver) of the consumables used in this session.
+func (s *Service) SessionConsumableCost() int64 {
+	if s.handler == nil {
+		return 0
+	}
+	return s.handler.GetSessionConsumableCost()
+}
+
+// DetectedGameVersion returns the game client version seen in traffic, empty if not detected yet.
+func (s *Service) DetectedGameVersion() string {
+	if s.handler == nil {
+		return ""
+	}
+	return s.handler.DetectedGameVersion()
+}
+
+// CurrentZone returns the name of the local player's zone: the raw cluster ID if
+// the world database isn't loaded, empty until the zone is known.
+func (s *Service) CurrentZone() string {
+	if s.handler == nil {
+		return ""
+	}
+	return s.handler.CurrentZone()
+}
+
+// InCombat reports whether the local player is in combat (false until the local player is known).
+func (s *Service) InCombat() bool {
+	if s.handler == nil {
+		return false
+	}
+	return s.handler.IsInCombat()
+}
+
+// MountHealth returns the current and maximum health of the local player's mount (0, 0 when not mounted).
+func (s *Service) MountHealth() (health, maxHealth float32) {
+	if s.handler == nil {
+		return 0, 0
+	}
+	return s.handler.MountHealth()
+}
+
+// MountCooldown returns the time left before the local player can mount again.
+func (s *Service) MountCooldown() time.Duration {
+	if s.handler == nil {
+		return 0
+	}
+	return s.handler.MountCooldown()
+}
+
+// Relationship returns the relationship of the named player to the local player.
+func (s *Service) Relationship(name string) handlers.Relationship {
+	if s.handler == nil {
+		return handlers.RelationshipNeutral
+	}
+	return s.handler.Relationship(name)
+}
+
+// MaxMessageLength returns the maximum event message length in runes (0 = no limit).
+func (s *Service) MaxMessageLength() int {
+	return s.maxMessageLen
+}
+
 // ParserStats returns the current parser statistics.
 func (s *Service) ParserStats() *photon.Stats {
 	if s.parser == nil {
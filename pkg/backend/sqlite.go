@@ -0,0 +1,235 @@
+package backend
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/handlers"
+
+	// Pure-Go SQLite driver, so builds don't need CGO
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// sqliteBatchSize is how many events are inserted per transaction at most
+	sqliteBatchSize = 500
+
+	// sqliteFlushInterval is how often pending events are committed
+	sqliteFlushInterval = time.Second
+)
+
+// sqliteSchema creates the store tables. Every event has a row in events; loot,
+// kills (kills and deaths) and fame rows reference it for easier queries.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS events (
+	id        INTEGER PRIMARY KEY,
+	type      TEXT NOT NULL,
+	timestamp TEXT NOT NULL,
+	message   TEXT NOT NULL,
+	data      TEXT
+);
+CREATE INDEX IF NOT EXISTS events_type_timestamp ON events(type, timestamp);
+CREATE TABLE IF NOT EXISTS loot (
+	event_id        INTEGER NOT NULL REFERENCES events(id),
+	timestamp       TEXT NOT NULL,
+	looted_by       TEXT NOT NULL,
+	item_id         INTEGER NOT NULL,
+	unique_name     TEXT NOT NULL,
+	item_name       TEXT NOT NULL,
+	quantity        INTEGER NOT NULL,
+	looted_from     TEXT NOT NULL,
+	estimated_value INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS kills (
+	event_id  INTEGER NOT NULL REFERENCES events(id),
+	timestamp TEXT NOT NULL,
+	kind      TEXT NOT NULL,
+	killer    TEXT NOT NULL,
+	victim    TEXT NOT NULL,
+	is_local  INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS fame (
+	event_id  INTEGER NOT NULL REFERENCES events(id),
+	timestamp TEXT NOT NULL,
+	gained    INTEGER NOT NULL,
+	total     INTEGER NOT NULL
+);
+`
+
+// sqliteStore persists GameEvents to a SQLite database. Like eventExporter, events
+// are queued and inserted by a dedicated goroutine, in batched transactions.
+type sqliteStore struct {
+	db *sql.DB
+
+	queue  chan GameEvent
+	onDrop func()
+	done   chan struct{}
+}
+
+// newSQLiteStore opens (or creates) the database and its tables
+func newSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// A single connection: writes are serialized by the writer goroutine anyway
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+// start launches the writer goroutine; onDrop is called for each event dropped
+// because the queue is full or it couldn't be written
+func (s *sqliteStore) start(onDrop func()) {
+	s.queue = make(chan GameEvent, eventLogBufferSize)
+	s.onDrop = onDrop
+	s.done = make(chan struct{})
+	go s.run()
+}
+
+// run inserts queued events, committing every sqliteFlushInterval or
+// sqliteBatchSize events, until the queue is closed
+func (s *sqliteStore) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(sqliteFlushInterval)
+	defer ticker.Stop()
+
+	var pending []GameEvent
+	for {
+		select {
+		case event, ok := <-s.queue:
+			if !ok {
+				s.insert(pending)
+				return
+			}
+			pending = append(pending, event)
+			if len(pending) >= sqliteBatchSize {
+				s.insert(pending)
+				pending = pending[:0]
+			}
+		case <-ticker.C:
+			if len(pending) > 0 {
+				s.insert(pending)
+				pending = pending[:0]
+			}
+		}
+	}
+}
+
+// enqueue hands an event to the writer goroutine without blocking
+func (s *sqliteStore) enqueue(event GameEvent) {
+	select {
+	case s.queue <- event:
+	default:
+		s.drop(1)
+	}
+}
+
+// drop reports n dropped events to onDrop
+func (s *sqliteStore) drop(n int) {
+	if s.onDrop == nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		s.onDrop()
+	}
+}
+
+// close inserts the queued events and closes the database
+func (s *sqliteStore) close() error {
+	if s.queue != nil {
+		close(s.queue)
+		<-s.done
+	}
+	return s.db.Close()
+}
+
+// insert writes events in a single transaction. An event that can't be written
+// (e.g. its data doesn't marshal) is skipped and dropped, the others are committed;
+// if the transaction itself fails, the whole batch is dropped.
+func (s *sqliteStore) insert(events []GameEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	skipped, err := s.insertBatch(events)
+	if err != nil {
+		skipped = len(events)
+	}
+	s.drop(skipped)
+}
+
+// insertBatch writes events in a single transaction, each under a savepoint so a
+// failing event leaves no partial rows. It returns the number of events skipped.
+func (s *sqliteStore) insertBatch(events []GameEvent) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	skipped := 0
+	for _, event := range events {
+		if _, err := tx.Exec("SAVEPOINT event"); err != nil {
+			return 0, err
+		}
+		if err := insertEvent(tx, event); err != nil {
+			if _, err := tx.Exec("ROLLBACK TO event"); err != nil {
+				return 0, err
+			}
+			skipped++
+		}
+		if _, err := tx.Exec("RELEASE event"); err != nil {
+			return 0, err
+		}
+	}
+	return skipped, tx.Commit()
+}
+
+// insertEvent writes an event row, plus its loot, kills or fame row
+func insertEvent(tx *sql.Tx, event GameEvent) error {
+	var data []byte
+	if event.Data != nil {
+		var err error
+		if data, err = json.Marshal(event.Data); err != nil {
+			return err
+		}
+	}
+
+	timestamp := event.Timestamp.UTC().Format(time.RFC3339Nano)
+	result, err := tx.Exec("INSERT INTO events (type, timestamp, message, data) VALUES (?, ?, ?, ?)",
+		string(event.Type), timestamp, event.Message, data)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	switch d := event.Data.(type) {
+	case *handlers.LootEventData:
+		_, err = tx.Exec("INSERT INTO loot (event_id, timestamp, looted_by, item_id, unique_name, item_name, quantity, looted_from, estimated_value) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			id, timestamp, d.LootedBy, d.ItemID, d.UniqueName, d.ItemName, d.Quantity, d.LootedFrom, d.EstimatedValue)
+	case *handlers.KillEventData:
+		_, err = tx.Exec("INSERT INTO kills (event_id, timestamp, kind, killer, victim, is_local) VALUES (?, ?, 'kill', ?, ?, ?)",
+			id, timestamp, d.Killer, d.Victim, d.IsLocal)
+	case *handlers.DeathEventData:
+		_, err = tx.Exec("INSERT INTO kills (event_id, timestamp, kind, killer, victim, is_local) VALUES (?, ?, 'death', ?, ?, ?)",
+			id, timestamp, d.Killer, d.Victim, d.IsLocal)
+	case *handlers.FameEventData:
+		_, err = tx.Exec("INSERT INTO fame (event_id, timestamp, gained, total) VALUES (?, ?, ?, ?)",
+			id, timestamp, d.Gained, d.Total)
+	}
+	return err
+}
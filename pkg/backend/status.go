@@ -0,0 +1,93 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/format"
+)
+
+// SessionSummary is a consistent snapshot of the session totals and capture counters
+type SessionSummary struct {
+	Fame   int64 `json:"fame"`
+	Silver int64 `json:"silver"`
+	Kills  int   `json:"kills"`
+	Deaths int   `json:"deaths"`
+	Loot   int   `json:"loot"`
+
+	Uptime          time.Duration `json:"uptime"` // Nanoseconds in JSON
+	PacketsReceived uint64        `json:"packets_received"`
+	EventsDecoded   uint64        `json:"events_decoded"`
+}
+
+// SessionSnapshot returns the session totals read in one pass, so frontends rendering
+// or exporting them never see a torn state (e.g. a kill counted without its fame).
+// Before Start, the snapshot is empty.
+func (s *Service) SessionSnapshot() SessionSummary {
+	var summary SessionSummary
+	if s.handler != nil {
+		totals := s.handler.GetSessionTotals()
+		summary.Fame = totals.Fame
+		summary.Silver = totals.Silver
+		summary.Kills = totals.Kills
+		summary.Deaths = totals.Deaths
+		summary.Loot = totals.Loot
+	}
+	if stats := s.ParserStats(); stats != nil {
+		summary.Uptime = stats.Uptime()
+		summary.PacketsReceived = stats.GetPacketsReceived()
+		summary.EventsDecoded = stats.GetEventsDecoded()
+	}
+	return summary
+}
+
+// CompactStatusLine returns a minimal single-line session summary for streamers,
+// e.g. "⭐12.3k/h 💰45.6k/h ⚔3 💀1 | 01:23:45".
+// Numbers respect the full/abbreviated display setting.
+func (s *Service) CompactStatusLine() string {
+	summary := s.SessionSnapshot()
+
+	return compactStatusLine(
+		summary.Fame,
+		summary.Silver,
+		summary.Kills,
+		summary.Deaths,
+		summary.Uptime,
+		s.IsFullNumbers(),
+	)
+}
+
+// compactStatusLine formats the compact status line from session values.
+func compactStatusLine(fame, silver int64, kills, deaths int, uptime time.Duration, full bool) string {
+	return fmt.Sprintf("⭐%s/h 💰%s/h ⚔%d 💀%d | %s",
+		format.Number(format.PerHour(fame, uptime), full),
+		format.Number(format.PerHour(silver, uptime), full),
+		kills,
+		deaths,
+		format.Clock(uptime),
+	)
+}
+
+// writeStatusFile writes the compact status line to the configured status file.
+// Errors are non-fatal: the overlay simply keeps its previous content.
+func (s *Service) writeStatusFile() {
+	if s.statusFile == "" {
+		return
+	}
+	_ = os.WriteFile(s.statusFile, []byte(s.CompactStatusLine()+"\n"), 0644)
+}
+
+// SetFullNumbers sets whether numbers are shown in full (4984) or abbreviated (4.9k).
+func (s *Service) SetFullNumbers(full bool) {
+	s.mu.Lock()
+	s.fullNumbers = full
+	s.mu.Unlock()
+}
+
+// IsFullNumbers returns whether numbers are shown in full.
+func (s *Service) IsFullNumbers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.fullNumbers
+}
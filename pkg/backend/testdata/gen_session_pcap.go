@@ -0,0 +1,129 @@
+//go:build ignore
+
+// This program generates session.pcap, the fixture replayed by the backend pipeline test.
+// Run from pkg/backend with: go run testdata/gen_session_pcap.go
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/capture"
+	"github.com/cantalupo555/albion-lens/pkg/events"
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// param is a Photon protocol16 parameter
+type param struct {
+	key   byte
+	value interface{}
+}
+
+func main() {
+	messages := [][]byte{
+		// Fame: 100 gained (FixPoint), detailed format
+		event(events.EventUpdateFame, param{0, int64(1)}, param{1, int64(50_000_000)}, param{2, int64(1_000_000)}),
+		// Silver: 250 picked up from a mob
+		event(events.EventOtherGrabbedLoot, param{1, "Mob"}, param{2, "Me"}, param{3, true}, param{5, int64(2_500_000)}),
+		// Item: 2x item 1234 from a chest
+		event(events.EventOtherGrabbedLoot, param{1, "Chest"}, param{2, "Me"}, param{3, false}, param{4, int32(1234)}, param{5, int32(2)}),
+		// Kill
+		event(events.EventKilledPlayer),
+	}
+
+	f, err := os.Create("testdata/session.pcap")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	w := pcapgo.NewWriter(f)
+	if err := w.WriteFileHeader(capture.SnapshotLen, layers.LinkTypeEthernet); err != nil {
+		log.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, message := range messages {
+		frame := udpFrame(packet(message))
+		ci := gopacket.CaptureInfo{
+			Timestamp:     start.Add(time.Duration(i) * 100 * time.Millisecond),
+			CaptureLength: len(frame),
+			Length:        len(frame),
+		}
+		if err := w.WritePacket(ci, frame); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// event encodes an event data message with the given parameters
+func event(code events.EventCode, params ...param) []byte {
+	params = append(params, param{events.ParamEventCode, int16(code)})
+
+	msg := []byte{243, photon.MessageTypeEventData, 0}
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(params)))
+	for _, p := range params {
+		msg = append(msg, p.key)
+		switch v := p.value.(type) {
+		case bool:
+			b := byte(0)
+			if v {
+				b = 1
+			}
+			msg = append(msg, photon.TypeBoolean, b)
+		case int16:
+			msg = binary.BigEndian.AppendUint16(append(msg, photon.TypeShort), uint16(v))
+		case int32:
+			msg = binary.BigEndian.AppendUint32(append(msg, photon.TypeInteger), uint32(v))
+		case int64:
+			msg = binary.BigEndian.AppendUint64(append(msg, photon.TypeLong), uint64(v))
+		case string:
+			msg = binary.BigEndian.AppendUint16(append(msg, photon.TypeString), uint16(len(v)))
+			msg = append(msg, v...)
+		}
+	}
+	return msg
+}
+
+// packet wraps a message in a Photon packet with a single reliable command
+func packet(message []byte) []byte {
+	p := make([]byte, photon.PhotonHeaderLength+photon.CommandHeaderLength)
+	p[3] = 1 // command count
+	cmd := p[photon.PhotonHeaderLength:]
+	cmd[0] = photon.CommandTypeSendReliable
+	binary.BigEndian.PutUint32(cmd[4:8], uint32(photon.CommandHeaderLength+len(message)))
+	return append(p, message...)
+}
+
+// udpFrame wraps a payload in an Ethernet/IPv4/UDP frame from the game server
+func udpFrame(payload []byte) []byte {
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 1, 2, 3, 4, 5},
+		DstMAC:       net.HardwareAddr{0, 1, 2, 3, 4, 6},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{5, 188, 125, 10},
+		DstIP:    net.IP{192, 168, 1, 10},
+	}
+	udp := &layers.UDP{SrcPort: capture.PortGame, DstPort: 50000}
+	if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+		log.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, eth, ip, udp, gopacket.Payload(payload)); err != nil {
+		log.Fatal(err)
+	}
+	return buf.Bytes()
+}
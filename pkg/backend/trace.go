@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultTraceFile is the file packet traces are appended to by frontends
+const DefaultTraceFile = "packet-trace.txt"
+
+// TraceNextPacket appends the command-by-command structure of the next received
+// packet to path and reports where it was written with an info event.
+// The trace is never sent through the event stream itself, so it cannot disturb frontends.
+func (s *Service) TraceNextPacket(path string) error {
+	if !s.IsRunning() || s.parser == nil {
+		return fmt.Errorf("service not running")
+	}
+
+	s.parser.TraceNextPacket(func(trace string) {
+		msg := fmt.Sprintf("Packet trace written to %s", path)
+		if err := appendTrace(path, trace); err != nil {
+			msg = fmt.Sprintf("Failed to write packet trace: %v", err)
+		}
+		s.emitEvent(GameEvent{Type: EventTypeInfo, Message: msg, Timestamp: time.Now()})
+	})
+	return nil
+}
+
+// appendTrace appends a timestamped packet trace to path
+func appendTrace(path, trace string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(file, "=== %s ===\n%s\n", time.Now().Format(time.RFC3339Nano), trace)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
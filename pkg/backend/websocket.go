@@ -0,0 +1,204 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// wsClientBuffer is how many events a WebSocket client may fall behind before
+// further events are dropped for that client only
+const wsClientBuffer = 256
+
+// wsClient is a connected WebSocket client
+type wsClient struct {
+	send chan []byte
+}
+
+// wsServer broadcasts every GameEvent as JSON to the connected WebSocket clients.
+// Each client has its own buffer, so a slow client never blocks the Events channel
+// or the other clients.
+type wsServer struct {
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+
+	mu      sync.Mutex
+	clients map[*wsClient]struct{}
+	closed  bool
+	conns   sync.WaitGroup
+}
+
+// startWebSocketServer listens on addr and streams events to clients connecting to
+// /events. Browser clients are only accepted from the server's own host or from
+// allowedOrigins (see checkOrigin).
+func startWebSocketServer(addr string, allowedOrigins ...string) (*wsServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	w := &wsServer{
+		listener: listener,
+		done:     make(chan struct{}),
+		clients:  make(map[*wsClient]struct{}),
+	}
+
+	// The default Handshake rejects clients that don't send an Origin header, which
+	// non-browser tools usually don't
+	mux := http.NewServeMux()
+	mux.Handle("/events", websocket.Server{
+		Handler: w.serveClient,
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			return checkOrigin(config, req, allowedOrigins)
+		},
+	})
+
+	w.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		defer close(w.done)
+		_ = w.server.Serve(listener)
+	}()
+
+	return w, nil
+}
+
+// checkOrigin accepts a WebSocket handshake without an Origin header (non-browser
+// clients), from a page on the server's own host, or from one of the allowed origins
+// ("*" allows any). Other origins are rejected, so a web page open in the user's
+// browser can't read the event stream (chat, player names, positions).
+func checkOrigin(config *websocket.Config, req *http.Request, allowed []string) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("invalid origin %q: %w", origin, err)
+	}
+	config.Origin = u
+
+	if slices.Contains(allowed, "*") || slices.Contains(allowed, strings.TrimSuffix(origin, "/")) {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		host = req.Host
+	}
+	if strings.EqualFold(u.Hostname(), host) {
+		return nil
+	}
+	return fmt.Errorf("origin %q not allowed", origin)
+}
+
+// serveClient writes broadcast events to conn until the client disconnects or the server closes
+func (w *wsServer) serveClient(conn *websocket.Conn) {
+	defer conn.Close()
+
+	client := &wsClient{send: make(chan []byte, wsClientBuffer)}
+	if !w.add(client) {
+		return
+	}
+	defer w.remove(client)
+
+	// Clients aren't expected to send anything; reading only detects disconnects
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		var discard []byte
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case msg, ok := <-client.send:
+			if !ok {
+				return
+			}
+			if err := websocket.Message.Send(conn, string(msg)); err != nil {
+				return
+			}
+		case <-gone:
+			return
+		}
+	}
+}
+
+// add registers a client, returns false if the server is closing
+func (w *wsServer) add(client *wsClient) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return false
+	}
+	w.clients[client] = struct{}{}
+	w.conns.Add(1)
+	return true
+}
+
+// remove unregisters a disconnected client
+func (w *wsServer) remove(client *wsClient) {
+	w.mu.Lock()
+	delete(w.clients, client)
+	w.mu.Unlock()
+	w.conns.Done()
+}
+
+// broadcast queues event for every client, dropping it for clients whose buffer is full
+func (w *wsServer) broadcast(event GameEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed || len(w.clients) == 0 {
+		return
+	}
+
+	line, err := formatJSONLLine(event)
+	if err != nil {
+		return
+	}
+	msg := []byte(line)
+	for client := range w.clients {
+		select {
+		case client.send <- msg:
+		default:
+		}
+	}
+}
+
+// close stops accepting clients, disconnects the connected ones and waits for them to exit
+func (w *wsServer) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+
+	// Shutdown doesn't track hijacked WebSocket connections, those are closed below
+	if err := w.server.Shutdown(ctx); err != nil {
+		_ = w.server.Close()
+	}
+
+	w.mu.Lock()
+	w.closed = true
+	for client := range w.clients {
+		close(client.send)
+	}
+	w.mu.Unlock()
+
+	w.conns.Wait()
+	<-w.done
+}
+
+// WebSocketAddr returns the address the WebSocket server listens on, empty if it is not running
+func (s *Service) WebSocketAddr() string {
+	if s.websocket == nil {
+		return ""
+	}
+	return s.websocket.listener.Addr().String()
+}
@@ -3,8 +3,10 @@
 package capture
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"sync"
 	"time"
 
@@ -22,10 +24,16 @@ const (
 	// BPF filter for Albion Online traffic
 	BPFFilter = "udp and (port 5055 or port 5056)"
 
+	// BPF filter for Albion Online traffic including the TCP chat server
+	BPFFilterWithChat = "(udp and (port 5055 or port 5056)) or (tcp and port 4535)"
+
 	// Capture settings
 	SnapshotLen = 65536
 	Promiscuous = false
 	Timeout     = pcap.BlockForever
+
+	// DefaultOnlineTimeout is how long without packets before the game is considered offline
+	DefaultOnlineTimeout = 5 * time.Second
 )
 
 // PacketHandler is a callback function for received packets
@@ -33,56 +41,185 @@ type PacketHandler func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort ui
 
 // Capture handles Albion Online network traffic capture
 type Capture struct {
-	handles []*pcap.Handle
+	handles []liveHandle
 	handler PacketHandler
 	running bool
 	mu      sync.Mutex
 	wg      sync.WaitGroup
 
+	// Device recovery after read errors (see capturePackets)
+	stop             chan struct{}                               // Closed by Stop, ends reopen backoffs
+	openHandle       func(deviceName string) (liveHandle, error) // Opens devices (openDevice unless replaced by tests)
+	OnDeviceError    func(device string, err error)              // Called when a device fails and for each failed reopen attempt
+	OnDeviceReopened func(device string)                         // Called when a failed device is capturing again
+
+	// Optional TCP chat capture (nil when disabled)
+	chat *chatAssembler
+
+	// Additional UDP ports captured besides the default ones
+	extraPorts []uint16
+
+	// Custom BPF filter replacing the built one, empty for the default (see SetBPFFilter)
+	bpfFilter string
+
+	// File replay (see StartFromFile)
+	replayPaced bool
+	replayStop  chan struct{}
+	replayBPF   *pcap.BPF // Compiled custom filter applied to replayed packets
+
+	// Optional pcap dump of matched packets (nil when disabled, see SetPcapDump)
+	dump *pcapDump
+
+	// Cross-interface de-duplication
+	dedup             *packetDeduplicator
+	DuplicateCallback func() // Called for each packet dropped as a duplicate
+
 	// Status tracking
 	lastPacketTime time.Time
 	isOnline       bool
+	onlineTimeout  time.Duration // See SetOnlineTimeout
+	onlineStop     chan struct{} // Stops the online status checker
 	OnlineCallback func(online bool)
 }
 
+// KernelStats contains the packet counters reported by pcap, summed over all open handles
+type KernelStats struct {
+	Received  uint64 // Packets received by the capture driver
+	Dropped   uint64 // Packets dropped because the capture buffer was full
+	IfDropped uint64 // Packets dropped by the network interface
+}
+
 // NewCapture creates a new network capture instance
 func NewCapture(handler PacketHandler) *Capture {
 	return &Capture{
-		handler:  handler,
-		handles:  make([]*pcap.Handle, 0),
-		isOnline: false,
+		handler:       handler,
+		handles:       make([]liveHandle, 0),
+		stop:          make(chan struct{}),
+		isOnline:      false,
+		onlineTimeout: DefaultOnlineTimeout,
+		dedup:         newPacketDeduplicator(DedupWindow),
+	}
+}
+
+// EnableChatCapture enables TCP capture and stream reassembly on the chat port.
+// Reassembled Photon messages are passed to handler. Must be called before Start.
+func (s *Capture) EnableChatCapture(handler ChatHandler) {
+	s.chat = newChatAssembler(handler)
+}
+
+// SetOnlineTimeout sets how long without packets before the game is considered offline
+// (DefaultOnlineTimeout if d <= 0). Raise it on high-latency connections where the
+// status flaps. Must be called before Start.
+func (s *Capture) SetOnlineTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultOnlineTimeout
 	}
+	s.onlineTimeout = d
 }
 
-// ListDevices returns all available network devices
+// SetExtraPorts adds UDP ports to capture besides PortMaster and PortGame
+// (e.g., regional servers). Must be called before Start.
+func (s *Capture) SetExtraPorts(ports []uint16) {
+	s.extraPorts = slices.Clone(ports)
+}
+
+// KernelStats returns the pcap counters of all open handles.
+// Handles that don't support statistics are skipped; after Stop, all counters are zero.
+func (s *Capture) KernelStats() KernelStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total KernelStats
+	if !s.running {
+		return total
+	}
+	for _, handle := range s.handles {
+		stats, err := handle.Stats()
+		if err != nil {
+			continue
+		}
+		total.Received += uint64(stats.PacketsReceived)
+		total.Dropped += uint64(stats.PacketsDropped)
+		total.IfDropped += uint64(stats.PacketsIfDropped)
+	}
+	return total
+}
+
+// SetBPFFilter replaces the BPF filter built from the chat and extra port settings
+// with a custom one; an empty filter restores the default. The filter is checked
+// with ValidateFilter before any device or file is opened. Must be called before Start.
+func (s *Capture) SetBPFFilter(filter string) {
+	s.bpfFilter = filter
+}
+
+// filter returns the BPF filter for the enabled traffic
+func (s *Capture) filter() string {
+	if s.bpfFilter != "" {
+		return s.bpfFilter
+	}
+	return BuildFilter(s.chat != nil, s.extraPorts)
+}
+
+// checkFilter validates a custom BPF filter, the built one is always valid
+func (s *Capture) checkFilter() error {
+	if s.bpfFilter == "" {
+		return nil
+	}
+	return ValidateFilter(s.bpfFilter)
+}
+
+// ListDevices returns all available network devices.
+// Errors caused by a missing capture library or missing privileges are
+// classified (see ClassifyError).
 func ListDevices() ([]pcap.Interface, error) {
-	return pcap.FindAllDevs()
+	devices, err := pcap.FindAllDevs()
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	return devices, nil
 }
 
 // PrintDevices prints all available network devices
 func PrintDevices() error {
-	devices, err := ListDevices()
+	devices, err := GetDevices()
 	if err != nil {
 		return fmt.Errorf("failed to list devices: %w", err)
 	}
 
 	fmt.Println("Available network devices:")
 	for i, device := range devices {
-		fmt.Printf("  %d. %s\n", i+1, device.Name)
+		fmt.Printf("  %d. %s (%s)\n", i+1, device.Name, deviceState(device))
 		if device.Description != "" {
 			fmt.Printf("     Description: %s\n", device.Description)
 		}
-		for _, addr := range device.Addresses {
-			if addr.IP.To4() != nil {
-				fmt.Printf("     IPv4: %s\n", addr.IP)
-			}
+		for _, ip := range device.IPv4 {
+			fmt.Printf("     IPv4: %s\n", ip)
+		}
+		for _, ip := range device.IPv6 {
+			fmt.Printf("     IPv6: %s\n", ip)
 		}
 	}
 	return nil
 }
 
+// deviceState describes the up and loopback flags of a device for PrintDevices
+func deviceState(device DeviceInfo) string {
+	state := "down"
+	if device.IsUp {
+		state = "up"
+	}
+	if device.IsLoopback {
+		state += ", loopback"
+	}
+	return state
+}
+
 // Start begins capturing packets on all available interfaces
 func (s *Capture) Start() error {
+	if err := s.checkFilter(); err != nil {
+		return err
+	}
+
 	devices, err := ListDevices()
 	if err != nil {
 		return fmt.Errorf("failed to list devices: %w", err)
@@ -92,46 +229,125 @@ func (s *Capture) Start() error {
 	s.running = true
 	s.mu.Unlock()
 
-	// Start capturing on all devices with IPv4 addresses
-	for _, device := range devices {
-		for _, addr := range device.Addresses {
-			if addr.IP.To4() != nil {
-				go s.captureOnDevice(device.Name, addr.IP.String())
-			}
-		}
+	// Start capturing on all devices with a usable IPv4 or IPv6 address
+	for _, name := range captureDevices(devices) {
+		go s.captureOnDevice(name)
 	}
 
 	// Start online status checker
-	go s.checkOnlineStatus()
+	s.startOnlineChecker()
 
 	return nil
 }
 
 // StartOnDevice begins capturing packets on a specific device
 func (s *Capture) StartOnDevice(deviceName string) error {
+	if err := s.checkFilter(); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	s.running = true
 	s.mu.Unlock()
 
-	go s.captureOnDevice(deviceName, "")
+	go s.captureOnDevice(deviceName)
 
 	// Start online status checker
-	go s.checkOnlineStatus()
+	s.startOnlineChecker()
 
 	return nil
 }
 
-// captureOnDevice captures packets on a specific network device
-func (s *Capture) captureOnDevice(deviceName, ipAddr string) {
+// StartOnDevices begins capturing packets on the given devices.
+// Every device must exist and be opened successfully; otherwise nothing is captured
+// and the errors of all failing devices are returned together.
+func (s *Capture) StartOnDevices(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no devices given")
+	}
+	if err := s.checkFilter(); err != nil {
+		return err
+	}
+
+	devices, err := ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+	if err := checkDevices(names, devices); err != nil {
+		return err
+	}
+
+	// Open all devices before capturing on any of them
+	var errs []error
+	handles := make([]liveHandle, 0, len(names))
+	for _, name := range names {
+		handle, err := s.openDevice(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", name, err))
+			continue
+		}
+		handles = append(handles, handle)
+	}
+	if len(errs) > 0 {
+		for _, handle := range handles {
+			handle.Close()
+		}
+		return errors.Join(errs...)
+	}
+
+	s.mu.Lock()
+	s.running = true
+	s.handles = append(s.handles, handles...)
+	s.mu.Unlock()
+
+	for i, handle := range handles {
+		s.wg.Add(1)
+		go func(handle liveHandle, deviceName string) {
+			defer s.wg.Done()
+			s.capturePackets(handle, deviceName)
+		}(handle, names[i])
+	}
+
+	// Start online status checker
+	s.startOnlineChecker()
+
+	return nil
+}
+
+// checkDevices returns an error listing the names that aren't among devices
+func checkDevices(names []string, devices []pcap.Interface) error {
+	var errs []error
+	for _, name := range names {
+		found := slices.ContainsFunc(devices, func(device pcap.Interface) bool {
+			return device.Name == name
+		})
+		if !found {
+			errs = append(errs, fmt.Errorf("device %s not found (see -list)", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// openDevice opens a live capture handle on a device with the BPF filter set
+func (s *Capture) openDevice(deviceName string) (*pcap.Handle, error) {
 	handle, err := pcap.OpenLive(deviceName, SnapshotLen, Promiscuous, Timeout)
 	if err != nil {
-		// Silently skip devices that can't be opened
-		return
+		return nil, ClassifyError(err)
 	}
 
 	// Set BPF filter
-	if err := handle.SetBPFFilter(BPFFilter); err != nil {
+	if err := handle.SetBPFFilter(s.filter()); err != nil {
 		handle.Close()
+		return nil, fmt.Errorf("failed to set BPF filter: %w", err)
+	}
+	return handle, nil
+}
+
+// captureOnDevice captures packets on a specific network device
+func (s *Capture) captureOnDevice(deviceName string) {
+	handle, err := s.openDevice(deviceName)
+	if err != nil {
+		// Silently skip devices that can't be opened
 		return
 	}
 
@@ -142,27 +358,36 @@ func (s *Capture) captureOnDevice(deviceName, ipAddr string) {
 	s.wg.Add(1)
 	defer s.wg.Done()
 
-	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
-	for packet := range packetSource.Packets() {
-		s.mu.Lock()
-		if !s.running {
-			s.mu.Unlock()
-			break
-		}
-		s.mu.Unlock()
+	s.capturePackets(handle, deviceName)
+}
 
-		s.processPacket(packet)
+// processPacket extracts UDP payload and passes it to the handler.
+// deviceName identifies the interface the packet was captured on.
+func (s *Capture) processPacket(packet gopacket.Packet, deviceName string) {
+	// Get IP layer (IPv4 or IPv6)
+	var ip gopacket.NetworkLayer
+	var srcIP, dstIP net.IP
+	if ipLayer := packet.Layer(layers.LayerTypeIPv4); ipLayer != nil {
+		ip4, _ := ipLayer.(*layers.IPv4)
+		ip, srcIP, dstIP = ip4, ip4.SrcIP, ip4.DstIP
+	} else if ipLayer := packet.Layer(layers.LayerTypeIPv6); ipLayer != nil {
+		ip6, _ := ipLayer.(*layers.IPv6)
+		ip, srcIP, dstIP = ip6, ip6.SrcIP, ip6.DstIP
+	} else {
+		return
 	}
-}
 
-// processPacket extracts UDP payload and passes it to the handler
-func (s *Capture) processPacket(packet gopacket.Packet) {
-	// Get IP layer
-	ipLayer := packet.Layer(layers.LayerTypeIPv4)
-	if ipLayer == nil {
+	// TCP chat traffic goes through stream reassembly
+	if tcpLayer := packet.Layer(layers.LayerTypeTCP); tcpLayer != nil {
+		if s.chat != nil {
+			if s.dump != nil {
+				s.dump.write(ip, packet.Metadata().Timestamp)
+			}
+			tcp, _ := tcpLayer.(*layers.TCP)
+			s.chat.assemble(ip.NetworkFlow(), tcp, packet.Metadata().Timestamp)
+		}
 		return
 	}
-	ip, _ := ipLayer.(*layers.IPv4)
 
 	// Get UDP layer
 	udpLayer := packet.Layer(layers.LayerTypeUDP)
@@ -182,6 +407,23 @@ func (s *Capture) processPacket(packet gopacket.Packet) {
 		return
 	}
 
+	// Drop the same packet captured on another interface
+	timestamp := packet.Metadata().Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	key := packetKey(payload, srcIP, dstIP, uint16(udp.SrcPort), uint16(udp.DstPort))
+	if s.dedup.isDuplicate(key, deviceName, timestamp) {
+		if s.DuplicateCallback != nil {
+			s.DuplicateCallback()
+		}
+		return
+	}
+
+	if s.dump != nil {
+		s.dump.write(ip, timestamp)
+	}
+
 	// Update last packet time
 	s.mu.Lock()
 	s.lastPacketTime = time.Now()
@@ -199,27 +441,45 @@ func (s *Capture) processPacket(packet gopacket.Packet) {
 	if s.handler != nil {
 		s.handler(
 			payload,
-			ip.SrcIP,
-			ip.DstIP,
+			srcIP,
+			dstIP,
 			uint16(udp.SrcPort),
 			uint16(udp.DstPort),
 		)
 	}
 }
 
-// checkOnlineStatus periodically checks if the game is still sending packets
-func (s *Capture) checkOnlineStatus() {
-	ticker := time.NewTicker(5 * time.Second)
+// startOnlineChecker starts checkOnlineStatus, until Stop
+func (s *Capture) startOnlineChecker() {
+	stop := make(chan struct{})
+	s.mu.Lock()
+	s.onlineStop = stop
+	s.mu.Unlock()
+
+	go s.checkOnlineStatus(stop, s.onlineTimeout)
+}
+
+// checkOnlineStatus periodically checks if the game is still sending packets,
+// going offline after timeout without any, until stop is closed
+func (s *Capture) checkOnlineStatus(stop <-chan struct{}, timeout time.Duration) {
+	// Check twice per timeout, so going offline takes at most 1.5x the timeout
+	ticker := time.NewTicker(timeout / 2)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
 		s.mu.Lock()
 		if !s.running {
 			s.mu.Unlock()
 			return
 		}
 
-		if s.isOnline && time.Since(s.lastPacketTime) > 5*time.Second {
+		if s.isOnline && time.Since(s.lastPacketTime) > timeout {
 			s.isOnline = false
 			s.mu.Unlock()
 			if s.OnlineCallback != nil {
@@ -235,13 +495,36 @@ func (s *Capture) checkOnlineStatus() {
 func (s *Capture) Stop() {
 	s.mu.Lock()
 	s.running = false
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	handles := s.handles
+	s.handles = nil
+	if s.replayStop != nil {
+		close(s.replayStop)
+		s.replayStop = nil
+	}
+	if s.onlineStop != nil {
+		close(s.onlineStop)
+		s.onlineStop = nil
+	}
 	s.mu.Unlock()
 
-	for _, handle := range s.handles {
+	for _, handle := range handles {
 		handle.Close()
 	}
 
 	s.wg.Wait()
+
+	if s.chat != nil {
+		s.chat.flushAll()
+	}
+
+	if s.dump != nil {
+		_ = s.dump.close()
+	}
 }
 
 // IsOnline returns whether the game is currently sending packets
@@ -0,0 +1,198 @@
+package capture
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// TestCheckDevices tests that every unknown device is reported
+func TestCheckDevices(t *testing.T) {
+	devices := []pcap.Interface{{Name: "eth0"}, {Name: "wlan0"}}
+
+	if err := checkDevices([]string{"eth0", "wlan0"}, devices); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	err := checkDevices([]string{"eth0", "tun0", "vmnet1"}, devices)
+	if err == nil {
+		t.Fatal("expected error for unknown devices")
+	}
+	for _, name := range []string{"tun0", "vmnet1"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected error to mention %s, got %v", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), "eth0") {
+		t.Errorf("expected error not to mention eth0, got %v", err)
+	}
+}
+
+// TestStartOnDevicesEmpty tests that a device list is required
+func TestStartOnDevicesEmpty(t *testing.T) {
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	if err := c.StartOnDevices(nil); err == nil {
+		t.Error("expected error for no devices")
+	}
+}
+
+// buildUDPv6Packet builds a decoded Ethernet/IPv6/UDP packet from the game port
+func buildUDPv6Packet(t *testing.T, payload []byte) gopacket.Packet {
+	t.Helper()
+
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 1},
+		DstMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 2},
+		EthernetType: layers.EthernetTypeIPv6,
+	}
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		NextHeader: layers.IPProtocolUDP,
+		SrcIP:      net.ParseIP("2001:db8::1"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+	}
+	udp := &layers.UDP{
+		SrcPort: PortGame,
+		DstPort: 50000,
+	}
+	_ = udp.SetNetworkLayerForChecksum(ip)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, eth, ip, udp, gopacket.Payload(payload)); err != nil {
+		t.Fatalf("failed to serialize packet: %v", err)
+	}
+
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeEthernet, gopacket.Default)
+	packet.Metadata().Timestamp = time.Now()
+	return packet
+}
+
+// TestProcessPacketIPv6 tests that the UDP payload and addresses of IPv6 packets are extracted
+func TestProcessPacketIPv6(t *testing.T) {
+	var gotPayload string
+	var gotSrc, gotDst net.IP
+	var gotSrcPort, gotDstPort uint16
+	c := NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
+		gotPayload = string(payload)
+		gotSrc, gotDst = srcIP, dstIP
+		gotSrcPort, gotDstPort = srcPort, dstPort
+	})
+
+	c.processPacket(buildUDPv6Packet(t, []byte("game")), "eth0")
+
+	if gotPayload != "game" {
+		t.Fatalf("expected payload game, got %q", gotPayload)
+	}
+	if !gotSrc.Equal(net.ParseIP("2001:db8::1")) || !gotDst.Equal(net.ParseIP("2001:db8::2")) {
+		t.Errorf("expected 2001:db8::1 -> 2001:db8::2, got %v -> %v", gotSrc, gotDst)
+	}
+	if gotSrc.To4() != nil {
+		t.Errorf("expected an IPv6 address, got %v", gotSrc)
+	}
+	if gotSrcPort != PortGame || gotDstPort != 50000 {
+		t.Errorf("expected ports %d -> 50000, got %d -> %d", PortGame, gotSrcPort, gotDstPort)
+	}
+}
+
+// TestPcapDumpIPv6 tests that IPv6 packets are dumped and replayed
+func TestPcapDumpIPv6(t *testing.T) {
+	dump := filepath.Join(t.TempDir(), "dump.pcap")
+
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	if err := c.SetPcapDump(dump); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.processPacket(buildUDPv6Packet(t, []byte("game")), "eth0")
+	if err := c.dump.close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replay, payloads := newReplayCapture()
+	if err := replay.StartFromFile(dump); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replay.Wait()
+	replay.Stop()
+
+	if got := payloads(); len(got) != 1 || got[0] != "game" {
+		t.Errorf("expected [game], got %v", got)
+	}
+}
+
+// TestSetOnlineTimeout tests the online timeout default and its fallback for invalid values
+func TestSetOnlineTimeout(t *testing.T) {
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	if c.onlineTimeout != DefaultOnlineTimeout {
+		t.Errorf("expected default %v, got %v", DefaultOnlineTimeout, c.onlineTimeout)
+	}
+
+	c.SetOnlineTimeout(30 * time.Second)
+	if c.onlineTimeout != 30*time.Second {
+		t.Errorf("expected 30s, got %v", c.onlineTimeout)
+	}
+
+	c.SetOnlineTimeout(-time.Second)
+	if c.onlineTimeout != DefaultOnlineTimeout {
+		t.Errorf("expected fallback to %v, got %v", DefaultOnlineTimeout, c.onlineTimeout)
+	}
+}
+
+// TestOnlineTimeout tests going offline once no packet arrived for the timeout
+func TestOnlineTimeout(t *testing.T) {
+	status := make(chan bool, 2)
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	c.OnlineCallback = func(online bool) {
+		status <- online
+	}
+	c.SetOnlineTimeout(40 * time.Millisecond)
+
+	c.mu.Lock()
+	c.running = true
+	c.mu.Unlock()
+	c.startOnlineChecker()
+	defer c.Stop()
+
+	c.processPacket(buildUDPPacket(t, []byte("game"), time.Now()), "eth0")
+	if online := <-status; !online {
+		t.Fatal("expected online after a packet")
+	}
+
+	select {
+	case online := <-status:
+		if online {
+			t.Error("expected offline after the timeout")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected offline within a second")
+	}
+}
+
+// TestStopEndsOnlineChecker tests that Stop signals the checker without waiting for a tick
+func TestStopEndsOnlineChecker(t *testing.T) {
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	c.SetOnlineTimeout(time.Hour)
+
+	c.mu.Lock()
+	c.running = true
+	c.mu.Unlock()
+	c.startOnlineChecker()
+
+	c.mu.Lock()
+	stop := c.onlineStop
+	c.mu.Unlock()
+
+	c.Stop()
+	select {
+	case <-stop:
+	default:
+		t.Error("expected the checker stop channel to be closed")
+	}
+}
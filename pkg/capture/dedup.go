@@ -0,0 +1,80 @@
+package capture
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"net"
+	"sync"
+	"time"
+)
+
+// DedupWindow is how long a packet is remembered for cross-interface de-duplication.
+// The same packet captured on two interfaces (e.g., a bridge and its member) arrives
+// within microseconds, so a short window is enough and avoids dropping real repeats.
+const DedupWindow = 50 * time.Millisecond
+
+// dedupEntry records where and when a packet was last seen
+type dedupEntry struct {
+	device string
+	seen   time.Time
+}
+
+// packetDeduplicator detects the same packet captured on more than one interface
+type packetDeduplicator struct {
+	mu        sync.Mutex
+	seen      map[uint64]dedupEntry
+	window    time.Duration
+	lastSweep time.Time
+}
+
+// newPacketDeduplicator creates a deduplicator with the given window
+func newPacketDeduplicator(window time.Duration) *packetDeduplicator {
+	return &packetDeduplicator{
+		seen:   make(map[uint64]dedupEntry),
+		window: window,
+	}
+}
+
+// packetKey hashes the payload together with the addresses and ports
+func packetKey(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(srcIP.To16())
+	_, _ = h.Write(dstIP.To16())
+
+	var ports [4]byte
+	binary.BigEndian.PutUint16(ports[0:2], srcPort)
+	binary.BigEndian.PutUint16(ports[2:4], dstPort)
+	_, _ = h.Write(ports[:])
+
+	_, _ = h.Write(payload)
+	return h.Sum64()
+}
+
+// isDuplicate reports whether the packet was already seen on a different device
+// within the window. Repeats on the same device are real packets and are kept.
+func (d *packetDeduplicator) isDuplicate(key uint64, device string, ts time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(ts)
+
+	if entry, ok := d.seen[key]; ok && ts.Sub(entry.seen) <= d.window && entry.device != device {
+		return true
+	}
+
+	d.seen[key] = dedupEntry{device: device, seen: ts}
+	return false
+}
+
+// sweep removes expired entries (at most once per window)
+func (d *packetDeduplicator) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.window {
+		return
+	}
+	for key, entry := range d.seen {
+		if now.Sub(entry.seen) > d.window {
+			delete(d.seen, key)
+		}
+	}
+	d.lastSweep = now
+}
@@ -0,0 +1,132 @@
+package capture
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// buildUDPPacket builds a decoded Ethernet/IPv4/UDP packet on the game port
+func buildUDPPacket(t *testing.T, payload []byte, ts time.Time) gopacket.Packet {
+	t.Helper()
+
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 1},
+		DstMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 2},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IPv4(10, 0, 0, 1),
+		DstIP:    net.IPv4(10, 0, 0, 2),
+	}
+	udp := &layers.UDP{
+		SrcPort: PortGame,
+		DstPort: 50000,
+	}
+	_ = udp.SetNetworkLayerForChecksum(ip)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, eth, ip, udp, gopacket.Payload(payload)); err != nil {
+		t.Fatalf("failed to serialize packet: %v", err)
+	}
+
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeEthernet, gopacket.Default)
+	packet.Metadata().Timestamp = ts
+	return packet
+}
+
+// newDedupTestCapture creates a Capture that counts handled and duplicate packets
+func newDedupTestCapture() (*Capture, *int, *int) {
+	handled, duplicates := 0, 0
+	c := NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
+		handled++
+	})
+	c.DuplicateCallback = func() {
+		duplicates++
+	}
+	return c, &handled, &duplicates
+}
+
+// TestDedupAcrossInterfaces tests that a packet seen on two interfaces is processed once
+func TestDedupAcrossInterfaces(t *testing.T) {
+	c, handled, duplicates := newDedupTestCapture()
+	now := time.Now()
+	payload := []byte{0, 1, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2}
+
+	c.processPacket(buildUDPPacket(t, payload, now), "br0")
+	c.processPacket(buildUDPPacket(t, payload, now.Add(time.Millisecond)), "eth0")
+
+	if *handled != 1 {
+		t.Errorf("expected 1 handled packet, got %d", *handled)
+	}
+	if *duplicates != 1 {
+		t.Errorf("expected 1 duplicate, got %d", *duplicates)
+	}
+}
+
+// TestDedupSameInterfaceKept tests that repeats on the same interface are not dropped
+func TestDedupSameInterfaceKept(t *testing.T) {
+	c, handled, duplicates := newDedupTestCapture()
+	now := time.Now()
+	payload := []byte{0, 1, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2}
+
+	c.processPacket(buildUDPPacket(t, payload, now), "eth0")
+	c.processPacket(buildUDPPacket(t, payload, now.Add(time.Millisecond)), "eth0")
+
+	if *handled != 2 {
+		t.Errorf("expected 2 handled packets, got %d", *handled)
+	}
+	if *duplicates != 0 {
+		t.Errorf("expected 0 duplicates, got %d", *duplicates)
+	}
+}
+
+// TestDedupOutsideWindow tests that packets outside the window are processed again
+func TestDedupOutsideWindow(t *testing.T) {
+	c, handled, duplicates := newDedupTestCapture()
+	now := time.Now()
+	payload := []byte{0, 1, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2}
+
+	c.processPacket(buildUDPPacket(t, payload, now), "br0")
+	c.processPacket(buildUDPPacket(t, payload, now.Add(2*DedupWindow)), "eth0")
+
+	if *handled != 2 {
+		t.Errorf("expected 2 handled packets, got %d", *handled)
+	}
+	if *duplicates != 0 {
+		t.Errorf("expected 0 duplicates, got %d", *duplicates)
+	}
+}
+
+// TestDedupDifferentPayloads tests that different packets are never deduplicated
+func TestDedupDifferentPayloads(t *testing.T) {
+	c, handled, _ := newDedupTestCapture()
+	now := time.Now()
+
+	c.processPacket(buildUDPPacket(t, []byte{1, 2, 3}, now), "br0")
+	c.processPacket(buildUDPPacket(t, []byte{1, 2, 4}, now), "eth0")
+
+	if *handled != 2 {
+		t.Errorf("expected 2 handled packets, got %d", *handled)
+	}
+}
+
+// TestDedupSweep tests that expired entries are removed
+func TestDedupSweep(t *testing.T) {
+	d := newPacketDeduplicator(DedupWindow)
+	now := time.Now()
+
+	d.isDuplicate(1, "eth0", now)
+	d.isDuplicate(2, "eth0", now.Add(3*DedupWindow))
+
+	if len(d.seen) != 1 {
+		t.Errorf("expected 1 entry after sweep, got %d", len(d.seen))
+	}
+}
@@ -0,0 +1,106 @@
+package capture
+
+import (
+	"net"
+	"slices"
+
+	"github.com/google/gopacket/pcap"
+)
+
+// pcap interface flags (PCAP_IF_*), only reported by libpcap 1.6 and later
+const (
+	pcapIfLoopback = 0x1
+	pcapIfUp       = 0x2
+	pcapIfRunning  = 0x4
+)
+
+// DeviceInfo describes a network device available for capture
+type DeviceInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IPv4        []string `json:"ipv4"`
+	IPv6        []string `json:"ipv6"`
+	IsLoopback  bool     `json:"is_loopback"`
+	IsUp        bool     `json:"is_up"`
+}
+
+// GetDevices returns the network devices available for capture, for frontends
+// that present a device picker. Errors are classified as in ListDevices.
+func GetDevices() ([]DeviceInfo, error) {
+	devices, err := ListDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DeviceInfo, len(devices))
+	for i, device := range devices {
+		infos[i] = newDeviceInfo(device, lookupInterface(device.Name))
+	}
+	return infos, nil
+}
+
+// lookupInterface returns the OS interface with the given pcap device name, or nil
+// if there is none (e.g. Windows NPF device names)
+func lookupInterface(name string) *net.Interface {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil
+	}
+	return iface
+}
+
+// newDeviceInfo builds the description of a pcap device. The up and loopback flags
+// come from the OS interface when it is known, otherwise from the pcap flags. Old
+// libpcap versions report no up flag, in which case a device with an address is
+// assumed to be up.
+func newDeviceInfo(device pcap.Interface, iface *net.Interface) DeviceInfo {
+	info := DeviceInfo{
+		Name:        device.Name,
+		Description: device.Description,
+		IPv4:        []string{},
+		IPv6:        []string{},
+	}
+	for _, addr := range device.Addresses {
+		if addr.IP.To4() != nil {
+			info.IPv4 = append(info.IPv4, addr.IP.String())
+		} else if addr.IP.To16() != nil {
+			info.IPv6 = append(info.IPv6, addr.IP.String())
+		}
+	}
+
+	switch {
+	case iface != nil:
+		info.IsLoopback = iface.Flags&net.FlagLoopback != 0
+		info.IsUp = iface.Flags&net.FlagUp != 0
+	case device.Flags&(pcapIfUp|pcapIfRunning) != 0:
+		info.IsLoopback = device.Flags&pcapIfLoopback != 0
+		info.IsUp = true
+	default:
+		info.IsLoopback = device.Flags&pcapIfLoopback != 0
+		info.IsUp = len(device.Addresses) > 0
+	}
+	return info
+}
+
+// captureDevices returns the names of the devices to capture on, each once: those
+// with at least one usable address
+func captureDevices(devices []pcap.Interface) []string {
+	var names []string
+	for _, device := range devices {
+		if slices.ContainsFunc(device.Addresses, func(addr pcap.InterfaceAddress) bool {
+			return usableAddress(addr.IP)
+		}) {
+			names = append(names, device.Name)
+		}
+	}
+	return names
+}
+
+// usableAddress reports whether a device address can carry game traffic: any IPv4
+// address, or an IPv6 address other than the unspecified and link-local ones
+func usableAddress(ip net.IP) bool {
+	if ip.To4() != nil {
+		return true
+	}
+	return ip.To16() != nil && !ip.IsUnspecified() && !ip.IsLinkLocalUnicast()
+}
@@ -0,0 +1,114 @@
+package capture
+
+import (
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/google/gopacket/pcap"
+)
+
+// TestNewDeviceInfoAddresses tests that addresses are split by family
+func TestNewDeviceInfoAddresses(t *testing.T) {
+	device := pcap.Interface{
+		Name:        "eth0",
+		Description: "Ethernet",
+		Addresses: []pcap.InterfaceAddress{
+			{IP: net.ParseIP("192.168.1.10")},
+			{IP: net.ParseIP("fe80::1")},
+		},
+	}
+
+	info := newDeviceInfo(device, nil)
+	if info.Name != "eth0" || info.Description != "Ethernet" {
+		t.Errorf("unexpected name/description: %+v", info)
+	}
+	if !slices.Equal(info.IPv4, []string{"192.168.1.10"}) {
+		t.Errorf("expected IPv4 [192.168.1.10], got %v", info.IPv4)
+	}
+	if !slices.Equal(info.IPv6, []string{"fe80::1"}) {
+		t.Errorf("expected IPv6 [fe80::1], got %v", info.IPv6)
+	}
+}
+
+// TestNewDeviceInfoFlags tests the up/loopback flags from the OS interface, the pcap
+// flags and the fallback when neither reports them
+func TestNewDeviceInfoFlags(t *testing.T) {
+	withAddress := []pcap.InterfaceAddress{{IP: net.ParseIP("10.0.0.1")}}
+
+	tests := []struct {
+		name         string
+		device       pcap.Interface
+		iface        *net.Interface
+		wantUp       bool
+		wantLoopback bool
+	}{
+		{"os interface up", pcap.Interface{}, &net.Interface{Flags: net.FlagUp}, true, false},
+		{"os loopback down", pcap.Interface{Flags: pcapIfUp}, &net.Interface{Flags: net.FlagLoopback}, false, true},
+		{"pcap flags", pcap.Interface{Flags: pcapIfUp | pcapIfLoopback}, nil, true, true},
+		{"no flags with address", pcap.Interface{Addresses: withAddress}, nil, true, false},
+		{"no flags without address", pcap.Interface{}, nil, false, false},
+	}
+
+	for _, tt := range tests {
+		info := newDeviceInfo(tt.device, tt.iface)
+		if info.IsUp != tt.wantUp || info.IsLoopback != tt.wantLoopback {
+			t.Errorf("%s: expected up=%v loopback=%v, got up=%v loopback=%v",
+				tt.name, tt.wantUp, tt.wantLoopback, info.IsUp, info.IsLoopback)
+		}
+	}
+}
+
+// TestUsableAddress tests which device addresses are captured on, including a device
+// with IPv6 addresses only
+func TestUsableAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"192.168.1.10", true},
+		{"127.0.0.1", true},
+		{"2001:db8::10", true},
+		{"::1", true},
+		{"fe80::1", false},
+		{"::", false},
+	}
+	for _, tt := range tests {
+		if got := usableAddress(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.ip, tt.want, got)
+		}
+	}
+	if usableAddress(nil) {
+		t.Error("expected a missing address to be unusable")
+	}
+
+	ipv6Only := pcap.Interface{
+		Name: "eth0",
+		Addresses: []pcap.InterfaceAddress{
+			{IP: net.ParseIP("fe80::1")},
+			{IP: net.ParseIP("2001:db8::10")},
+		},
+	}
+	if !slices.ContainsFunc(ipv6Only.Addresses, func(addr pcap.InterfaceAddress) bool { return usableAddress(addr.IP) }) {
+		t.Error("expected a device with only IPv6 addresses to be captured on")
+	}
+}
+
+// TestCaptureDevices tests that each device with a usable address is selected once,
+// however many addresses it has
+func TestCaptureDevices(t *testing.T) {
+	devices := []pcap.Interface{
+		{Name: "eth0", Addresses: []pcap.InterfaceAddress{
+			{IP: net.ParseIP("192.168.1.10")},
+			{IP: net.ParseIP("10.0.0.10")},
+			{IP: net.ParseIP("2001:db8::10")},
+		}},
+		{Name: "wlan0", Addresses: []pcap.InterfaceAddress{{IP: net.ParseIP("fe80::1")}}},
+		{Name: "eth1", Addresses: []pcap.InterfaceAddress{{IP: net.ParseIP("2001:db8::20")}}},
+		{Name: "any"},
+	}
+
+	if got := captureDevices(devices); !slices.Equal(got, []string{"eth0", "eth1"}) {
+		t.Errorf("expected [eth0 eth1], got %v", got)
+	}
+}
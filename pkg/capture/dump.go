@@ -0,0 +1,84 @@
+package capture
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// pcapDump writes captured packets to a pcap file.
+// Packets are written from the IP layer on (raw IP link type), so that packets
+// captured on devices with different link types can share one file.
+type pcapDump struct {
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	w    *pcapgo.Writer
+}
+
+// SetPcapDump writes every matched packet to a pcap file at path (e.g., to share a
+// reproduction, replayable with StartFromFile). Must be called before Start.
+// If the file can't be created, an error is returned and capture works without a dump;
+// if writing fails later, dumping stops. The file is flushed and closed by Stop.
+func (s *Capture) SetPcapDump(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create pcap dump: %w", err)
+	}
+
+	dump := &pcapDump{file: f, buf: bufio.NewWriter(f)}
+	dump.w = pcapgo.NewWriter(dump.buf)
+	if err := dump.w.WriteFileHeader(SnapshotLen, layers.LinkTypeRaw); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write pcap dump: %w", err)
+	}
+
+	s.dump = dump
+	return nil
+}
+
+// write writes the IP (IPv4 or IPv6) packet ip to the dump. On error, the dump is closed.
+func (d *pcapDump) write(ip gopacket.Layer, timestamp time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.file == nil {
+		return
+	}
+
+	data := make([]byte, 0, len(ip.LayerContents())+len(ip.LayerPayload()))
+	data = append(data, ip.LayerContents()...)
+	data = append(data, ip.LayerPayload()...)
+
+	ci := gopacket.CaptureInfo{Timestamp: timestamp, CaptureLength: len(data), Length: len(data)}
+	if err := d.w.WritePacket(ci, data); err != nil {
+		d.closeLocked()
+	}
+}
+
+// close flushes and closes the dump file
+func (d *pcapDump) close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.closeLocked()
+}
+
+// closeLocked flushes and closes the dump file. The caller must hold d.mu.
+func (d *pcapDump) closeLocked() error {
+	if d.file == nil {
+		return nil
+	}
+
+	err := d.buf.Flush()
+	if closeErr := d.file.Close(); err == nil {
+		err = closeErr
+	}
+	d.file = nil
+	return err
+}
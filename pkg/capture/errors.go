@@ -0,0 +1,104 @@
+package capture
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+)
+
+var (
+	// ErrPcapUnavailable indicates that Npcap/libpcap is not installed or could not be loaded
+	ErrPcapUnavailable = errors.New("Npcap/libpcap not found")
+
+	// ErrPermissionDenied indicates that capturing requires elevated privileges
+	ErrPermissionDenied = errors.New("insufficient permissions to capture packets")
+)
+
+// pcapUnavailablePatterns are (lowercase) error fragments reported when the capture
+// library is missing or can't be loaded
+var pcapUnavailablePatterns = []string{
+	"wpcap.dll",
+	"packet.dll",
+	"libpcap",
+	"npcap",
+	"winpcap",
+	"cannot open shared object file",
+	"image not found",
+	"library not loaded",
+}
+
+// permissionDeniedPatterns are (lowercase) error fragments reported when the process
+// lacks capture privileges
+var permissionDeniedPatterns = []string{
+	"permission",
+	"operation not permitted",
+	"access is denied",
+	"access denied",
+}
+
+// CaptureError is a classified capture error with an actionable hint
+type CaptureError struct {
+	Kind error  // ErrPcapUnavailable or ErrPermissionDenied
+	Hint string // What the user can do about it
+	Err  error  // Original error
+}
+
+// Error implements the error interface
+func (e *CaptureError) Error() string {
+	return e.Kind.Error() + " — " + e.Hint + " (" + e.Err.Error() + ")"
+}
+
+// Unwrap allows errors.Is to match both the kind and the original error
+func (e *CaptureError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ClassifyError turns a raw pcap error into a CaptureError when it indicates a missing
+// capture library or missing privileges. Other errors are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified *CaptureError
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, pcapUnavailablePatterns):
+		return &CaptureError{Kind: ErrPcapUnavailable, Hint: pcapInstallHint(runtime.GOOS), Err: err}
+	case containsAny(msg, permissionDeniedPatterns):
+		return &CaptureError{Kind: ErrPermissionDenied, Hint: permissionHint(runtime.GOOS), Err: err}
+	}
+	return err
+}
+
+// pcapInstallHint returns install instructions for the capture library
+func pcapInstallHint(goos string) string {
+	switch goos {
+	case "windows":
+		return "install Npcap from https://npcap.com with \"WinPcap API-compatible Mode\" enabled"
+	case "darwin":
+		return "libpcap ships with macOS; reinstall the Xcode command line tools (xcode-select --install)"
+	}
+	return "install libpcap (e.g. sudo apt install libpcap0.8, sudo dnf install libpcap or sudo pacman -S libpcap)"
+}
+
+// permissionHint returns how to grant capture privileges
+func permissionHint(goos string) string {
+	if goos == "windows" {
+		return "run as administrator"
+	}
+	return "run with sudo or grant the binary cap_net_raw,cap_net_admin"
+}
+
+// containsAny reports whether s contains any of the patterns
+func containsAny(s string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
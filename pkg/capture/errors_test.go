@@ -0,0 +1,69 @@
+package capture
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestClassifyError tests classification of representative pcap error strings
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      string
+		expected error
+	}{
+		{"windows missing npcap", "couldn't load wpcap.dll", ErrPcapUnavailable},
+		{"windows missing packet.dll", "The specified module could not be found: Packet.dll", ErrPcapUnavailable},
+		{"linux missing library", "libpcap.so.0.8: cannot open shared object file: No such file or directory", ErrPcapUnavailable},
+		{"macos missing library", "dyld: Library not loaded: /usr/lib/libpcap.A.dylib", ErrPcapUnavailable},
+		{"linux no privileges", "eth0: You don't have permission to capture on that device (socket: Operation not permitted)", ErrPermissionDenied},
+		{"windows no privileges", "Error opening adapter: Access is denied. (5)", ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := errors.New(tt.err)
+			err := ClassifyError(raw)
+
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if !errors.Is(err, raw) {
+				t.Error("expected classified error to wrap the original error")
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected message to include original error, got %q", err.Error())
+			}
+		})
+	}
+}
+
+// TestClassifyErrorUnrelated tests that other errors are returned unchanged
+func TestClassifyErrorUnrelated(t *testing.T) {
+	raw := errors.New("no such device exists")
+	if err := ClassifyError(raw); err != raw {
+		t.Errorf("expected unchanged error, got %v", err)
+	}
+	if ClassifyError(nil) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+// TestClassifyErrorIdempotent tests that classifying twice does not nest errors
+func TestClassifyErrorIdempotent(t *testing.T) {
+	once := ClassifyError(errors.New("couldn't load wpcap.dll"))
+	if twice := ClassifyError(once); twice != once {
+		t.Errorf("expected same error, got %v", twice)
+	}
+}
+
+// TestPcapInstallHint tests that install hints are platform specific
+func TestPcapInstallHint(t *testing.T) {
+	if hint := pcapInstallHint("windows"); !strings.Contains(hint, "npcap.com") {
+		t.Errorf("expected Npcap link on windows, got %q", hint)
+	}
+	if hint := pcapInstallHint("linux"); !strings.Contains(hint, "libpcap") {
+		t.Errorf("expected libpcap on linux, got %q", hint)
+	}
+}
@@ -0,0 +1,179 @@
+package capture
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// replayDevice is the device name reported for packets replayed from a file
+const replayDevice = "file"
+
+// SetReplayPaced sets whether StartFromFile replays packets with their recorded
+// inter-packet timing instead of as fast as possible. Must be called before StartFromFile.
+func (s *Capture) SetReplayPaced(paced bool) {
+	s.replayPaced = paced
+}
+
+// ReplayOptions controls how StartFromFileWithOptions replays a pcap file
+type ReplayOptions struct {
+	Speed      float64 // With RealTiming, playback speed (2.0 plays twice as fast); 0 means 1
+	Loop       bool    // Restart from the beginning at the end of the file, until Stop
+	RealTiming bool    // Honor the recorded inter-packet timing instead of replaying as fast as possible
+}
+
+// StartFromFile replays the packets of a pcap capture file (e.g., recorded with tcpdump
+// or Wireshark) instead of capturing live traffic, as fast as possible unless paced
+// (see SetReplayPaced). Packets outside the Albion ports (or not matching the custom
+// BPF filter, see SetBPFFilter) are skipped, as the BPF filter would do for live capture,
+// and the online status is tracked the same way.
+// The file is replayed in the background; Wait blocks until it has been fully read.
+func (s *Capture) StartFromFile(path string) error {
+	return s.StartFromFileWithOptions(path, ReplayOptions{RealTiming: s.replayPaced})
+}
+
+// StartFromFileWithOptions replays a pcap capture file like StartFromFile, with
+// control over the pace and looping. A looping replay only ends with Stop, so Wait
+// blocks until then.
+func (s *Capture) StartFromFileWithOptions(path string, opts ReplayOptions) error {
+	if opts.Speed < 0 {
+		return fmt.Errorf("invalid replay speed %v", opts.Speed)
+	}
+	if opts.Speed == 0 {
+		opts.Speed = 1
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open pcap file: %w", err)
+	}
+
+	reader, err := pcapgo.NewReader(f)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to read pcap file: %w", err)
+	}
+
+	s.replayBPF = nil
+	if s.bpfFilter != "" {
+		bpf, err := pcap.NewBPF(reader.LinkType(), SnapshotLen, s.bpfFilter)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("invalid BPF filter %q: %w", s.bpfFilter, err)
+		}
+		s.replayBPF = bpf
+	}
+
+	stop := make(chan struct{})
+	s.mu.Lock()
+	s.running = true
+	s.replayStop = stop
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.replayFile(f, reader, opts, stop)
+
+	// Start online status checker
+	s.startOnlineChecker()
+
+	return nil
+}
+
+// Wait blocks until a file replay has finished, or until Stop for live capture
+func (s *Capture) Wait() {
+	s.wg.Wait()
+}
+
+// replayFile passes the packets of a pcap file to processPacket until the file ends
+// (or, when looping, until a pass reads no packets) or stop is closed
+func (s *Capture) replayFile(f *os.File, reader *pcapgo.Reader, opts ReplayOptions, stop <-chan struct{}) {
+	defer s.wg.Done()
+	defer f.Close()
+
+	for {
+		packets, ok := s.replayPass(reader, opts, stop)
+		if !ok || !opts.Loop || packets == 0 {
+			return
+		}
+
+		// Start over from the file header
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		var err error
+		if reader, err = pcapgo.NewReader(f); err != nil {
+			return
+		}
+	}
+}
+
+// replayPass replays the packets of reader once, returning the number of packets read
+// and false if the replay was stopped
+func (s *Capture) replayPass(reader *pcapgo.Reader, opts ReplayOptions, stop <-chan struct{}) (int, bool) {
+	var first time.Time
+	start := time.Now()
+	packets := 0
+
+	source := gopacket.NewPacketSource(reader, reader.LinkType())
+	for {
+		// Read errors (including io.EOF) end the pass
+		packet, err := source.NextPacket()
+		if err != nil {
+			return packets, true
+		}
+		packets++
+
+		// Wait until the packet's offset from the first one (scaled by the speed) has elapsed
+		if opts.RealTiming {
+			timestamp := packet.Metadata().Timestamp
+			if first.IsZero() {
+				first = timestamp
+			}
+			offset := time.Duration(float64(timestamp.Sub(first)) / opts.Speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-stop:
+					return packets, false
+				}
+			}
+		}
+
+		s.mu.Lock()
+		running := s.running
+		s.mu.Unlock()
+		if !running {
+			return packets, false
+		}
+
+		if s.matchesFilter(packet) {
+			s.processPacket(packet, replayDevice)
+		}
+	}
+}
+
+// matchesFilter applies the capture filter in software, for sources without BPF
+func (s *Capture) matchesFilter(packet gopacket.Packet) bool {
+	if s.replayBPF != nil {
+		return s.replayBPF.Matches(packet.Metadata().CaptureInfo, packet.Data())
+	}
+	if udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+		return s.isCapturedPort(uint16(udp.SrcPort)) || s.isCapturedPort(uint16(udp.DstPort))
+	}
+	if tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+		return s.chat != nil && (tcp.SrcPort == PortChat || tcp.DstPort == PortChat)
+	}
+	return false
+}
+
+// isCapturedPort reports whether UDP traffic on port is captured
+func (s *Capture) isCapturedPort(port uint16) bool {
+	return port == PortMaster || port == PortGame || slices.Contains(s.extraPorts, port)
+}
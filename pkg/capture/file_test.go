@@ -0,0 +1,299 @@
+package capture
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// replayPacket is a UDP packet written to a test pcap file
+type replayPacket struct {
+	port    uint16
+	offset  time.Duration
+	payload string
+}
+
+// writeTestPcap writes UDP packets from the given source ports to a pcap file
+func writeTestPcap(t *testing.T, packets []replayPacket) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "capture.pcap")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := pcapgo.NewWriter(f)
+	if err := w.WriteFileHeader(SnapshotLen, layers.LinkTypeEthernet); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, p := range packets {
+		ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: net.IP{5, 188, 125, 10}, DstIP: net.IP{192, 168, 1, 10}}
+		udp := &layers.UDP{SrcPort: layers.UDPPort(p.port), DstPort: 50000}
+		if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+			t.Fatal(err)
+		}
+
+		buf := gopacket.NewSerializeBuffer()
+		opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+		eth := &layers.Ethernet{SrcMAC: net.HardwareAddr{0, 1, 2, 3, 4, 5}, DstMAC: net.HardwareAddr{0, 1, 2, 3, 4, 6}, EthernetType: layers.EthernetTypeIPv4}
+		if err := gopacket.SerializeLayers(buf, opts, eth, ip, udp, gopacket.Payload(p.payload)); err != nil {
+			t.Fatal(err)
+		}
+
+		ci := gopacket.CaptureInfo{Timestamp: start.Add(p.offset), CaptureLength: len(buf.Bytes()), Length: len(buf.Bytes())}
+		if err := w.WritePacket(ci, buf.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+// newReplayCapture creates a capture that records the payloads it receives
+func newReplayCapture() (*Capture, func() []string) {
+	var mu sync.Mutex
+	var payloads []string
+	c := NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
+		mu.Lock()
+		payloads = append(payloads, string(payload))
+		mu.Unlock()
+	})
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), payloads...)
+	}
+}
+
+// TestStartFromFile tests that a file is replayed through the capture filter
+func TestStartFromFile(t *testing.T) {
+	path := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "game"},
+		{80, 10 * time.Millisecond, "web"},
+		{PortMaster, 20 * time.Millisecond, "master"},
+	})
+
+	c, payloads := newReplayCapture()
+	var online bool
+	c.OnlineCallback = func(o bool) { online = o }
+
+	if err := c.StartFromFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+
+	got := payloads()
+	if len(got) != 2 || got[0] != "game" || got[1] != "master" {
+		t.Errorf("expected [game master], got %v", got)
+	}
+	if !online {
+		t.Error("expected online callback on the first packet")
+	}
+}
+
+// TestStartFromFileCustomFilter tests that a custom BPF filter replaces the default ports
+func TestStartFromFileCustomFilter(t *testing.T) {
+	if err := ValidateFilter(BPFFilter); err != nil {
+		t.Skipf("BPF compiler unavailable: %v", err)
+	}
+
+	path := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "game"},
+		{80, 10 * time.Millisecond, "web"},
+		{PortMaster, 20 * time.Millisecond, "master"},
+	})
+
+	c, payloads := newReplayCapture()
+	c.SetBPFFilter("udp and src port 80")
+	if err := c.StartFromFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+
+	if got := payloads(); len(got) != 1 || got[0] != "web" {
+		t.Errorf("expected [web], got %v", got)
+	}
+
+	c, _ = newReplayCapture()
+	c.SetBPFFilter("udp and port")
+	if err := c.StartFromFile(path); err == nil {
+		t.Error("expected error for an invalid filter")
+	}
+}
+
+// TestStartFromFileMissing tests that a missing file is reported
+func TestStartFromFileMissing(t *testing.T) {
+	c, _ := newReplayCapture()
+	if err := c.StartFromFile(filepath.Join(t.TempDir(), "missing.pcap")); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestStartFromFilePaced tests that a paced replay keeps the recorded timing and can be stopped
+func TestStartFromFilePaced(t *testing.T) {
+	path := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "first"},
+		{PortGame, 50 * time.Millisecond, "second"},
+		{PortGame, time.Hour, "late"},
+	})
+
+	c, payloads := newReplayCapture()
+	c.SetReplayPaced(true)
+
+	start := time.Now()
+	if err := c.StartFromFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(payloads()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the second packet after 50ms, got it after %v", elapsed)
+	}
+
+	// Stop doesn't wait for the packet an hour later
+	c.Stop()
+
+	got := payloads()
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("expected [first second], got %v", got)
+	}
+}
+
+// TestStartFromFileSpeed tests that the speed scales the recorded timing
+func TestStartFromFileSpeed(t *testing.T) {
+	path := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "first"},
+		{PortGame, 400 * time.Millisecond, "second"},
+	})
+
+	c, payloads := newReplayCapture()
+	start := time.Now()
+	if err := c.StartFromFileWithOptions(path, ReplayOptions{Speed: 4, RealTiming: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+
+	elapsed := time.Since(start)
+	if elapsed < 100*time.Millisecond || elapsed >= 400*time.Millisecond {
+		t.Errorf("expected the replay to take about 100ms at 4x, took %v", elapsed)
+	}
+	if got := payloads(); len(got) != 2 {
+		t.Errorf("expected 2 packets, got %v", got)
+	}
+
+	if err := c.StartFromFileWithOptions(path, ReplayOptions{Speed: -1}); err == nil {
+		t.Error("expected error for a negative speed")
+	}
+}
+
+// TestStartFromFileLoop tests that a looping replay restarts at the end of the file until Stop
+func TestStartFromFileLoop(t *testing.T) {
+	path := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "game"},
+		{80, 10 * time.Millisecond, "web"},
+		{PortMaster, 20 * time.Millisecond, "master"},
+	})
+
+	c, payloads := newReplayCapture()
+	if err := c.StartFromFileWithOptions(path, ReplayOptions{Loop: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(payloads()) < 6 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Stop ends the loop (the test would hang otherwise)
+	c.Stop()
+
+	got := payloads()
+	if len(got) < 6 {
+		t.Fatalf("expected at least 3 passes, got %v", got)
+	}
+	for i, payload := range got[:6] {
+		if want := []string{"game", "master"}[i%2]; payload != want {
+			t.Errorf("packet %d: expected %q, got %q", i, want, payload)
+		}
+	}
+}
+
+// TestStartFromFileLoopEmpty tests that looping over a file without packets ends
+func TestStartFromFileLoopEmpty(t *testing.T) {
+	path := writeTestPcap(t, nil)
+
+	c, _ := newReplayCapture()
+	if err := c.StartFromFileWithOptions(path, ReplayOptions{Loop: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+}
+
+// TestPcapDump tests that matched packets are dumped to a replayable pcap file
+func TestPcapDump(t *testing.T) {
+	source := writeTestPcap(t, []replayPacket{
+		{PortGame, 0, "game"},
+		{80, 10 * time.Millisecond, "web"},
+		{PortMaster, 20 * time.Millisecond, "master"},
+	})
+	dump := filepath.Join(t.TempDir(), "dump.pcap")
+
+	c, _ := newReplayCapture()
+	if err := c.SetPcapDump(dump); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.StartFromFile(source); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+
+	// Replaying the dump yields the same matched packets
+	replay, payloads := newReplayCapture()
+	if err := replay.StartFromFile(dump); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replay.Wait()
+	replay.Stop()
+
+	got := payloads()
+	if len(got) != 2 || got[0] != "game" || got[1] != "master" {
+		t.Errorf("expected [game master], got %v", got)
+	}
+}
+
+// TestPcapDumpCreateError tests that an uncreatable dump file is reported and capture still works
+func TestPcapDumpCreateError(t *testing.T) {
+	source := writeTestPcap(t, []replayPacket{{PortGame, 0, "game"}})
+
+	c, payloads := newReplayCapture()
+	if err := c.SetPcapDump(filepath.Join(t.TempDir(), "missing", "dump.pcap")); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if err := c.StartFromFile(source); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Wait()
+	c.Stop()
+
+	if got := payloads(); len(got) != 1 {
+		t.Errorf("expected 1 packet, got %v", got)
+	}
+}
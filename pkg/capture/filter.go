@@ -0,0 +1,42 @@
+package capture
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// BuildFilter returns the BPF filter for Albion Online traffic on the default UDP ports
+// plus extraPorts, optionally including the TCP chat server. Extra ports that are
+// zero, duplicated or already part of the defaults are ignored, so without extra
+// ports the result is BPFFilter or BPFFilterWithChat.
+func BuildFilter(chat bool, extraPorts []uint16) string {
+	ports := []uint16{PortMaster, PortGame}
+	for _, port := range extraPorts {
+		if port != 0 && !slices.Contains(ports, port) {
+			ports = append(ports, port)
+		}
+	}
+
+	terms := make([]string, len(ports))
+	for i, port := range ports {
+		terms[i] = fmt.Sprintf("port %d", port)
+	}
+	filter := fmt.Sprintf("udp and (%s)", strings.Join(terms, " or "))
+
+	if chat {
+		filter = fmt.Sprintf("(%s) or (tcp and port %d)", filter, PortChat)
+	}
+	return filter
+}
+
+// ValidateFilter checks that a BPF filter expression compiles
+func ValidateFilter(filter string) error {
+	if _, err := pcap.CompileBPFFilter(layers.LinkTypeEthernet, SnapshotLen, filter); err != nil {
+		return fmt.Errorf("invalid BPF filter %q: %w", filter, err)
+	}
+	return nil
+}
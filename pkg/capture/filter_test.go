@@ -0,0 +1,57 @@
+package capture
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBuildFilterDefaults tests that the default filters are unchanged without extra ports
+func TestBuildFilterDefaults(t *testing.T) {
+	if got := BuildFilter(false, nil); got != BPFFilter {
+		t.Errorf("expected %q, got %q", BPFFilter, got)
+	}
+	if got := BuildFilter(true, nil); got != BPFFilterWithChat {
+		t.Errorf("expected %q, got %q", BPFFilterWithChat, got)
+	}
+	// Zero, duplicated and default ports are ignored
+	if got := BuildFilter(false, []uint16{0, PortGame, PortMaster}); got != BPFFilter {
+		t.Errorf("expected %q, got %q", BPFFilter, got)
+	}
+}
+
+// TestBuildFilterExtraPorts tests that extra ports are added to the UDP port list
+func TestBuildFilterExtraPorts(t *testing.T) {
+	expected := "udp and (port 5055 or port 5056 or port 5057 or port 6000)"
+	if got := BuildFilter(false, []uint16{5057, 6000, 5057}); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	expected = "(udp and (port 5055 or port 5056 or port 5057)) or (tcp and port 4535)"
+	if got := BuildFilter(true, []uint16{5057}); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	c := NewCapture(nil)
+	c.SetExtraPorts([]uint16{5057})
+	if !strings.Contains(c.filter(), "port 5057") {
+		t.Errorf("expected capture filter to include port 5057, got %q", c.filter())
+	}
+}
+
+// TestValidateFilter tests that generated filters compile
+func TestValidateFilter(t *testing.T) {
+	if err := ValidateFilter(BPFFilter); err != nil {
+		t.Skipf("BPF compiler unavailable: %v", err)
+	}
+
+	for _, chat := range []bool{false, true} {
+		filter := BuildFilter(chat, []uint16{5057, 6000})
+		if err := ValidateFilter(filter); err != nil {
+			t.Errorf("expected %q to compile: %v", filter, err)
+		}
+	}
+
+	if err := ValidateFilter("udp and port"); err == nil {
+		t.Error("expected an error for an invalid filter")
+	}
+}
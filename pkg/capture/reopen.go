@@ -0,0 +1,160 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// maxReadErrors is how many read errors in a row make a handle considered dead
+const maxReadErrors = 10
+
+// Delays between attempts to reopen a failed device, doubling up to the maximum
+var (
+	reopenMinDelay = time.Second
+	reopenMaxDelay = 30 * time.Second
+)
+
+// liveHandle is the part of *pcap.Handle used by live capture
+type liveHandle interface {
+	gopacket.PacketDataSource
+	LinkType() layers.LinkType
+	Stats() (*pcap.Stats, error)
+	Close()
+}
+
+// open opens a device for live capture
+func (s *Capture) open(deviceName string) (liveHandle, error) {
+	if s.openHandle != nil {
+		return s.openHandle(deviceName)
+	}
+	handle, err := s.openDevice(deviceName)
+	if err != nil {
+		return nil, err
+	}
+	return handle, nil
+}
+
+// capturePackets reads packets from handle until Stop. If the handle fails
+// (e.g. the adapter was reset or the system resumed from sleep), it is closed and
+// the device reopened with backoff, reporting each failure to OnDeviceError.
+func (s *Capture) capturePackets(handle liveHandle, deviceName string) {
+	for {
+		err := s.readPackets(handle, deviceName)
+		s.removeHandle(handle)
+		handle.Close()
+		if err == nil {
+			return
+		}
+
+		s.deviceError(deviceName, err)
+		if handle = s.reopenDevice(deviceName); handle == nil {
+			return
+		}
+		if s.OnDeviceReopened != nil {
+			s.OnDeviceReopened(deviceName)
+		}
+	}
+}
+
+// readPackets passes the packets captured by handle to processPacket. It returns
+// nil once the capture is stopped, or the error that made the handle unusable.
+func (s *Capture) readPackets(handle liveHandle, deviceName string) error {
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	errorsInRow := 0
+	for {
+		packet, err := packetSource.NextPacket()
+		if !s.isRunning() {
+			return nil
+		}
+		if err == nil {
+			errorsInRow = 0
+			s.processPacket(packet, deviceName)
+			continue
+		}
+
+		if isTransientReadError(err) {
+			continue
+		}
+		// A live handle only ends when closed, which Stop does
+		if isClosedReadError(err) {
+			return fmt.Errorf("capture ended: %w", err)
+		}
+		if errorsInRow++; errorsInRow >= maxReadErrors {
+			return err
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// isTransientReadError reports whether a read can be retried right away
+func isTransientReadError(err error) bool {
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.EAGAIN) || errors.Is(err, pcap.NextErrorTimeoutExpired)
+}
+
+// isClosedReadError reports whether a read failed because the handle is gone
+func isClosedReadError(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.EBADF)
+}
+
+// reopenDevice tries to reopen a failed device until it succeeds, waiting longer
+// after each failure. It returns nil if the capture is stopped first.
+func (s *Capture) reopenDevice(deviceName string) liveHandle {
+	delay := reopenMinDelay
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-s.stop:
+			return nil
+		case <-time.After(delay):
+		}
+
+		handle, err := s.open(deviceName)
+		if err == nil {
+			s.mu.Lock()
+			if !s.running {
+				s.mu.Unlock()
+				handle.Close()
+				return nil
+			}
+			s.handles = append(s.handles, handle)
+			s.mu.Unlock()
+			return handle
+		}
+
+		s.deviceError(deviceName, fmt.Errorf("reopen attempt %d failed: %w", attempt, err))
+		delay = min(delay*2, reopenMaxDelay)
+	}
+}
+
+// removeHandle forgets a handle, so Stop and KernelStats no longer use it
+func (s *Capture) removeHandle(handle liveHandle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handles = slices.DeleteFunc(s.handles, func(h liveHandle) bool { return h == handle })
+}
+
+// deviceError reports a device failure to OnDeviceError
+func (s *Capture) deviceError(deviceName string, err error) {
+	if s.OnDeviceError != nil {
+		s.OnDeviceError(deviceName, err)
+	}
+}
+
+// isRunning returns whether the capture has not been stopped
+func (s *Capture) isRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
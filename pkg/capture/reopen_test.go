@@ -0,0 +1,183 @@
+package capture
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// fakeHandle is a live handle returning the given packets, then failing with err,
+// or blocking until closed if err is nil
+type fakeHandle struct {
+	packets [][]byte
+	err     error
+
+	mu     sync.Mutex
+	closed chan struct{}
+}
+
+func newFakeHandle(err error, packets ...[]byte) *fakeHandle {
+	return &fakeHandle{packets: packets, err: err, closed: make(chan struct{})}
+}
+
+func (h *fakeHandle) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	h.mu.Lock()
+	if len(h.packets) > 0 {
+		data := h.packets[0]
+		h.packets = h.packets[1:]
+		h.mu.Unlock()
+		return data, gopacket.CaptureInfo{Timestamp: time.Now(), CaptureLength: len(data), Length: len(data)}, nil
+	}
+	h.mu.Unlock()
+
+	if h.err != nil {
+		return nil, gopacket.CaptureInfo{}, h.err
+	}
+	<-h.closed
+	return nil, gopacket.CaptureInfo{}, io.EOF
+}
+
+func (h *fakeHandle) LinkType() layers.LinkType { return layers.LinkTypeEthernet }
+
+func (h *fakeHandle) Stats() (*pcap.Stats, error) { return &pcap.Stats{}, nil }
+
+func (h *fakeHandle) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	select {
+	case <-h.closed:
+	default:
+		close(h.closed)
+	}
+}
+
+func (h *fakeHandle) isClosed() bool {
+	select {
+	case <-h.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// setReopenDelays shortens the reopen backoff for a test
+func setReopenDelays(t *testing.T, minDelay, maxDelay time.Duration) {
+	oldMin, oldMax := reopenMinDelay, reopenMaxDelay
+	reopenMinDelay, reopenMaxDelay = minDelay, maxDelay
+	t.Cleanup(func() { reopenMinDelay, reopenMaxDelay = oldMin, oldMax })
+}
+
+// startFakeCapture captures on a fake handle as StartOnDevices would
+func startFakeCapture(c *Capture, handle liveHandle, deviceName string) {
+	c.mu.Lock()
+	c.running = true
+	c.handles = append(c.handles, handle)
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.capturePackets(handle, deviceName)
+	}()
+}
+
+// TestReopenAfterReadErrors tests that a failing device is closed and reopened,
+// with the failures reported, and that capture resumes on the new handle
+func TestReopenAfterReadErrors(t *testing.T) {
+	setReopenDelays(t, time.Millisecond, 5*time.Millisecond)
+
+	var mu sync.Mutex
+	var payloads []string
+	var failures []error
+	var reopened []string
+	c := NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
+		mu.Lock()
+		payloads = append(payloads, string(payload))
+		mu.Unlock()
+	})
+	c.OnDeviceError = func(device string, err error) {
+		mu.Lock()
+		failures = append(failures, err)
+		mu.Unlock()
+	}
+	c.OnDeviceReopened = func(device string) {
+		mu.Lock()
+		reopened = append(reopened, device)
+		mu.Unlock()
+	}
+
+	first := newFakeHandle(pcap.NextErrorReadError, buildUDPv6Packet(t, []byte("before")).Data())
+	second := newFakeHandle(nil, buildUDPv6Packet(t, []byte("after")).Data())
+	opens := 0
+	c.openHandle = func(deviceName string) (liveHandle, error) {
+		if opens++; opens == 1 {
+			return nil, errors.New("no such device")
+		}
+		return second, nil
+	}
+
+	startFakeCapture(c, first, "eth0")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		done := len(payloads) == 2
+		mu.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(payloads) != 2 || payloads[0] != "before" || payloads[1] != "after" {
+		t.Fatalf("expected payloads before and after the reopen, got %q", payloads)
+	}
+	if len(failures) != 2 || !errors.Is(failures[0], pcap.NextErrorReadError) {
+		t.Errorf("expected the read error and 1 failed reopen to be reported, got %v", failures)
+	}
+	if len(reopened) != 1 || reopened[0] != "eth0" {
+		t.Errorf("expected eth0 to be reopened once, got %v", reopened)
+	}
+	if !first.isClosed() || !second.isClosed() {
+		t.Error("expected both handles to be closed")
+	}
+}
+
+// TestStopDuringReopen tests that Stop doesn't wait for the reopen backoff
+func TestStopDuringReopen(t *testing.T) {
+	setReopenDelays(t, time.Hour, time.Hour)
+
+	failed := make(chan struct{}, 1)
+	c := NewCapture(func([]byte, net.IP, net.IP, uint16, uint16) {})
+	c.OnDeviceError = func(device string, err error) {
+		failed <- struct{}{}
+	}
+	c.openHandle = func(deviceName string) (liveHandle, error) {
+		t.Error("expected no reopen attempt before Stop")
+		return nil, errors.New("no such device")
+	}
+
+	startFakeCapture(c, newFakeHandle(io.EOF), "eth0")
+	<-failed
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Stop to end the reopen backoff")
+	}
+}
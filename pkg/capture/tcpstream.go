@@ -0,0 +1,167 @@
+package capture
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/tcpassembly"
+)
+
+const (
+	// Photon TCP framing
+	tcpFrameMagic        = 0xFB    // Message frame header
+	tcpPingMagic         = 0xF0    // Ping frame header
+	tcpFrameHeaderLength = 7       // magic(1) + length(4) + channel(1) + reliable(1)
+	tcpPingLength        = 9       // magic(1) + timestamps(8)
+	tcpMaxFrameLength    = 1 << 20 // Frames larger than this are treated as garbage
+
+	// Stream assembly settings
+	chatFlushInterval = 1 * time.Second  // How often stalled streams are flushed
+	chatStreamTimeout = 2 * time.Second  // Streams waiting on missing data longer than this are flushed
+	chatIdleTimeout   = 10 * time.Minute // Idle connections are closed after this
+)
+
+// ChatHandler is a callback for Photon messages reassembled from the TCP chat stream.
+// The message starts at the signal byte, as in a UDP reliable command payload.
+type ChatHandler func(message []byte)
+
+// chatAssembler reassembles TCP chat traffic into Photon messages.
+// The tcpassembly.Assembler is not safe for concurrent use, so calls are serialized.
+type chatAssembler struct {
+	mu        sync.Mutex
+	assembler *tcpassembly.Assembler
+	lastFlush time.Time
+}
+
+// newChatAssembler creates a chat assembler that delivers messages to handler
+func newChatAssembler(handler ChatHandler) *chatAssembler {
+	pool := tcpassembly.NewStreamPool(&chatStreamFactory{handler: handler})
+	return &chatAssembler{
+		assembler: tcpassembly.NewAssembler(pool),
+	}
+}
+
+// assemble feeds a TCP segment into the assembler
+func (c *chatAssembler) assemble(netFlow gopacket.Flow, tcp *layers.TCP, timestamp time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.assembler.AssembleWithTimestamp(netFlow, tcp, timestamp)
+
+	// Periodically flush streams stuck waiting for missing segments, so a lost
+	// segment (or a capture started mid-connection) doesn't stall the stream forever
+	if timestamp.Sub(c.lastFlush) >= chatFlushInterval {
+		c.assembler.FlushWithOptions(tcpassembly.FlushOptions{T: timestamp.Add(-chatStreamTimeout)})
+		c.assembler.FlushOlderThan(timestamp.Add(-chatIdleTimeout))
+		c.lastFlush = timestamp
+	}
+}
+
+// flushAll delivers any buffered data and closes all streams
+func (c *chatAssembler) flushAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.assembler.FlushAll()
+}
+
+// chatStreamFactory creates a chatStream for each new TCP connection
+type chatStreamFactory struct {
+	handler ChatHandler
+}
+
+// New implements tcpassembly.StreamFactory
+func (f *chatStreamFactory) New(netFlow, tcpFlow gopacket.Flow) tcpassembly.Stream {
+	return &chatStream{framer: photonTCPFramer{handler: f.handler}}
+}
+
+// chatStream receives reassembled data for one direction of a TCP connection
+type chatStream struct {
+	framer photonTCPFramer
+}
+
+// Reassembled implements tcpassembly.Stream
+func (s *chatStream) Reassembled(reassemblies []tcpassembly.Reassembly) {
+	for _, r := range reassemblies {
+		// Bytes were lost (or the stream start was never seen): any partial
+		// frame is now corrupt, so drop it and resync on the next frame header
+		if r.Skip != 0 {
+			s.framer.reset()
+		}
+		s.framer.write(r.Bytes)
+	}
+}
+
+// ReassemblyComplete implements tcpassembly.Stream (called on FIN, RST or timeout)
+func (s *chatStream) ReassemblyComplete() {
+	s.framer.reset()
+}
+
+// photonTCPFramer splits a Photon TCP byte stream into messages
+type photonTCPFramer struct {
+	buf     []byte
+	handler ChatHandler
+}
+
+// write appends stream data and delivers every complete frame
+func (f *photonTCPFramer) write(data []byte) {
+	f.buf = append(f.buf, data...)
+
+	for len(f.buf) > 0 {
+		switch f.buf[0] {
+		case tcpPingMagic:
+			if len(f.buf) < tcpPingLength {
+				return
+			}
+			f.buf = f.buf[tcpPingLength:]
+
+		case tcpFrameMagic:
+			if len(f.buf) < tcpFrameHeaderLength {
+				return
+			}
+			length := int(binary.BigEndian.Uint32(f.buf[1:5]))
+			if length < tcpFrameHeaderLength || length > tcpMaxFrameLength {
+				f.resync()
+				continue
+			}
+			if len(f.buf) < length {
+				return
+			}
+
+			message := make([]byte, length-tcpFrameHeaderLength)
+			copy(message, f.buf[tcpFrameHeaderLength:length])
+			f.buf = f.buf[length:]
+
+			if f.handler != nil && len(message) > 0 {
+				f.handler(message)
+			}
+
+		default:
+			f.resync()
+		}
+	}
+
+	// Release the backing array once fully consumed
+	if len(f.buf) == 0 {
+		f.buf = nil
+	}
+}
+
+// resync drops bytes until the next possible frame header
+func (f *photonTCPFramer) resync() {
+	for i := 1; i < len(f.buf); i++ {
+		if f.buf[i] == tcpFrameMagic || f.buf[i] == tcpPingMagic {
+			f.buf = f.buf[i:]
+			return
+		}
+	}
+	f.buf = nil
+}
+
+// reset discards any partially received frame
+func (f *photonTCPFramer) reset() {
+	f.buf = nil
+}
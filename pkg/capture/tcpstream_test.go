@@ -0,0 +1,236 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/tcpassembly"
+)
+
+// buildFrame builds a Photon TCP frame carrying message
+func buildFrame(message []byte) []byte {
+	frame := make([]byte, tcpFrameHeaderLength, tcpFrameHeaderLength+len(message))
+	frame[0] = tcpFrameMagic
+	binary.BigEndian.PutUint32(frame[1:5], uint32(tcpFrameHeaderLength+len(message)))
+	return append(frame, message...)
+}
+
+// segment describes a synthetic TCP segment on the chat port
+type segment struct {
+	seq     uint32
+	syn     bool
+	rst     bool
+	payload []byte
+	ts      time.Time
+}
+
+// buildTCPPacket builds a decoded Ethernet/IPv4/TCP packet for a segment
+func buildTCPPacket(t *testing.T, seg segment) gopacket.Packet {
+	t.Helper()
+
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 1},
+		DstMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 2},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    net.IPv4(10, 0, 0, 1),
+		DstIP:    net.IPv4(10, 0, 0, 2),
+	}
+	tcp := &layers.TCP{
+		SrcPort: PortChat,
+		DstPort: 50000,
+		Seq:     seg.seq,
+		SYN:     seg.syn,
+		RST:     seg.rst,
+		ACK:     true,
+		Window:  65535,
+	}
+	_ = tcp.SetNetworkLayerForChecksum(ip)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, eth, ip, tcp, gopacket.Payload(seg.payload)); err != nil {
+		t.Fatalf("failed to serialize packet: %v", err)
+	}
+
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeEthernet, gopacket.Default)
+	packet.Metadata().Timestamp = seg.ts
+	return packet
+}
+
+// newChatTestCapture creates a Capture with chat enabled that records messages
+func newChatTestCapture() (*Capture, *[][]byte) {
+	var messages [][]byte
+	c := NewCapture(nil)
+	c.EnableChatCapture(func(message []byte) {
+		messages = append(messages, message)
+	})
+	return c, &messages
+}
+
+// TestEnableChatCaptureFilter tests that chat capture extends the BPF filter
+func TestEnableChatCaptureFilter(t *testing.T) {
+	c := NewCapture(nil)
+	if c.filter() != BPFFilter {
+		t.Errorf("expected default filter %q, got %q", BPFFilter, c.filter())
+	}
+
+	c.EnableChatCapture(func(message []byte) {})
+	if c.filter() != BPFFilterWithChat {
+		t.Errorf("expected chat filter %q, got %q", BPFFilterWithChat, c.filter())
+	}
+}
+
+// TestChatStreamSplitFrames tests frames split across and packed into segments
+func TestChatStreamSplitFrames(t *testing.T) {
+	c, messages := newChatTestCapture()
+	now := time.Now()
+
+	msg1 := []byte{243, 4, 1, 0, 0}
+	msg2 := []byte{243, 4, 2, 0, 0}
+	stream := append(buildFrame(msg1), buildFrame(msg2)...)
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1000, syn: true, ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1001, payload: stream[:4], ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1005, payload: stream[4:15], ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1016, payload: stream[15:], ts: now}), "eth0")
+
+	if len(*messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(*messages))
+	}
+	if !bytes.Equal((*messages)[0], msg1) || !bytes.Equal((*messages)[1], msg2) {
+		t.Errorf("unexpected messages: %v", *messages)
+	}
+}
+
+// TestChatStreamOutOfOrder tests that out-of-order segments are reordered
+func TestChatStreamOutOfOrder(t *testing.T) {
+	c, messages := newChatTestCapture()
+	now := time.Now()
+
+	msg := []byte{243, 4, 7, 0, 0}
+	frame := buildFrame(msg)
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1000, syn: true, ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1006, payload: frame[5:], ts: now}), "eth0")
+	if len(*messages) != 0 {
+		t.Fatalf("expected no messages before missing segment arrives, got %d", len(*messages))
+	}
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1001, payload: frame[:5], ts: now}), "eth0")
+	if len(*messages) != 1 || !bytes.Equal((*messages)[0], msg) {
+		t.Errorf("expected reassembled message %v, got %v", msg, *messages)
+	}
+}
+
+// TestChatStreamGap tests that a lost segment drops the partial frame and
+// the stream resyncs on the next frame
+func TestChatStreamGap(t *testing.T) {
+	c, messages := newChatTestCapture()
+	start := time.Now()
+
+	lost := buildFrame([]byte{243, 4, 1, 0, 0, 0, 0, 0})
+	next := []byte{243, 4, 2, 0, 0}
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1000, syn: true, ts: start}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1001, payload: lost[:6], ts: start}), "eth0")
+
+	// Segment with the rest of the first frame is lost; next frame arrives later
+	seq := uint32(1001 + len(lost))
+	c.processPacket(buildTCPPacket(t, segment{seq: seq, payload: buildFrame(next), ts: start}), "eth0")
+
+	// A later segment triggers the periodic flush of the stalled stream
+	later := start.Add(chatStreamTimeout + chatFlushInterval)
+	c.processPacket(buildTCPPacket(t, segment{seq: seq + uint32(len(buildFrame(next))), payload: []byte{tcpPingMagic, 0, 0, 0, 0, 0, 0, 0, 0}, ts: later}), "eth0")
+
+	if len(*messages) != 1 || !bytes.Equal((*messages)[0], next) {
+		t.Errorf("expected only the frame after the gap %v, got %v", next, *messages)
+	}
+}
+
+// TestChatStreamReset tests that a connection reset discards partial data
+func TestChatStreamReset(t *testing.T) {
+	stream := &chatStream{}
+	var messages [][]byte
+	stream.framer.handler = func(message []byte) {
+		messages = append(messages, message)
+	}
+
+	frame := buildFrame([]byte{243, 4, 1, 0, 0})
+	stream.Reassembled([]tcpassembly.Reassembly{{Bytes: frame[:6]}})
+	stream.ReassemblyComplete()
+	stream.Reassembled([]tcpassembly.Reassembly{{Bytes: frame[6:]}})
+
+	if len(messages) != 0 {
+		t.Errorf("expected no messages after reset, got %d", len(messages))
+	}
+	if stream.framer.buf != nil {
+		t.Errorf("expected empty buffer, got %v", stream.framer.buf)
+	}
+}
+
+// TestChatStreamRSTPacket tests that an RST segment completes the stream
+func TestChatStreamRSTPacket(t *testing.T) {
+	c, messages := newChatTestCapture()
+	now := time.Now()
+
+	frame := buildFrame([]byte{243, 4, 1, 0, 0})
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1000, syn: true, ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1001, payload: frame[:6], ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 1007, rst: true, ts: now}), "eth0")
+
+	// New connection on the same ports starts clean
+	c.processPacket(buildTCPPacket(t, segment{seq: 5000, syn: true, ts: now}), "eth0")
+	c.processPacket(buildTCPPacket(t, segment{seq: 5001, payload: frame, ts: now}), "eth0")
+
+	if len(*messages) != 1 {
+		t.Errorf("expected 1 message from the new connection, got %d", len(*messages))
+	}
+}
+
+// TestPhotonTCPFramerResync tests resync on garbage and ping frames
+func TestPhotonTCPFramerResync(t *testing.T) {
+	var messages [][]byte
+	f := photonTCPFramer{handler: func(message []byte) {
+		messages = append(messages, message)
+	}}
+
+	msg := []byte{243, 4, 9, 0, 0}
+	data := []byte{0x01, 0x02}                                       // garbage
+	data = append(data, tcpPingMagic, 0, 0, 0, 0, 0, 0, 0, 0)        // ping
+	data = append(data, tcpFrameMagic, 0, 0, 0, 1, 0, 0, 0x03, 0x04) // invalid length
+	data = append(data, buildFrame(msg)...)
+
+	f.write(data)
+
+	if len(messages) != 1 || !bytes.Equal(messages[0], msg) {
+		t.Errorf("expected message %v, got %v", msg, messages)
+	}
+	if f.buf != nil {
+		t.Errorf("expected empty buffer, got %v", f.buf)
+	}
+}
+
+// TestProcessPacketIgnoresTCPWithoutChat tests that TCP is ignored when chat capture is disabled
+func TestProcessPacketIgnoresTCPWithoutChat(t *testing.T) {
+	calls := 0
+	c := NewCapture(func(payload []byte, srcIP, dstIP net.IP, srcPort, dstPort uint16) {
+		calls++
+	})
+
+	c.processPacket(buildTCPPacket(t, segment{seq: 1000, payload: buildFrame([]byte{243, 4, 1, 0, 0}), ts: time.Now()}), "eth0")
+
+	if calls != 0 {
+		t.Errorf("expected TCP packet to be ignored, got %d handler calls", calls)
+	}
+}
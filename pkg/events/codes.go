@@ -7,8 +7,9 @@ package events
 
 import "fmt"
 
-// EventCode represents the event type for Albion Online network packets
-type EventCode int16
+// EventCode represents the event type for Albion Online network packets.
+// Codes are sent in parameter 252 and are not bounded by int16, so int32 is used.
+type EventCode int32
 
 // String returns the name of the event code
 func (e EventCode) String() string {
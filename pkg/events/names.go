@@ -0,0 +1,39 @@
+package events
+
+import "sync"
+
+var (
+	eventCodesByName     map[string]EventCode
+	eventCodesByNameOnce sync.Once
+
+	operationCodesByName     map[string]OperationCode
+	operationCodesByNameOnce sync.Once
+)
+
+// EventCodeByName returns the event code for the given name (e.g., "OtherGrabbedLoot").
+// The reverse lookup map is built from EventCodeNames on first use.
+func EventCodeByName(name string) (EventCode, bool) {
+	eventCodesByNameOnce.Do(func() {
+		eventCodesByName = make(map[string]EventCode, len(EventCodeNames))
+		for code, codeName := range EventCodeNames {
+			eventCodesByName[codeName] = code
+		}
+	})
+
+	code, ok := eventCodesByName[name]
+	return code, ok
+}
+
+// OperationCodeByName returns the operation code for the given name (e.g., "Move").
+// The reverse lookup map is built from OperationCodeNames on first use.
+func OperationCodeByName(name string) (OperationCode, bool) {
+	operationCodesByNameOnce.Do(func() {
+		operationCodesByName = make(map[string]OperationCode, len(OperationCodeNames))
+		for code, codeName := range OperationCodeNames {
+			operationCodesByName[codeName] = code
+		}
+	})
+
+	code, ok := operationCodesByName[name]
+	return code, ok
+}
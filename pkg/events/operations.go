@@ -0,0 +1,228 @@
+package events
+
+import "fmt"
+
+// OperationCode represents an operation (client request and its server response)
+// for Albion Online network packets. Codes are sent in parameter 253 and have
+// their own code space, separate from event codes.
+type OperationCode int32
+
+// String returns the name of the operation code
+func (o OperationCode) String() string {
+	if name, ok := OperationCodeNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown(%d)", o)
+}
+
+// Operation codes from Albion Online
+const (
+	OpUnused OperationCode = iota
+	OpPing
+	OpJoin
+	OpCreateAccount
+	OpLogin
+	OpCreateGuestAccount
+	OpSendCrashLog
+	OpSendTraceRoute
+	OpSendVfxStats
+	OpSendGamePingInfo
+	OpCreateCharacter
+	OpDeleteCharacter
+	OpSelectCharacter
+	OpAcceptPopups
+	OpRedeemKeycode
+	OpGetGameServerByCluster
+	OpGetShopPurchaseUrl
+	OpGetReferralSeasonDetails
+	OpGetReferralLink
+	OpGetShopTilesForCategory
+	OpMove
+	OpAttackStart
+	OpCastStart
+	OpCastCancel
+	OpTerminateToggleSpell
+	OpChannelingCancel
+	OpAttackBuildingStart
+	OpInventoryDestroyItem
+	OpInventoryMoveItem
+	OpInventoryRecoverItem
+	OpInventoryRecoverAllItems
+	OpInventorySplitStack
+	OpInventorySplitStackInto
+	OpGetClusterData
+	OpChangeCluster
+	OpConsoleCommand
+	OpChatMessage
+	OpReportClientError
+	OpRegisterToObject
+	OpUnRegisterFromObject
+	OpCraftBuildingChangeSettings
+	OpCraftBuildingTakeMoney
+	OpRepairBuildingChangeSettings
+	OpRepairBuildingTakeMoney
+	OpActionBuildingChangeSettings
+	OpHarvestStart
+	OpHarvestCancel
+	OpTakeSilver
+	OpActionOnBuildingStart
+	OpActionOnBuildingCancel
+	OpInstallResourceStart
+	OpInstallResourceCancel
+	OpInstallSilver
+	OpBuildingFillNutrition
+	OpBuildingChangeRenovationState
+	OpBuildingBuySkin
+	OpBuildingClaim
+	OpBuildingGiveup
+	OpBuildingNutritionSilverStorageDeposit
+	OpBuildingNutritionSilverStorageWithdraw
+	OpBuildingNutritionSilverRewardSet
+	OpConstructionSiteCreate
+	OpPlaceableObjectPlace
+	OpPlaceableObjectPlaceCancel
+	OpPlaceableObjectPickup
+	OpFurnitureObjectUse
+	OpFarmableHarvest
+	OpFarmableFinishGrownItem
+	OpFarmableDestroy
+	OpFarmableGetProduct
+	OpFarmableFill
+	OpTearDownConstructionSite
+	OpCastleGateUse
+	OpAuctionCreateOffer
+	OpAuctionCreateRequest
+	OpAuctionGetOffers
+	OpAuctionGetRequests
+	OpAuctionBuyOffer
+	OpAuctionAbortAuction
+	OpAuctionModifyAuction
+	OpAuctionAbortOffer
+	OpAuctionAbortRequest
+	OpAuctionSellRequest
+	OpAuctionGetFinishedAuctions
+	OpAuctionGetFinishedAuctionsCount
+	OpAuctionFetchAuction
+	OpAuctionGetMyOpenOffers
+	OpAuctionGetMyOpenRequests
+	OpAuctionGetMyOpenAuctions
+	OpAuctionGetItemAverageStats
+	OpAuctionGetItemAverageValue
+	OpContainerOpen
+	OpContainerClose
+	OpContainerManageSubContainer
+	OpRespawn
+	OpSuicide
+	OpJoinGuild
+	OpLeaveGuild
+	OpCreateGuild
+	OpInviteToGuild
+	OpDeclineGuildInvitation
+	OpKickFromGuild
+)
+
+// OperationCodeNames maps operation codes to their string representation
+var OperationCodeNames = map[OperationCode]string{
+	OpUnused:                                 "Unused",
+	OpPing:                                   "Ping",
+	OpJoin:                                   "Join",
+	OpCreateAccount:                          "CreateAccount",
+	OpLogin:                                  "Login",
+	OpCreateGuestAccount:                     "CreateGuestAccount",
+	OpSendCrashLog:                           "SendCrashLog",
+	OpSendTraceRoute:                         "SendTraceRoute",
+	OpSendVfxStats:                           "SendVfxStats",
+	OpSendGamePingInfo:                       "SendGamePingInfo",
+	OpCreateCharacter:                        "CreateCharacter",
+	OpDeleteCharacter:                        "DeleteCharacter",
+	OpSelectCharacter:                        "SelectCharacter",
+	OpAcceptPopups:                           "AcceptPopups",
+	OpRedeemKeycode:                          "RedeemKeycode",
+	OpGetGameServerByCluster:                 "GetGameServerByCluster",
+	OpGetShopPurchaseUrl:                     "GetShopPurchaseUrl",
+	OpGetReferralSeasonDetails:               "GetReferralSeasonDetails",
+	OpGetReferralLink:                        "GetReferralLink",
+	OpGetShopTilesForCategory:                "GetShopTilesForCategory",
+	OpMove:                                   "Move",
+	OpAttackStart:                            "AttackStart",
+	OpCastStart:                              "CastStart",
+	OpCastCancel:                             "CastCancel",
+	OpTerminateToggleSpell:                   "TerminateToggleSpell",
+	OpChannelingCancel:                       "ChannelingCancel",
+	OpAttackBuildingStart:                    "AttackBuildingStart",
+	OpInventoryDestroyItem:                   "InventoryDestroyItem",
+	OpInventoryMoveItem:                      "InventoryMoveItem",
+	OpInventoryRecoverItem:                   "InventoryRecoverItem",
+	OpInventoryRecoverAllItems:               "InventoryRecoverAllItems",
+	OpInventorySplitStack:                    "InventorySplitStack",
+	OpInventorySplitStackInto:                "InventorySplitStackInto",
+	OpGetClusterData:                         "GetClusterData",
+	OpChangeCluster:                          "ChangeCluster",
+	OpConsoleCommand:                         "ConsoleCommand",
+	OpChatMessage:                            "ChatMessage",
+	OpReportClientError:                      "ReportClientError",
+	OpRegisterToObject:                       "RegisterToObject",
+	OpUnRegisterFromObject:                   "UnRegisterFromObject",
+	OpCraftBuildingChangeSettings:            "CraftBuildingChangeSettings",
+	OpCraftBuildingTakeMoney:                 "CraftBuildingTakeMoney",
+	OpRepairBuildingChangeSettings:           "RepairBuildingChangeSettings",
+	OpRepairBuildingTakeMoney:                "RepairBuildingTakeMoney",
+	OpActionBuildingChangeSettings:           "ActionBuildingChangeSettings",
+	OpHarvestStart:                           "HarvestStart",
+	OpHarvestCancel:                          "HarvestCancel",
+	OpTakeSilver:                             "TakeSilver",
+	OpActionOnBuildingStart:                  "ActionOnBuildingStart",
+	OpActionOnBuildingCancel:                 "ActionOnBuildingCancel",
+	OpInstallResourceStart:                   "InstallResourceStart",
+	OpInstallResourceCancel:                  "InstallResourceCancel",
+	OpInstallSilver:                          "InstallSilver",
+	OpBuildingFillNutrition:                  "BuildingFillNutrition",
+	OpBuildingChangeRenovationState:          "BuildingChangeRenovationState",
+	OpBuildingBuySkin:                        "BuildingBuySkin",
+	OpBuildingClaim:                          "BuildingClaim",
+	OpBuildingGiveup:                         "BuildingGiveup",
+	OpBuildingNutritionSilverStorageDeposit:  "BuildingNutritionSilverStorageDeposit",
+	OpBuildingNutritionSilverStorageWithdraw: "BuildingNutritionSilverStorageWithdraw",
+	OpBuildingNutritionSilverRewardSet:       "BuildingNutritionSilverRewardSet",
+	OpConstructionSiteCreate:                 "ConstructionSiteCreate",
+	OpPlaceableObjectPlace:                   "PlaceableObjectPlace",
+	OpPlaceableObjectPlaceCancel:             "PlaceableObjectPlaceCancel",
+	OpPlaceableObjectPickup:                  "PlaceableObjectPickup",
+	OpFurnitureObjectUse:                     "FurnitureObjectUse",
+	OpFarmableHarvest:                        "FarmableHarvest",
+	OpFarmableFinishGrownItem:                "FarmableFinishGrownItem",
+	OpFarmableDestroy:                        "FarmableDestroy",
+	OpFarmableGetProduct:                     "FarmableGetProduct",
+	OpFarmableFill:                           "FarmableFill",
+	OpTearDownConstructionSite:               "TearDownConstructionSite",
+	OpCastleGateUse:                          "CastleGateUse",
+	OpAuctionCreateOffer:                     "AuctionCreateOffer",
+	OpAuctionCreateRequest:                   "AuctionCreateRequest",
+	OpAuctionGetOffers:                       "AuctionGetOffers",
+	OpAuctionGetRequests:                     "AuctionGetRequests",
+	OpAuctionBuyOffer:                        "AuctionBuyOffer",
+	OpAuctionAbortAuction:                    "AuctionAbortAuction",
+	OpAuctionModifyAuction:                   "AuctionModifyAuction",
+	OpAuctionAbortOffer:                      "AuctionAbortOffer",
+	OpAuctionAbortRequest:                    "AuctionAbortRequest",
+	OpAuctionSellRequest:                     "AuctionSellRequest",
+	OpAuctionGetFinishedAuctions:             "AuctionGetFinishedAuctions",
+	OpAuctionGetFinishedAuctionsCount:        "AuctionGetFinishedAuctionsCount",
+	OpAuctionFetchAuction:                    "AuctionFetchAuction",
+	OpAuctionGetMyOpenOffers:                 "AuctionGetMyOpenOffers",
+	OpAuctionGetMyOpenRequests:               "AuctionGetMyOpenRequests",
+	OpAuctionGetMyOpenAuctions:               "AuctionGetMyOpenAuctions",
+	OpAuctionGetItemAverageStats:             "AuctionGetItemAverageStats",
+	OpAuctionGetItemAverageValue:             "AuctionGetItemAverageValue",
+	OpContainerOpen:                          "ContainerOpen",
+	OpContainerClose:                         "ContainerClose",
+	OpContainerManageSubContainer:            "ContainerManageSubContainer",
+	OpRespawn:                                "Respawn",
+	OpSuicide:                                "Suicide",
+	OpJoinGuild:                              "JoinGuild",
+	OpLeaveGuild:                             "LeaveGuild",
+	OpCreateGuild:                            "CreateGuild",
+	OpInviteToGuild:                          "InviteToGuild",
+	OpDeclineGuildInvitation:                 "DeclineGuildInvitation",
+	OpKickFromGuild:                          "KickFromGuild",
+}
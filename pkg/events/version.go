@@ -0,0 +1,8 @@
+package events
+
+// GameVersion is the Albion Online client version the event codes in this
+// package were derived from. Update it along with the codes after a game update;
+// it can also be overridden at build time:
+//
+//	go build -ldflags "-X github.com/cantalupo555/albion-lens/pkg/events.GameVersion=1.25.310"
+var GameVersion = "1.25.310"
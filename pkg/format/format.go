@@ -0,0 +1,69 @@
+// Package format provides number and text formatting shared by all frontends
+// (TUI, status line, exports) so values are rendered consistently everywhere.
+package format
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultMaxMessageLength is the default maximum length of an event message, in runes
+const DefaultMaxMessageLength = 200
+
+// ellipsis marks a truncated message
+const ellipsis = "…"
+
+// Number formats an amount based on the full/abbreviated display setting.
+// If full is true, returns the full number (e.g., 4984).
+// If full is false, returns the abbreviated form (e.g., 4.9k, 1.3M).
+func Number(amount int64, full bool) string {
+	if full {
+		return fmt.Sprintf("%d", amount)
+	}
+	// Abbreviated format with truncation (floor) instead of rounding
+	if amount >= 1000000 {
+		val := math.Floor(float64(amount)/100000.0) / 10.0
+		return fmt.Sprintf("%.1fM", val)
+	} else if amount >= 1000 {
+		val := math.Floor(float64(amount)/100.0) / 10.0
+		return fmt.Sprintf("%.1fk", val)
+	}
+	return fmt.Sprintf("%d", amount)
+}
+
+// Clock formats a duration as HH:MM:SS.
+func Clock(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
+// PerHour converts an amount accumulated over elapsed into an hourly rate.
+// Returns 0 when no time has elapsed.
+func PerHour(amount int64, elapsed time.Duration) int64 {
+	if elapsed < time.Second {
+		return 0
+	}
+	return int64(float64(amount) / elapsed.Hours())
+}
+
+// Truncate shortens s to at most max runes, replacing the end with an ellipsis.
+// Runes are never split. A max of 0 or less disables truncation.
+func Truncate(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	// Keep max-1 runes and append the ellipsis
+	kept := 0
+	for i := range s {
+		if kept == max-1 {
+			return s[:i] + ellipsis
+		}
+		kept++
+	}
+	return s
+}
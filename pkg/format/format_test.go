@@ -0,0 +1,84 @@
+package format
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestNumber tests full and abbreviated number formatting
+func TestNumber(t *testing.T) {
+	testCases := []struct {
+		amount   int64
+		full     bool
+		expected string
+	}{
+		{4984, true, "4984"},
+		{4984, false, "4.9k"},
+		{999, false, "999"},
+		{1000, false, "1.0k"},
+		{1299999, false, "1.2M"},
+		{0, false, "0"},
+	}
+
+	for _, tc := range testCases {
+		if got := Number(tc.amount, tc.full); got != tc.expected {
+			t.Errorf("Number(%d, %v): expected '%s', got '%s'", tc.amount, tc.full, tc.expected, got)
+		}
+	}
+}
+
+// TestClock tests HH:MM:SS duration formatting
+func TestClock(t *testing.T) {
+	d := 1*time.Hour + 23*time.Minute + 45*time.Second
+	if got := Clock(d); got != "01:23:45" {
+		t.Errorf("expected '01:23:45', got '%s'", got)
+	}
+
+	if got := Clock(0); got != "00:00:00" {
+		t.Errorf("expected '00:00:00', got '%s'", got)
+	}
+}
+
+// TestPerHour tests hourly rate calculation
+func TestPerHour(t *testing.T) {
+	if got := PerHour(6000, 30*time.Minute); got != 12000 {
+		t.Errorf("expected 12000/h, got %d", got)
+	}
+
+	// No elapsed time should not divide by zero
+	if got := PerHour(6000, 0); got != 0 {
+		t.Errorf("expected 0 with no elapsed time, got %d", got)
+	}
+}
+
+// TestTruncate tests truncation with an ellipsis on rune boundaries
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		s        string
+		max      int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"this is too long", 10, "this is t…"},
+		{"no limit at all", 0, "no limit at all"},
+		{"héllo wörld", 6, "héllo…"},
+		{"🏰 Hideout of Guild", 3, "🏰 …"},
+		{"日本語のテキスト", 4, "日本語…"},
+		{"ab", 1, "…"},
+	}
+
+	for _, tc := range testCases {
+		got := Truncate(tc.s, tc.max)
+		if got != tc.expected {
+			t.Errorf("Truncate(%q, %d): expected '%s', got '%s'", tc.s, tc.max, tc.expected, got)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d): split a rune: %q", tc.s, tc.max, got)
+		}
+		if tc.max > 0 && utf8.RuneCountInString(got) > tc.max {
+			t.Errorf("Truncate(%q, %d): %d runes exceed the limit", tc.s, tc.max, utf8.RuneCountInString(got))
+		}
+	}
+}
@@ -4,64 +4,315 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cantalupo555/albion-lens/pkg/events"
 	"github.com/cantalupo555/albion-lens/pkg/items"
+	"github.com/cantalupo555/albion-lens/pkg/mobs"
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+	"github.com/cantalupo555/albion-lens/pkg/spells"
+	"github.com/cantalupo555/albion-lens/pkg/world"
 )
 
+// Silver sanity thresholds (whole silver)
+const (
+	// DefaultMinSilver is the smallest silver grab counted; smaller values are noise
+	DefaultMinSilver int64 = 1
+	// DefaultMaxSilverGrab is the largest plausible single silver grab; larger values are misparsed
+	DefaultMaxSilverGrab int64 = 10_000_000
+)
+
+// DefaultFameThreshold is the smallest total fame (FixPoint, 1000000 = 100 fame) accepted
+// from a fame event; events with a smaller total are assumed to be unrelated events
+// with a similar structure
+const DefaultFameThreshold int64 = 1_000_000
+
 // EventCallback is called when a game event is processed
-// eventType: "fame", "silver", "loot", "combat", "info", "death", "kill"
+// eventType: "fame", "silver", "loot", "combat", "info", "death", "kill", "reward", "consume", "social", "match", "chat", "harvest", "fishing", "market", "trade"
 // message: formatted message to display
 // data: optional structured data (FameEventData, SilverEventData, etc.)
 type EventCallback func(eventType, message string, data interface{})
 
+// EventInfoCallback is an EventCallback that also receives the info of the message
+// the event was decoded from (zero if the message was passed without it)
+type EventInfoCallback func(info photon.MessageInfo, eventType, message string, data interface{})
+
 // AlbionHandler handles Albion Online game events
 type AlbionHandler struct {
 	debug     bool
 	discovery bool
 
 	// Fame tracking
-	totalFame   int64
-	sessionFame int64
+	totalFame     int64
+	sessionFame   int64
+	fameThreshold int64 // Fame events with a smaller total are ignored (see SetFameThreshold)
 
 	// Silver tracking
-	sessionSilver int64
+	sessionSilver  int64
+	minSilver      int64 // Grabs below this are ignored as noise
+	maxSilverGrab  int64 // Grabs above this are rejected as misparsed (0 = no limit)
+	rejectedSilver int   // Silver grabs filtered by the thresholds
+
+	// Reward fame already credited to the session but not yet seen in an
+	// UpdateFame event (used to avoid counting the same fame twice)
+	pendingRewardFame int64
 
 	// Kill/Death tracking
 	sessionKills  int
 	sessionDeaths int
 	sessionLoot   int
 
+	// Gathered resources (see HarvestEventData)
+	sessionHarvested int
+
+	// Fishing (see FishingEventData)
+	sessionFishCaught int
+	fishingReported   bool // The current attempt's result was reported by FishingCatch
+
+	// Spell damage involving the local player (see DamageEventData)
+	sessionDamageDealt float64
+	sessionDamageTaken float64
+
+	// Guards totalFame, pendingRewardFame, rejectedSilver and the session counters
+	// (including consumables): events are handled on the capture goroutines while
+	// the getters are called from the frontend
+	sessionMu sync.RWMutex
+
+	// Consumable tracking (quantity used by item name, estimated cost in silver)
+	sessionConsumables    map[string]int
+	sessionConsumableCost int64
+	pendingBatchUses      map[int64]pendingBatchUse
+
+	// Player tracking (the local player and joinPending are guarded by relationsMu, see localPlayer)
+	localPlayerID   int64
+	localPlayerName string
+	joinPending     bool              // JoinFinished arrived without a Join response (see handleJoinFinished)
+	players         map[int64]string  // Nearby player names by object ID (see GetPlayerName)
+	playerGUIDs     map[int64]string  // Nearby player GUIDs by object ID (see GetPlayerGUID)
+	namesByGUID     map[string]string // Player names by GUID, kept across zones (see GetPlayerNameByGUID)
+	playersMu       sync.RWMutex
+	transformation  TransformationState
+	transformMu     sync.Mutex
+
+	// Object positions in view (see SetTrackPositions)
+	positions      map[int64]Position
+	trackPositions bool
+	positionsMu    sync.RWMutex
+
+	// Party roster and guild/flagging state (see Relationship)
+	relations   relationshipRegistry
+	relationsMu sync.RWMutex
+
+	// Whether the local player is in combat (see IsInCombat)
+	inCombat   bool
+	inCombatMu sync.RWMutex
+
+	// Local player's mount (see MountHealth)
+	mount   mountState
+	mountMu sync.Mutex
+
+	// Client version seen in traffic (see DetectedGameVersion)
+	detectedVersion string
+	versionParam    byte
+	versionMu       sync.Mutex
+
+	// Zone tracking (session gains per zone)
+	zoneCheckpoint zoneCheckpoint
+	zoneStats      map[string]*ZoneStats
+	zoneOrder      []string
+	zonesMu        sync.Mutex
+
+	// Loot chests in the current zone (dungeons, avalon roads)
+	chests   map[int64]*Chest
+	chestsMu sync.Mutex
+
+	// Buildings in view (hideouts, island and territory buildings)
+	buildings   map[int64]*Building
+	buildingsMu sync.Mutex
+
+	// Arena/crystal match in progress (nil outside matches)
+	match        *Match
+	matchPlayers map[int64]*MatchPlayerStats
+	matchMu      sync.Mutex
+
+	// Combat awareness
+	verboseCombat  atomic.Bool
+	combatLastSent map[combatKey]time.Time
+
+	// Social invitations (rate limiting)
+	socialLastSent map[socialKey]time.Time
+
+	// Estimated market values per unit (silver) by item ID
+	marketValues     map[int32]int64
+	recentMarket     []MarketEventData // Ring buffer (see RecentMarketValues)
+	recentMarketNext int               // Oldest entry once recentMarket is full
+	marketValuesMu   sync.RWMutex
+
 	// Items database
 	itemDB *items.ItemDatabase
 
+	// Spells database (ability names for cast events)
+	spellDB *spells.SpellDatabase
+
+	// World database (zone names)
+	worldDB *world.WorldDatabase
+
+	// Mobs database (creature names), and mobs in view by object ID (see GetMobType)
+	mobDB  *mobs.MobDatabase
+	mobs   map[int64]int32
+	mobsMu sync.RWMutex
+
 	// Discovery mode tracking
-	discoveredEvents map[int16]*DiscoveredEvent
-	discoveryMu      sync.RWMutex
+	discoveredEvents   map[int32]*DiscoveredEvent
+	rawSamples         map[int32][]RawSample // Full parameters of rare unknown events
+	rawSampleThreshold int
+	discoverySamples   int // Distinct samples kept per code (see DiscoveredEvent.Samples)
+	discoveryMu        sync.RWMutex
+
+	// ObjectEvent unwrapping
+	objectEventCodeParam   byte
+	objectEventParamsParam byte
+	objectEventDepth       int
+
+	// Custom handlers registered by frontends/integrations
+	customHandlers   map[events.EventCode][]EventHandlerFunc
+	customHandlersMu sync.RWMutex
+
+	// Per event type rate limits (see SetEventRateLimit)
+	rateLimits   map[string]*tokenBucket
+	rateLimitsMu sync.Mutex
+	onThrottle   func()
 
 	// Event callback for frontend integration (TUI, Wails, etc.)
-	eventCallback EventCallback
+	eventCallback     EventCallback
+	eventInfoCallback EventInfoCallback
+
+	// Messages are dispatched one at a time, so the info of the message being
+	// handled applies to every event it produces
+	dispatchMu sync.Mutex
+	message    photon.MessageInfo
 }
 
 // DiscoveredEvent tracks unknown events in discovery mode
 type DiscoveredEvent struct {
-	Code       int16                  `json:"code"`
+	Code       int32                  `json:"code"`
 	Count      int                    `json:"count"`
 	FirstSeen  time.Time              `json:"first_seen"`
 	LastSeen   time.Time              `json:"last_seen"`
 	SampleData map[byte]interface{}   `json:"sample_data"`
 	ParamTypes map[byte]string        `json:"param_types"`
+	Handled    bool                   `json:"handled"`
+
+	// Distinct parameter tables seen for this code, up to SetDiscoverySamples
+	Samples []map[byte]interface{} `json:"samples"`
+}
+
+// eventHandlers maps event codes to their dedicated handler.
+// This is the single source of truth for which events are handled by OnEvent.
+var eventHandlers = map[events.EventCode]func(h *AlbionHandler, params map[byte]interface{}){
+	events.EventUpdateFame:           (*AlbionHandler).handleUpdateFame,
+	events.EventUpdateMoney:          (*AlbionHandler).handleUpdateMoney,
+	events.EventHealthUpdate:         (*AlbionHandler).handleHealthUpdate,
+	events.EventNewCharacter:         (*AlbionHandler).handleNewCharacter,
+	events.EventOtherGrabbedLoot:     (*AlbionHandler).handleOtherGrabbedLoot,
+	events.EventNewLoot:              (*AlbionHandler).handleNewLoot,
+	events.EventKilledPlayer:         (*AlbionHandler).handleKilledPlayer,
+	events.EventDied:                 (*AlbionHandler).handleDied,
+	events.EventRewardGranted:        (*AlbionHandler).handleRewardGranted,
+	events.EventForcedMovement:       (*AlbionHandler).handleForcedMovement,
+	events.EventForcedMovementCancel: (*AlbionHandler).handleForcedMovementCancel,
+	events.EventCloak:                (*AlbionHandler).handleCloak,
+	events.EventInCombatStateUpdate:  (*AlbionHandler).handleInCombatStateUpdate,
+	events.EventBatchUseItemStart:    (*AlbionHandler).handleBatchUseItemStart,
+	events.EventBatchUseItemEnd:      (*AlbionHandler).handleBatchUseItemEnd,
+	events.EventUseFunction:          (*AlbionHandler).handleUseFunction,
+	events.EventNewLootChest:         (*AlbionHandler).handleNewLootChest,
+	events.EventUpdateLootChest:      (*AlbionHandler).handleUpdateLootChest,
+	events.EventLootChestOpened:      (*AlbionHandler).handleLootChestOpened,
+	events.EventTransformation:       (*AlbionHandler).handleTransformation,
+	events.EventTransformationEnd:    (*AlbionHandler).handleTransformationEnd,
+	events.EventNewBuilding:          (*AlbionHandler).handleNewBuilding,
+	events.EventPlayerBuildingInfo:   (*AlbionHandler).handlePlayerBuildingInfo,
+	events.EventNewMountObject:       (*AlbionHandler).handleNewMountObject,
+	events.EventMountHealthUpdate:    (*AlbionHandler).handleMountHealthUpdate,
+	events.EventMountCooldownUpdate:  (*AlbionHandler).handleMountCooldownUpdate,
+	events.EventInvitedToGuild:       (*AlbionHandler).handleInvitedToGuild,
+	events.EventPartyInvitation:      (*AlbionHandler).handlePartyInvitation,
+	events.EventFriendRequest:        (*AlbionHandler).handleFriendRequest,
+	events.EventStartMatch:           (*AlbionHandler).handleStartMatch,
+	events.EventMatchUpdate:          (*AlbionHandler).handleMatchUpdate,
+	events.EventEndArenaMatch:        (*AlbionHandler).handleEndArenaMatch,
+	events.EventPartyJoined:          (*AlbionHandler).handlePartyJoined,
+	events.EventPartyPlayerJoined:    (*AlbionHandler).handlePartyPlayerJoined,
+	events.EventPartyPlayerLeft:      (*AlbionHandler).handlePartyPlayerLeft,
+	events.EventPartyDisbanded:       (*AlbionHandler).handlePartyDisbanded,
+	events.EventChatMessage:          (*AlbionHandler).handleChatMessage,
+	events.EventChatSay:              (*AlbionHandler).handleChatSay,
+	events.EventChatWhisper:          (*AlbionHandler).handleChatWhisper,
+	events.EventHarvestFinished:      (*AlbionHandler).handleHarvestFinished,
+	events.EventFishingCatch:         (*AlbionHandler).handleFishingCatch,
+	events.EventFishingFinished:      (*AlbionHandler).handleFishingFinished,
+	events.EventLeave:                (*AlbionHandler).handleLeave,
+	events.EventMove:                 (*AlbionHandler).handleMove,
+	events.EventCastHit:              (*AlbionHandler).handleCastHit,
+	events.EventCastHits:             (*AlbionHandler).handleCastHits,
+	events.EventJoinFinished:         (*AlbionHandler).handleJoinFinished,
+	events.EventClusterInfoUpdate:    (*AlbionHandler).handleClusterInfo,
+	events.EventPlayerTradeFinished:  (*AlbionHandler).handlePlayerTradeFinished,
+	events.EventNewMob:               (*AlbionHandler).handleNewMob,
+
+	events.EventMatchPlayerStatsEvent:      (*AlbionHandler).handleMatchPlayerStats,
+	events.EventEstimatedMarketValueUpdate: (*AlbionHandler).handleEstimatedMarketValueUpdate,
+}
+
+// HandledEventCodes returns the event codes with dedicated handling, in ascending order
+func (h *AlbionHandler) HandledEventCodes() []int32 {
+	codes := make([]int32, 0, len(eventHandlers))
+	for code := range eventHandlers {
+		codes = append(codes, int32(code))
+	}
+	slices.Sort(codes)
+	return codes
 }
 
 // NewAlbionHandler creates a new Albion event handler
 func NewAlbionHandler() *AlbionHandler {
 	return &AlbionHandler{
-		discoveredEvents: make(map[int16]*DiscoveredEvent),
+		discoveredEvents:   make(map[int32]*DiscoveredEvent),
+		rawSamples:         make(map[int32][]RawSample),
+		rawSampleThreshold: DefaultRawSampleThreshold,
+		discoverySamples:   DefaultDiscoverySamples,
+		customHandlers:     make(map[events.EventCode][]EventHandlerFunc),
+		players:            make(map[int64]string),
+		playerGUIDs:        make(map[int64]string),
+		namesByGUID:        make(map[string]string),
+		positions:          make(map[int64]Position),
+		relations:          newRelationshipRegistry(),
+		combatLastSent:     make(map[combatKey]time.Time),
+		socialLastSent:     make(map[socialKey]time.Time),
+		sessionConsumables: make(map[string]int),
+		pendingBatchUses:   make(map[int64]pendingBatchUse),
+		chests:             make(map[int64]*Chest),
+		buildings:          make(map[int64]*Building),
+		mobs:               make(map[int64]int32),
+		zoneStats:          make(map[string]*ZoneStats),
+		zoneCheckpoint:     zoneCheckpoint{enteredAt: time.Now()},
+		marketValues:       make(map[int32]int64),
+
+		minSilver:     DefaultMinSilver,
+		maxSilverGrab: DefaultMaxSilverGrab,
+		fameThreshold: DefaultFameThreshold,
+
+		objectEventCodeParam:   defaultObjectEventCodeParam,
+		objectEventParamsParam: defaultObjectEventParamsParam,
+		versionParam:           defaultVersionParam,
 	}
 }
 
@@ -75,16 +326,50 @@ func (h *AlbionHandler) SetDiscoveryMode(discovery bool) {
 	h.discovery = discovery
 }
 
+// SetFameThreshold sets the smallest total fame (FixPoint) accepted from a fame event.
+// Lower it for low-level characters whose total is below DefaultFameThreshold; 0 accepts
+// any positive total.
+func (h *AlbionHandler) SetFameThreshold(threshold int64) {
+	h.fameThreshold = threshold
+}
+
+// SetSilverThresholds sets the range of silver (whole silver) accepted from a single grab.
+// Grabs below minSilver are ignored as noise; grabs above maxSilverGrab are rejected as
+// misparsed values (0 disables the upper limit).
+func (h *AlbionHandler) SetSilverThresholds(minSilver, maxSilverGrab int64) {
+	h.minSilver = minSilver
+	h.maxSilverGrab = maxSilverGrab
+}
+
+// GetRejectedSilver returns the number of silver grabs filtered by the thresholds
+func (h *AlbionHandler) GetRejectedSilver() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.rejectedSilver
+}
+
 // SetEventCallback sets a callback function for TUI integration
 func (h *AlbionHandler) SetEventCallback(callback EventCallback) {
 	h.eventCallback = callback
 }
 
-// notifyEvent calls the event callback if set
+// SetEventInfoCallback sets a callback function that also receives the info of the
+// message each event was decoded from (see OnEventInfo)
+func (h *AlbionHandler) SetEventInfoCallback(callback EventInfoCallback) {
+	h.eventInfoCallback = callback
+}
+
+// notifyEvent calls the event callbacks if set, unless the event type is over its rate limit
 func (h *AlbionHandler) notifyEvent(eventType, message string, data interface{}) {
+	if !h.allowEvent(eventType) {
+		return
+	}
 	if h.eventCallback != nil {
 		h.eventCallback(eventType, message, data)
 	}
+	if h.eventInfoCallback != nil {
+		h.eventInfoCallback(h.message, eventType, message, data)
+	}
 }
 
 // FameEventData contains fame-specific event data
@@ -104,15 +389,37 @@ type SilverEventData struct {
 
 // LootEventData contains loot-specific event data
 type LootEventData struct {
-	LootedBy   string // Player who looted
-	ItemName   string // Name of the item
-	Quantity   int32  // Quantity of the item
-	LootedFrom string // Source of the loot
+	LootedBy       string // Player who looted
+	ItemID         int32  // Numeric item ID
+	UniqueName     string // Item unique name (e.g., "T4_BAG"), empty if the database is not loaded
+	ItemName       string // Name of the item
+	Quantity       int64  // Quantity of the item
+	LootedFrom     string // Source of the loot
+	EstimatedValue int64  // Estimated market value of the stack in silver, 0 if unknown
+}
+
+// RewardEventData contains reward-specific event data (quests, activities, chests)
+type RewardEventData struct {
+	Silver        int64        // Silver granted by this reward
+	Fame          int64        // Fame granted by this reward
+	Items         []RewardItem // Items granted by this reward
+	SessionSilver int64        // Total silver gained this session
+	SessionFame   int64        // Total fame gained this session
+}
+
+// RewardItem is a single item granted by a reward
+type RewardItem struct {
+	ItemID   int32  // Item ID
+	ItemName string // Name of the item
+	Quantity int32  // Quantity of the item
 }
 
 // KillEventData contains kill-specific event data
 type KillEventData struct {
-	SessionKills int // Total kills in this session
+	SessionKills int    // Total kills in this session
+	Killer       string // Player who killed, empty if unknown
+	Victim       string // Player who was killed, empty if unknown
+	IsLocal      bool   // Whether the local player is the killer
 }
 
 // DeathEventData contains death-specific event data
@@ -120,41 +427,144 @@ type DeathEventData struct {
 	Victim        string // Player who died
 	Killer        string // Player who killed
 	SessionDeaths int    // Total deaths in this session
+	IsLocal       bool   // Whether the local player died
 }
 
 // GetSessionKills returns the number of kills in this session
 func (h *AlbionHandler) GetSessionKills() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
 	return h.sessionKills
 }
 
 // GetSessionDeaths returns the number of deaths in this session
 func (h *AlbionHandler) GetSessionDeaths() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
 	return h.sessionDeaths
 }
 
 // GetSessionLoot returns the number of loot items in this session
 func (h *AlbionHandler) GetSessionLoot() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
 	return h.sessionLoot
 }
 
+// ResetSession clears the session totals, consumables and per-zone stats so a new
+// measurement window starts now. Capture state (players, zone, chests) is kept.
+func (h *AlbionHandler) ResetSession() {
+	h.zonesMu.Lock()
+	defer h.zonesMu.Unlock()
+
+	h.sessionMu.Lock()
+	h.sessionFame = 0
+	h.sessionSilver = 0
+	h.sessionKills = 0
+	h.sessionDeaths = 0
+	h.sessionLoot = 0
+	h.sessionHarvested = 0
+	h.sessionFishCaught = 0
+	h.sessionDamageDealt = 0
+	h.sessionDamageTaken = 0
+	clear(h.sessionConsumables)
+	h.sessionConsumableCost = 0
+	h.sessionMu.Unlock()
+
+	clear(h.zoneStats)
+	h.zoneOrder = nil
+	h.zoneCheckpoint = h.checkpoint(h.zoneCheckpoint.zone, time.Now())
+}
+
 // LoadItemDatabase loads the item database from ao-bin-dumps
 func (h *AlbionHandler) LoadItemDatabase(path string) error {
 	h.itemDB = items.GetDatabase()
 	return h.itemDB.LoadFromPath(path)
 }
 
+// LoadSpellDatabase loads the spell database from ao-bin-dumps
+func (h *AlbionHandler) LoadSpellDatabase(path string) error {
+	h.spellDB = spells.GetDatabase()
+	return h.spellDB.LoadFromPath(path)
+}
+
+// LoadWorldDatabase loads the world (zone) database from ao-bin-dumps
+func (h *AlbionHandler) LoadWorldDatabase(path string) error {
+	h.worldDB = world.GetDatabase()
+	return h.worldDB.LoadFromPath(path)
+}
+
 // OnRequest handles operation requests (client -> server)
 func (h *AlbionHandler) OnRequest(operationCode byte, parameters map[byte]interface{}) {
-	// Requests are not logged to avoid polluting TUI output
+	h.OnRequestInfo(photon.MessageInfo{}, operationCode, parameters)
+}
+
+// OnRequestInfo handles an operation request along with the info of its message
+func (h *AlbionHandler) OnRequestInfo(info photon.MessageInfo, operationCode byte, parameters map[byte]interface{}) {
+	h.dispatchMu.Lock()
+	defer h.dispatchMu.Unlock()
+	h.message = info
+
+	// Requests are only logged in debug mode to avoid polluting TUI output
+	if h.debug {
+		h.notifyEvent("debug", fmt.Sprintf("Request %s (%d params)", gameOperationCode(operationCode, parameters), len(parameters)), nil)
+	}
+	h.detectGameVersion(parameters)
 }
 
 // OnResponse handles operation responses (server -> client)
 func (h *AlbionHandler) OnResponse(operationCode byte, returnCode int16, debugMessage string, parameters map[byte]interface{}) {
-	// Responses are not logged to avoid polluting TUI output
+	h.OnResponseInfo(photon.MessageInfo{}, operationCode, returnCode, debugMessage, parameters)
+}
+
+// OnResponseInfo handles an operation response along with the info of its message
+func (h *AlbionHandler) OnResponseInfo(info photon.MessageInfo, operationCode byte, returnCode int16, debugMessage string, parameters map[byte]interface{}) {
+	h.dispatchMu.Lock()
+	defer h.dispatchMu.Unlock()
+	h.message = info
+
+	// Responses are only logged in debug mode to avoid polluting TUI output
+	if h.debug {
+		msg := fmt.Sprintf("Response %s: return %d (%d params)", gameOperationCode(operationCode, parameters), returnCode, len(parameters))
+		if debugMessage != "" {
+			msg += ": " + debugMessage
+		}
+		h.notifyEvent("debug", msg, nil)
+	}
+	if operationCode == operationJoin {
+		h.handleJoinResponse(parameters)
+	}
+}
+
+// gameOperationCode returns the operation code from parameter 253, the Photon code if it has none
+func gameOperationCode(operationCode byte, parameters map[byte]interface{}) events.OperationCode {
+	switch v := parameters[events.ParamOperationCode].(type) {
+	case int16:
+		return events.OperationCode(v)
+	case int32:
+		return events.OperationCode(v)
+	case int64:
+		return events.OperationCode(v)
+	}
+	return events.OperationCode(operationCode)
 }
 
 // OnEvent handles incoming game events
 func (h *AlbionHandler) OnEvent(eventCode byte, parameters map[byte]interface{}) {
+	h.OnEventInfo(photon.MessageInfo{}, eventCode, parameters)
+}
+
+// OnEventInfo handles an incoming game event along with the info of its message,
+// passed to the EventInfoCallback with each event it produces
+func (h *AlbionHandler) OnEventInfo(info photon.MessageInfo, eventCode byte, parameters map[byte]interface{}) {
+	h.dispatchMu.Lock()
+	defer h.dispatchMu.Unlock()
+	h.message = info
+	h.dispatchEvent(eventCode, parameters)
+}
+
+// dispatchEvent runs the handlers of a game event. The caller must hold dispatchMu.
+func (h *AlbionHandler) dispatchEvent(eventCode byte, parameters map[byte]interface{}) {
 	// Get actual event code from parameter 252 if available
 	actualEventCode := events.EventCode(eventCode)
 	if code, ok := parameters[events.ParamEventCode]; ok {
@@ -168,57 +578,26 @@ func (h *AlbionHandler) OnEvent(eventCode byte, parameters map[byte]interface{})
 		}
 	}
 
-	handled := false
-
-	switch actualEventCode {
-	case events.EventUpdateFame:
-		h.handleUpdateFame(parameters)
-		handled = true
-
-	case events.EventUpdateMoney:
-		h.handleUpdateMoney(parameters)
-		handled = true
-
-	case events.EventHealthUpdate:
-		h.handleHealthUpdate(parameters)
-		handled = true
-
-	case events.EventNewCharacter:
-		h.handleNewCharacter(parameters)
-		handled = true
-
-	case events.EventOtherGrabbedLoot:
-		h.handleOtherGrabbedLoot(parameters)
-		handled = true
+	// Custom handlers run alongside the built-in ones
+	handled := h.runCustomHandlers(actualEventCode, parameters)
 
-	case events.EventNewLoot:
-		h.handleNewLoot(parameters)
+	if handler, ok := eventHandlers[actualEventCode]; ok {
+		handler(h, parameters)
 		handled = true
-
-	case events.EventKilledPlayer:
-		h.handleKilledPlayer(parameters)
-		handled = true
-
-	case events.EventDied:
-		h.handleDied(parameters)
-		handled = true
-
-	default:
-		if h.debug {
-			// Pass "debug" type and the raw event code as data.
-			// The TUI will handle visual formatting.
-			h.notifyEvent("debug", "", actualEventCode)
-		}
+	} else if h.debug && !handled {
+		// Pass "debug" type and the raw event code as data.
+		// The TUI will handle visual formatting.
+		h.notifyEvent("debug", "", actualEventCode)
 	}
 
 	// Discovery mode: track all events (including handled ones for completeness)
 	if h.discovery {
-		h.trackDiscoveredEvent(int16(actualEventCode), parameters, handled)
+		h.trackDiscoveredEvent(int32(actualEventCode), parameters, handled)
 	}
 }
 
 // trackDiscoveredEvent records event details in discovery mode
-func (h *AlbionHandler) trackDiscoveredEvent(code int16, params map[byte]interface{}, handled bool) {
+func (h *AlbionHandler) trackDiscoveredEvent(code int32, params map[byte]interface{}, handled bool) {
 	h.discoveryMu.Lock()
 	defer h.discoveryMu.Unlock()
 
@@ -230,6 +609,7 @@ func (h *AlbionHandler) trackDiscoveredEvent(code int16, params map[byte]interfa
 			FirstSeen:  time.Now(),
 			SampleData: make(map[byte]interface{}),
 			ParamTypes: make(map[byte]string),
+			Handled:    handled,
 		}
 		h.discoveredEvents[code] = event
 	}
@@ -244,15 +624,20 @@ func (h *AlbionHandler) trackDiscoveredEvent(code int16, params map[byte]interfa
 			event.SampleData[key] = val
 		}
 	}
+	h.sampleDiscoveredEvent(event, params)
+
+	if !handled {
+		h.sampleRawEvent(event, params)
+	}
 }
 
 // GetDiscoveredEvents returns all discovered events
-func (h *AlbionHandler) GetDiscoveredEvents() map[int16]*DiscoveredEvent {
+func (h *AlbionHandler) GetDiscoveredEvents() map[int32]*DiscoveredEvent {
 	h.discoveryMu.RLock()
 	defer h.discoveryMu.RUnlock()
 	
 	// Return a copy
-	result := make(map[int16]*DiscoveredEvent)
+	result := make(map[int32]*DiscoveredEvent)
 	for k, v := range h.discoveredEvents {
 		result[k] = v
 	}
@@ -270,10 +655,16 @@ func (h *AlbionHandler) SaveDiscoveredEvents(filename string) error {
 		return err
 	}
 
-	// Convert to a serializable format
-	output := make(map[string]*DiscoveredEvent)
-	for code, event := range h.discoveredEvents {
-		output[fmt.Sprintf("%d", code)] = event
+	// Convert to a serializable format, ordered by event code
+	output := discoveryFile{
+		Events:     make([]*DiscoveredEvent, 0, len(h.discoveredEvents)),
+		RawSamples: make([]rawSampleSet, 0, len(h.rawSamples)),
+	}
+	for _, code := range slices.Sorted(maps.Keys(h.discoveredEvents)) {
+		output.Events = append(output.Events, h.discoveredEvents[code])
+	}
+	for _, code := range slices.Sorted(maps.Keys(h.rawSamples)) {
+		output.RawSamples = append(output.RawSamples, rawSampleSet{Code: code, Samples: h.rawSamples[code]})
 	}
 
 	data, err := json.MarshalIndent(output, "", "  ")
@@ -284,66 +675,66 @@ func (h *AlbionHandler) SaveDiscoveredEvents(filename string) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
-// isKnownEventCode checks if an event code is in our known list
-func (h *AlbionHandler) isKnownEventCode(code int16) bool {
-	knownCodes := []events.EventCode{
-		events.EventUnused, events.EventLeave, events.EventJoinFinished,
-		events.EventMove, events.EventTeleport, events.EventHealthUpdate,
-		events.EventHealthUpdates, events.EventEnergyUpdate, events.EventAttack,
-		events.EventCastStart, events.EventCastHit, events.EventKilledPlayer,
-		events.EventDied, events.EventKnockedDown, events.EventInventoryPutItem,
-		events.EventInventoryDeleteItem, events.EventNewCharacter,
-		events.EventNewEquipmentItem, events.EventNewSiegeBannerItem,
-		events.EventNewSimpleItem, events.EventNewFurnitureItem,
-		events.EventHarvestStart, events.EventHarvestCancel,
-		events.EventHarvestFinished, events.EventTakeSilver,
-		events.EventUpdateMoney, events.EventUpdateFame, events.EventUpdateLearningPoints,
-		events.EventNewLoot, events.EventAttachItemContainer,
-		events.EventDetachItemContainer, events.EventCharacterStats,
-		events.EventPartyInvitation, events.EventPartyJoinRequest,
-		events.EventPartyJoined, events.EventPartyDisbanded,
-		events.EventPartyPlayerJoined, events.EventPartyPlayerLeft,
-		events.EventOtherGrabbedLoot, events.EventInCombatStateUpdate,
-	}
-
-	for _, known := range knownCodes {
-		if events.EventCode(code) == known {
-			return true
-		}
-	}
-	return false
+// isKnownEventCode checks if an event code has a dedicated handler.
+// Uses the same map as the OnEvent dispatch, so the two cannot diverge.
+func (h *AlbionHandler) isKnownEventCode(code int32) bool {
+	_, ok := eventHandlers[events.EventCode(code)]
+	return ok
 }
 
 // GetSessionFame returns the total fame gained in this session
 func (h *AlbionHandler) GetSessionFame() int64 {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
 	return h.sessionFame
 }
 
 // GetSessionSilver returns the total silver looted in this session
 func (h *AlbionHandler) GetSessionSilver() int64 {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
 	return h.sessionSilver
 }
 
 // handleUpdateFame handles fame/XP gain events
 // Supports multiple event formats as they vary between game versions
 func (h *AlbionHandler) handleUpdateFame(params map[byte]interface{}) {
+	// Validation: Total fame should be a large number (see SetFameThreshold)
+	// This helps filter out events with similar structure but different purpose.
+	// A total of 0 is never valid: it is also the "no fame seen yet" state used
+	// by deduplication.
+	totalFame := h.paramInt64(params, 1)
+	if totalFame <= 0 || totalFame < h.fameThreshold {
+		if h.debug {
+			h.notifyEvent("debug", fmt.Sprintf("Ignored fame event: total %d below threshold %d", totalFame, h.fameThreshold), nil)
+		}
+		return
+	}
+
+	h.sessionMu.Lock()
+	data := h.updateFame(params)
+	h.sessionMu.Unlock()
+
+	if data != nil {
+		// Message formatting is now handled by the frontend (TUI)
+		h.notifyEvent("fame", "", data)
+	}
+}
+
+// updateFame updates the fame totals from a fame event and returns the gain to
+// report, or nil if there is none. The caller must hold sessionMu.
+func (h *AlbionHandler) updateFame(params map[byte]interface{}) *FameEventData {
 	// Detect event format based on available parameters
 	// Format 1 (Event #81 simple): [0]=playerID, [1]=totalFame
 	// Format 2 (Event #82 detailed): [0]=playerID, [1]=totalFame, [2]=gained, [3]=zone
 
-	// Get total fame from parameter 1
+	// Get total fame from parameter 1 (already checked against the threshold)
 	totalFame := getInt64(params, 1)
 
-	// Validation: Total fame should be a large number (> 1 million in FixPoint = 100 fame)
-	// This helps filter out events with similar structure but different purpose
-	if totalFame < 1000000 {
-		return
-	}
-
 	// Deduplication: Server sends both Event #81 and #82 for the same fame gain
 	// Skip if we already processed an event with this exact totalFame
 	if totalFame == h.totalFame {
-		return
+		return nil
 	}
 
 	// Check if we have additional parameters (Format 2)
@@ -362,7 +753,7 @@ func (h *AlbionHandler) handleUpdateFame(params map[byte]interface{}) {
 	// Validation: Total fame should not decrease significantly
 	// This helps filter out events with similar structure but different purpose
 	if h.totalFame > 0 && totalFame < h.totalFame {
-		return
+		return nil
 	}
 	
 	// Calculate values (divide by 10000 for FixPoint format)
@@ -375,35 +766,50 @@ func (h *AlbionHandler) handleUpdateFame(params map[byte]interface{}) {
 		_ = zoneFame // Zone fame available but not displayed in simplified view
 
 		// Only notify if fame was actually gained
+		fameGainedVal = float64(h.consumeRewardFame(int64(fameGainedVal)))
 		if fameGainedVal > 0 {
 			h.sessionFame += int64(fameGainedVal)
 			h.totalFame = totalFame // Update tracked total
 
-			// Message formatting is now handled by the frontend (TUI)
-			h.notifyEvent("fame", "", &FameEventData{
+			return &FameEventData{
 				Gained:  int64(fameGainedVal),
 				Total:   int64(totalFameVal),
 				Session: h.sessionFame,
-			})
+			}
 		}
-	} else {
-		// Simple format: we only have total fame
-		// Calculate gained by comparing with previous total
-		if h.totalFame > 0 {
-			gained := totalFame - h.totalFame
-			if gained > 0 {
-				gainedVal := math.Floor(float64(gained) / 10000.0)
+		return nil
+	}
+
+	// Simple format: we only have total fame
+	// Calculate gained by comparing with previous total
+	var data *FameEventData
+	if h.totalFame > 0 {
+		gained := totalFame - h.totalFame
+		if gained > 0 {
+			gainedVal := float64(h.consumeRewardFame(int64(math.Floor(float64(gained) / 10000.0))))
+			if gainedVal > 0 {
 				h.sessionFame += int64(gainedVal)
-				// Message formatting is now handled by the frontend (TUI)
-				h.notifyEvent("fame", "", &FameEventData{
+				data = &FameEventData{
 					Gained:  int64(gainedVal),
 					Total:   int64(totalFameVal),
 					Session: h.sessionFame,
-				})
+				}
 			}
 		}
-		h.totalFame = totalFame
 	}
+	h.totalFame = totalFame
+	return data
+}
+
+// consumeRewardFame removes fame that was already credited by a reward from a
+// fame gain, returning the part of the gain that has not been counted yet.
+func (h *AlbionHandler) consumeRewardFame(gained int64) int64 {
+	if h.pendingRewardFame <= 0 || gained <= 0 {
+		return gained
+	}
+	deduped := min(gained, h.pendingRewardFame)
+	h.pendingRewardFame -= deduped
+	return gained - deduped
 }
 
 // toInt64 converts an interface{} to int64
@@ -441,9 +847,76 @@ func (h *AlbionHandler) handleHealthUpdate(params map[byte]interface{}) {
 	// Health updates are too frequent to notify, used only for debug
 }
 
-// handleNewCharacter handles new character events (debug only, no callback)
+// handleNewCharacter handles new character events (no callback)
+// Parameters: [0]=object ID, [1]=name, [2]=GUID, [7]=position, [8]=guild name, [53]=faction flag
 func (h *AlbionHandler) handleNewCharacter(params map[byte]interface{}) {
-	// New character events are informational only
+	// New character events are only used to track nearby players
+	objectID := h.paramInt64(params, 0)
+	name := h.paramString(params, 1)
+	if name == "" {
+		return
+	}
+	if pos, ok := getPosition(params, 7); ok {
+		h.setPosition(objectID, pos)
+	}
+
+	if h.takeJoinPending() {
+		// The first character after JoinFinished is the local player
+		h.setLocalPlayer(objectID, name, getString(params, 8))
+	}
+
+	h.playersMu.Lock()
+	if _, known := h.players[objectID]; !known && len(h.players) >= maxTrackedPlayers {
+		// Leave events were missed (e.g. capture started mid-zone), so start over
+		clear(h.players)
+		clear(h.playerGUIDs)
+	}
+	h.players[objectID] = name
+	h.playersMu.Unlock()
+
+	if guid := getGUID(params, 2); guid != "" {
+		h.rememberPlayerGUID(objectID, guid, name)
+	}
+
+	h.trackAffiliation(name, getString(params, 8), byte(toInt64(params[53])))
+}
+
+// handleLeave handles an object leaving the local player's view (no callback)
+// Parameters: [0]=object ID
+func (h *AlbionHandler) handleLeave(params map[byte]interface{}) {
+	if _, ok := params[0]; !ok {
+		return
+	}
+
+	h.playersMu.Lock()
+	delete(h.players, getInt64(params, 0))
+	delete(h.playerGUIDs, getInt64(params, 0))
+	h.playersMu.Unlock()
+
+	h.mobsMu.Lock()
+	delete(h.mobs, getInt64(params, 0))
+	h.mobsMu.Unlock()
+
+	h.chestsMu.Lock()
+	delete(h.chests, getInt64(params, 0))
+	h.chestsMu.Unlock()
+
+	h.removePosition(getInt64(params, 0))
+}
+
+// GetPlayerName returns the name of the nearby player with the given object ID,
+// or an empty string if the player is not in view
+func (h *AlbionHandler) GetPlayerName(id int64) string {
+	name, _ := h.trackedPlayer(id)
+	return name
+}
+
+// trackedPlayer returns the name of a nearby player and whether it is tracked
+func (h *AlbionHandler) trackedPlayer(id int64) (string, bool) {
+	h.playersMu.RLock()
+	defer h.playersMu.RUnlock()
+	name, ok := h.players[id]
+	return name, ok
 }
 
 // handleOtherGrabbedLoot handles when another player loots something
@@ -452,134 +925,317 @@ func (h *AlbionHandler) handleOtherGrabbedLoot(params map[byte]interface{}) {
 	lootedFrom := getString(params, 1)
 
 	// Parameter 2: Looted by
-	lootedBy := getString(params, 2)
+	lootedBy := h.paramString(params, 2)
 
 	// Parameter 3: Is silver
 	isSilver := getBool(params, 3)
 
 	// Parameter 4: Item ID
-	itemID := getInt32(params, 4)
+	itemID := h.paramInt32(params, 4)
 
-	// Parameter 5: Quantity
-	quantity := getInt32(params, 5)
+	// Parameter 5: Quantity (silver amounts and large stacks don't fit in int32)
+	quantity := h.paramInt64(params, 5)
 
 	if isSilver {
-		silverAmountRaw := getInt64(params, 5)
+		silverAmountRaw := quantity
 		// Silver also uses FixPoint format (divide by 10000)
 		silverAmount := int64(math.Floor(float64(silverAmountRaw) / 10000.0))
+
+		// Sanity check: drop noise and misparsed outliers
+		if silverAmount < h.minSilver || (h.maxSilverGrab > 0 && silverAmount > h.maxSilverGrab) {
+			h.sessionMu.Lock()
+			h.rejectedSilver++
+			h.sessionMu.Unlock()
+			if h.debug {
+				h.notifyEvent("debug", fmt.Sprintf("Rejected silver grab: %d (raw %d)", silverAmount, silverAmountRaw), nil)
+			}
+			return
+		}
+
+		h.sessionMu.Lock()
 		h.sessionSilver += silverAmount
+		session := h.sessionSilver
+		h.sessionMu.Unlock()
+
 		// Message formatting is now handled by the frontend (TUI)
 		// We just pass the raw data
 		h.notifyEvent("silver", "", &SilverEventData{
 			Amount:     silverAmount,
-			Session:    h.sessionSilver,
+			Session:    session,
 			LootedBy:   lootedBy,
 			LootedFrom: lootedFrom,
 		})
 	} else {
 		// Try to get item name from database
-		itemName := fmt.Sprintf("Item#%d", itemID)
-		if h.itemDB != nil && h.itemDB.IsLoaded() {
-			itemName = h.itemDB.GetItemName(itemID)
-		}
+		itemName := h.resolveItemName(itemID)
 
+		h.sessionMu.Lock()
 		h.sessionLoot++
+		h.sessionMu.Unlock()
 
 		// Message formatting is now handled by the frontend (TUI)
 		h.notifyEvent("loot", "", &LootEventData{
-			LootedBy:   lootedBy,
-			ItemName:   itemName,
-			Quantity:   quantity,
-			LootedFrom: lootedFrom,
+			LootedBy:       lootedBy,
+			ItemID:         itemID,
+			UniqueName:     h.resolveUniqueName(itemID),
+			ItemName:       itemName,
+			Quantity:       quantity,
+			LootedFrom:     lootedFrom,
+			EstimatedValue: h.GetEstimatedValue(itemID) * quantity,
 		})
 	}
 }
 
+// handleRewardGranted handles rewards from quests, activities and chests
+// Parameters: [0]=item IDs, [1]=item quantities, [2]=silver (FixPoint), [3]=fame (FixPoint)
+//
+// Reward fame is credited to the session immediately. The server also reflects it
+// in the next UpdateFame event, so it is remembered and deducted from that gain.
+func (h *AlbionHandler) handleRewardGranted(params map[byte]interface{}) {
+	itemIDs := getInt32Slice(params, 0)
+	quantities := getInt32Slice(params, 1)
+
+	// Silver and fame use FixPoint format (divide by 10000)
+	silver := int64(math.Floor(float64(getInt64(params, 2)) / 10000.0))
+	fame := int64(math.Floor(float64(getInt64(params, 3)) / 10000.0))
+
+	rewardItems := make([]RewardItem, 0, len(itemIDs))
+	for i, itemID := range itemIDs {
+		quantity := int32(1)
+		if i < len(quantities) {
+			quantity = quantities[i]
+		}
+		rewardItems = append(rewardItems, RewardItem{
+			ItemID:   itemID,
+			ItemName: h.resolveItemName(itemID),
+			Quantity: quantity,
+		})
+	}
+
+	if silver <= 0 && fame <= 0 && len(rewardItems) == 0 {
+		return
+	}
+
+	h.sessionMu.Lock()
+	if silver > 0 {
+		h.sessionSilver += silver
+	}
+	if fame > 0 {
+		h.sessionFame += fame
+		h.pendingRewardFame += fame
+	}
+	h.sessionLoot += len(rewardItems)
+	sessionSilver, sessionFame := h.sessionSilver, h.sessionFame
+	h.sessionMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("reward", "", &RewardEventData{
+		Silver:        max(silver, 0),
+		Fame:          max(fame, 0),
+		Items:         rewardItems,
+		SessionSilver: sessionSilver,
+		SessionFame:   sessionFame,
+	})
+}
+
+// resolveItemName returns the item name from the database, or a placeholder if unavailable
+func (h *AlbionHandler) resolveItemName(itemID int32) string {
+	if h.itemDB != nil && h.itemDB.IsLoaded() {
+		return h.itemDB.GetItemName(itemID)
+	}
+	return fmt.Sprintf("Item#%d", itemID)
+}
+
+// resolveUniqueName returns the item unique name from the database, or "" if unavailable
+func (h *AlbionHandler) resolveUniqueName(itemID int32) string {
+	if h.itemDB != nil && h.itemDB.IsLoaded() {
+		if info, ok := h.itemDB.GetByID(int(itemID)); ok {
+			return info.UniqueName
+		}
+	}
+	return ""
+}
+
 // handleNewLoot handles new loot available events (debug only, no callback)
 func (h *AlbionHandler) handleNewLoot(params map[byte]interface{}) {
 	// New loot events are informational only
 }
 
 // handleKilledPlayer handles player kill events
+// Parameters: [0]=killer ID, [1]=killer name, [2]=victim name
 func (h *AlbionHandler) handleKilledPlayer(params map[byte]interface{}) {
-	h.sessionKills++
+	isLocal, counts := h.involvesLocalPlayer(params, 0)
+
+	killer := getString(params, 1)
+	if isLocal && killer == "" {
+		_, killer = h.localPlayer()
+	}
+
+	h.sessionMu.Lock()
+	if counts {
+		h.sessionKills++
+	}
+	kills := h.sessionKills
+	h.sessionMu.Unlock()
 
 	// Message formatting is now handled by the frontend (TUI)
 	h.notifyEvent("kill", "", &KillEventData{
-		SessionKills: h.sessionKills,
+		SessionKills: kills,
+		Killer:       killer,
+		Victim:       getString(params, 2),
+		IsLocal:      isLocal,
 	})
 }
 
 // handleDied handles death events
+// Parameters: [0]=victim ID, [2]=victim name, [10]=killer name
 func (h *AlbionHandler) handleDied(params map[byte]interface{}) {
+	isLocal, counts := h.involvesLocalPlayer(params, 0)
+
 	victim := getString(params, 2)
 	killer := getString(params, 10)
 
+	if isLocal && victim == "" {
+		_, victim = h.localPlayer()
+	}
 	if victim == "" {
 		victim = "Someone"
 	}
 
-	// We only increment session deaths if WE died, but we don't have local player tracking yet.
-	// For now, let's just log the event.
-	h.sessionDeaths++
+	h.sessionMu.Lock()
+	if counts {
+		h.sessionDeaths++
+	}
+	deaths := h.sessionDeaths
+	h.sessionMu.Unlock()
 
 	// Message formatting is now handled by the frontend (TUI)
 	h.notifyEvent("death", "", &DeathEventData{
 		Victim:        victim,
 		Killer:        killer,
-		SessionDeaths: h.sessionDeaths,
+		SessionDeaths: deaths,
+		IsLocal:       isLocal,
 	})
 }
 
+// involvesLocalPlayer reports whether the object ID at idKey is the local player, and
+// whether the event counts for the session. Events that can't be attributed (local
+// player not known yet because capture started mid-zone, or no ID in the event) count.
+func (h *AlbionHandler) involvesLocalPlayer(params map[byte]interface{}, idKey byte) (isLocal, counts bool) {
+	localID := h.LocalPlayerID()
+	if _, hasID := params[idKey]; !hasID || localID == 0 {
+		return false, true
+	}
+	isLocal = getInt64(params, idKey) == localID
+	return isLocal, isLocal
+}
+
 // Helper functions to extract typed values from parameters
 func getInt64(params map[byte]interface{}, key byte) int64 {
-	if val, ok := params[key]; ok {
-		switch v := val.(type) {
-		case int64:
-			return v
-		case int32:
-			return int64(v)
-		case int16:
-			return int64(v)
-		case int:
-			return int64(v)
+	v, _ := getInt64Checked(params, key)
+	return v
+}
+
+func getInt32(params map[byte]interface{}, key byte) int32 {
+	v, _ := getInt32Checked(params, key)
+	return v
+}
+
+func getInt32Slice(params map[byte]interface{}, key byte) []int32 {
+	val, ok := params[key]
+	if !ok {
+		return nil
+	}
+	switch v := val.(type) {
+	case []int32:
+		return v
+	case []int16:
+		result := make([]int32, len(v))
+		for i, n := range v {
+			result[i] = int32(n)
+		}
+		return result
+	case []byte:
+		result := make([]int32, len(v))
+		for i, n := range v {
+			result[i] = int32(n)
 		}
+		return result
+	case []interface{}:
+		result := make([]int32, len(v))
+		for i, n := range v {
+			result[i] = int32(toInt64(n))
+		}
+		return result
 	}
-	return 0
+	return nil
 }
 
-func getInt32(params map[byte]interface{}, key byte) int32 {
+func getFloat32Slice(params map[byte]interface{}, key byte) []float32 {
+	val, ok := params[key]
+	if !ok {
+		return nil
+	}
+	switch v := val.(type) {
+	case []float32:
+		return v
+	case []interface{}:
+		result := make([]float32, len(v))
+		for i, n := range v {
+			switch f := n.(type) {
+			case float32:
+				result[i] = f
+			case float64:
+				result[i] = float32(f)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func getFloat32(params map[byte]interface{}, key byte) float32 {
 	if val, ok := params[key]; ok {
 		switch v := val.(type) {
-		case int32:
+		case float32:
 			return v
-		case int64:
-			return int32(v)
-		case int16:
-			return int32(v)
-		case int:
-			return int32(v)
+		case float64:
+			return float32(v)
+		case int16, int32, int64, int:
+			return float32(toInt64(v))
 		}
 	}
 	return 0
 }
 
 func getString(params map[byte]interface{}, key byte) string {
-	if val, ok := params[key]; ok {
-		if str, ok := val.(string); ok {
-			return str
+	v, _ := getStringChecked(params, key)
+	return v
+}
+
+func getStringSlice(params map[byte]interface{}, key byte) []string {
+	val, ok := params[key]
+	if !ok {
+		return nil
+	}
+	switch v := val.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				result = append(result, str)
+			}
 		}
+		return result
 	}
-	return ""
+	return nil
 }
 
 func getBool(params map[byte]interface{}, key byte) bool {
-	if val, ok := params[key]; ok {
-		if b, ok := val.(bool); ok {
-			return b
-		}
-	}
-	return false
+	v, _ := getBoolChecked(params, key)
+	return v
 }
 
 // formatSilver formats silver amount in a human-readable way
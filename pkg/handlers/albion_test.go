@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -295,6 +299,59 @@ func TestHandleOtherGrabbedLootSilver(t *testing.T) {
 	}
 }
 
+// grabSilver simulates an OtherGrabbedLoot silver event with a raw FixPoint amount
+func grabSilver(handler *AlbionHandler, raw int64) {
+	handler.OnEvent(0, map[byte]interface{}{
+		1:                     "Monster",
+		2:                     "Player1",
+		3:                     true,
+		5:                     raw,
+		events.ParamEventCode: int16(events.EventOtherGrabbedLoot),
+	})
+}
+
+// TestSilverThresholds tests that noise and absurd silver grabs are filtered
+func TestSilverThresholds(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	calls := 0
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "silver" {
+			calls++
+		}
+	})
+
+	grabSilver(handler, 5000)              // 0.5 silver: below threshold
+	grabSilver(handler, 1_000_000_000_000) // 100M silver: misparsed
+	grabSilver(handler, 12_340_000)        // 1234 silver: normal
+
+	if handler.GetSessionSilver() != 1234 {
+		t.Errorf("expected session silver 1234, got %d", handler.GetSessionSilver())
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 silver event, got %d", calls)
+	}
+	if handler.GetRejectedSilver() != 2 {
+		t.Errorf("expected 2 rejected grabs, got %d", handler.GetRejectedSilver())
+	}
+}
+
+// TestSetSilverThresholds tests custom silver thresholds
+func TestSetSilverThresholds(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetSilverThresholds(100, 0)
+
+	grabSilver(handler, 500_000)           // 50 silver: below custom minimum
+	grabSilver(handler, 1_000_000_000_000) // 100M silver: no upper limit
+
+	if handler.GetSessionSilver() != 100_000_000 {
+		t.Errorf("expected session silver 100000000, got %d", handler.GetSessionSilver())
+	}
+	if handler.GetRejectedSilver() != 1 {
+		t.Errorf("expected 1 rejected grab, got %d", handler.GetRejectedSilver())
+	}
+}
+
 // TestHandleOtherGrabbedLootItem tests item loot handling
 func TestHandleOtherGrabbedLootItem(t *testing.T) {
 	handler := NewAlbionHandler()
@@ -342,6 +399,171 @@ func TestHandleOtherGrabbedLootItem(t *testing.T) {
 	}
 }
 
+// TestHandleOtherGrabbedLootLargeQuantity tests that stacks above int32 max are not truncated
+func TestHandleOtherGrabbedLootLargeQuantity(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var receivedData *LootEventData
+	handler.SetEventCallback(func(eventType string, message string, data interface{}) {
+		if eventType == "loot" {
+			receivedData, _ = data.(*LootEventData)
+		}
+	})
+
+	const quantity = int64(math.MaxInt32) + 10
+	handler.OnEvent(0, map[byte]interface{}{
+		1:                     "Chest",
+		2:                     "Player1",
+		3:                     false,
+		4:                     int32(12345),
+		5:                     quantity,
+		events.ParamEventCode: int16(events.EventOtherGrabbedLoot),
+	})
+
+	if receivedData == nil {
+		t.Fatal("loot callback was not called")
+	}
+	if receivedData.Quantity != quantity {
+		t.Errorf("expected Quantity %d, got %d", quantity, receivedData.Quantity)
+	}
+}
+
+// TestHandleRewardGranted tests a mixed silver and item reward
+func TestHandleRewardGranted(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var receivedData *RewardEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "reward" {
+			receivedData = data.(*RewardEventData)
+		}
+	})
+
+	params := map[byte]interface{}{
+		0:                     []int32{1234, 5678}, // Item IDs
+		1:                     []int32{2, 1},       // Quantities
+		2:                     int64(15000000),     // Silver (1500 in FixPoint)
+		events.ParamEventCode: int16(events.EventRewardGranted),
+	}
+
+	handler.OnEvent(0, params)
+
+	if receivedData == nil {
+		t.Fatal("reward callback was not called")
+	}
+
+	if receivedData.Silver != 1500 {
+		t.Errorf("expected 1500 silver, got %d", receivedData.Silver)
+	}
+
+	if len(receivedData.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(receivedData.Items))
+	}
+
+	if receivedData.Items[0].ItemName != "Item#1234" || receivedData.Items[0].Quantity != 2 {
+		t.Errorf("expected 'Item#1234' x2, got '%s' x%d", receivedData.Items[0].ItemName, receivedData.Items[0].Quantity)
+	}
+
+	if receivedData.Items[1].ItemName != "Item#5678" || receivedData.Items[1].Quantity != 1 {
+		t.Errorf("expected 'Item#5678' x1, got '%s' x%d", receivedData.Items[1].ItemName, receivedData.Items[1].Quantity)
+	}
+
+	if handler.GetSessionSilver() != 1500 {
+		t.Errorf("expected session silver 1500, got %d", handler.GetSessionSilver())
+	}
+
+	if handler.GetSessionLoot() != 2 {
+		t.Errorf("expected session loot 2, got %d", handler.GetSessionLoot())
+	}
+}
+
+// TestHandleRewardGrantedEmptyIgnored tests that empty rewards are ignored
+func TestHandleRewardGrantedEmptyIgnored(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	callCount := 0
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		callCount++
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventRewardGranted),
+	})
+
+	if callCount != 0 {
+		t.Errorf("expected no callback for empty reward, got %d", callCount)
+	}
+}
+
+// TestHandleRewardGrantedFameNotDoubleCounted tests that reward fame is not counted
+// again when the following UpdateFame event reports it
+func TestHandleRewardGrantedFameNotDoubleCounted(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.totalFame = int64(40000000000) // 4M in FixPoint
+
+	var fameEvents []*FameEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "fame" {
+			fameEvents = append(fameEvents, data.(*FameEventData))
+		}
+	})
+
+	// Reward grants 300 fame
+	handler.OnEvent(0, map[byte]interface{}{
+		3:                     int64(3000000),
+		events.ParamEventCode: int16(events.EventRewardGranted),
+	})
+
+	if handler.GetSessionFame() != 300 {
+		t.Errorf("expected session fame 300 after reward, got %d", handler.GetSessionFame())
+	}
+
+	// Server reports the reward fame plus 200 fame from another source
+	handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{
+		0:                     int64(123456),
+		1:                     int64(40005000000), // 4M + 500 in FixPoint
+		events.ParamEventCode: int16(events.EventUpdateFame),
+	})
+
+	if handler.GetSessionFame() != 500 {
+		t.Errorf("expected session fame 500, got %d", handler.GetSessionFame())
+	}
+
+	if len(fameEvents) != 1 || fameEvents[0].Gained != 200 {
+		t.Errorf("expected one fame event with gained 200, got %v", fameEvents)
+	}
+}
+
+// TestHelperGetInt32Slice tests getInt32Slice helper
+func TestHelperGetInt32Slice(t *testing.T) {
+	params := map[byte]interface{}{
+		0: []int32{1, 2},
+		1: []int16{3, 4},
+		2: []byte{5, 6},
+		3: []interface{}{int32(7), int64(8)},
+		4: "not a slice",
+	}
+
+	tests := []struct {
+		key      byte
+		expected []int32
+	}{
+		{0, []int32{1, 2}},
+		{1, []int32{3, 4}},
+		{2, []int32{5, 6}},
+		{3, []int32{7, 8}},
+		{4, nil},
+		{99, nil},
+	}
+
+	for _, tt := range tests {
+		result := getInt32Slice(params, tt.key)
+		if fmt.Sprint(result) != fmt.Sprint(tt.expected) {
+			t.Errorf("getInt32Slice(%d): expected %v, got %v", tt.key, tt.expected, result)
+		}
+	}
+}
+
 // TestHandleKilledPlayer tests kill event handling
 func TestHandleKilledPlayer(t *testing.T) {
 	handler := NewAlbionHandler()
@@ -443,6 +665,99 @@ func TestDiscoveryModeTracking(t *testing.T) {
 	}
 }
 
+// TestHandledEventCodes tests that the accessor matches the codes dispatched by OnEvent
+func TestHandledEventCodes(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	expected := []int32{
+		int32(events.EventLeave),
+		int32(events.EventMove),
+		int32(events.EventInCombatStateUpdate),
+		int32(events.EventCastHit),
+		int32(events.EventCastHits),
+		int32(events.EventJoinFinished),
+		int32(events.EventClusterInfoUpdate),
+		int32(events.EventHealthUpdate),
+		int32(events.EventKilledPlayer),
+		int32(events.EventDied),
+		int32(events.EventNewCharacter),
+		int32(events.EventUpdateMoney),
+		int32(events.EventUpdateFame),
+		int32(events.EventNewLoot),
+		int32(events.EventOtherGrabbedLoot),
+		int32(events.EventRewardGranted),
+		int32(events.EventForcedMovement),
+		int32(events.EventForcedMovementCancel),
+		int32(events.EventCloak),
+		int32(events.EventBatchUseItemStart),
+		int32(events.EventBatchUseItemEnd),
+		int32(events.EventUseFunction),
+		int32(events.EventNewLootChest),
+		int32(events.EventUpdateLootChest),
+		int32(events.EventLootChestOpened),
+		int32(events.EventEstimatedMarketValueUpdate),
+		int32(events.EventTransformation),
+		int32(events.EventTransformationEnd),
+		int32(events.EventNewBuilding),
+		int32(events.EventPlayerBuildingInfo),
+		int32(events.EventNewMountObject),
+		int32(events.EventMountHealthUpdate),
+		int32(events.EventMountCooldownUpdate),
+		int32(events.EventInvitedToGuild),
+		int32(events.EventPartyInvitation),
+		int32(events.EventFriendRequest),
+		int32(events.EventStartMatch),
+		int32(events.EventMatchUpdate),
+		int32(events.EventEndArenaMatch),
+		int32(events.EventMatchPlayerStatsEvent),
+		int32(events.EventPartyJoined),
+		int32(events.EventPartyPlayerJoined),
+		int32(events.EventPartyPlayerLeft),
+		int32(events.EventPartyDisbanded),
+		int32(events.EventChatMessage),
+		int32(events.EventChatSay),
+		int32(events.EventChatWhisper),
+		int32(events.EventHarvestFinished),
+		int32(events.EventFishingCatch),
+		int32(events.EventFishingFinished),
+		int32(events.EventObjectEvent),
+		int32(events.EventPlayerTradeFinished),
+		int32(events.EventNewMob),
+	}
+	slices.Sort(expected)
+
+	codes := handler.HandledEventCodes()
+	if !slices.Equal(codes, expected) {
+		t.Errorf("expected handled codes %v, got %v", expected, codes)
+	}
+
+	if !slices.IsSorted(codes) {
+		t.Errorf("expected sorted codes, got %v", codes)
+	}
+}
+
+// TestDiscoveryModeHandledFlag tests that discovery output marks handled codes
+func TestDiscoveryModeHandledFlag(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	for _, code := range handler.HandledEventCodes() {
+		handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: code})
+	}
+	handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventTeleport)})
+
+	discovered := handler.GetDiscoveredEvents()
+	for _, code := range handler.HandledEventCodes() {
+		if event, ok := discovered[code]; !ok || !event.Handled {
+			t.Errorf("code %d should be discovered as handled", code)
+		}
+	}
+
+	if event, ok := discovered[int32(events.EventTeleport)]; !ok || event.Handled {
+		t.Errorf("code %d should be discovered as not handled", events.EventTeleport)
+	}
+}
+
 // TestDiscoveryModeParamTypes tests that param types are recorded correctly
 func TestDiscoveryModeParamTypes(t *testing.T) {
 	handler := NewAlbionHandler()
@@ -497,6 +812,53 @@ func TestSaveDiscoveredEvents(t *testing.T) {
 	}
 }
 
+// TestSaveDiscoveredEventsStable tests that saves are ordered by code and byte-stable
+func TestSaveDiscoveredEventsStable(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	// Codes whose string order differs from their numeric order
+	for _, code := range []byte{100, 9, 10, 200, 21} {
+		handler.OnEvent(code, map[byte]interface{}{1: int32(code), 10: "x", 2: true})
+	}
+
+	tmpDir := t.TempDir()
+	var saves [][]byte
+	for i := 0; i < 5; i++ {
+		filename := filepath.Join(tmpDir, fmt.Sprintf("discovered_%d.json", i))
+		if err := handler.SaveDiscoveredEvents(filename); err != nil {
+			t.Fatalf("SaveDiscoveredEvents failed: %v", err)
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		saves = append(saves, data)
+	}
+
+	for i := 1; i < len(saves); i++ {
+		if string(saves[i]) != string(saves[0]) {
+			t.Fatalf("save %d differs from the first save", i)
+		}
+	}
+
+	var output struct {
+		Events []struct {
+			Code int32 `json:"code"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(saves[0], &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var codes []int32
+	for _, event := range output.Events {
+		codes = append(codes, event.Code)
+	}
+	if expected := []int32{9, 10, 21, 100, 200}; !slices.Equal(codes, expected) {
+		t.Errorf("expected codes %v, got %v", expected, codes)
+	}
+}
+
 // TestGetDiscoveredEventsReturnsCopy tests that GetDiscoveredEvents returns a copy
 func TestGetDiscoveredEventsReturnsCopy(t *testing.T) {
 	handler := NewAlbionHandler()
@@ -519,22 +881,24 @@ func TestGetDiscoveredEventsReturnsCopy(t *testing.T) {
 func TestIsKnownEventCode(t *testing.T) {
 	handler := NewAlbionHandler()
 
-	knownCodes := []int16{
-		int16(events.EventUpdateFame),
-		int16(events.EventKilledPlayer),
-		int16(events.EventDied),
-		int16(events.EventOtherGrabbedLoot),
+	// Every code dispatched by OnEvent must report as known
+	for code := range eventHandlers {
+		if !handler.isKnownEventCode(int32(code)) {
+			t.Errorf("code %d (%v) should be known", code, code)
+		}
 	}
 
-	for _, code := range knownCodes {
-		if !handler.isKnownEventCode(code) {
-			t.Errorf("code %d should be known", code)
-		}
+	// Codes without a dedicated handler report as unknown
+	unhandledCodes := []int32{
+		int32(events.EventTeleport),
+		int32(events.EventChangeEquipment),
+		9999,
 	}
 
-	// Test unknown code
-	if handler.isKnownEventCode(9999) {
-		t.Error("code 9999 should be unknown")
+	for _, code := range unhandledCodes {
+		if handler.isKnownEventCode(code) {
+			t.Errorf("code %d should be unknown", code)
+		}
 	}
 }
 
@@ -580,6 +944,29 @@ func TestOnEventParamEventCodeConversion(t *testing.T) {
 	}
 }
 
+// TestOnEventLargeParamEventCode tests that codes beyond int16 range are not wrapped
+func TestOnEventLargeParamEventCode(t *testing.T) {
+	// Truncated to int16, this code would wrap around to EventKilledPlayer
+	code := int32(events.EventKilledPlayer) + 1<<16
+
+	for _, codeVal := range []interface{}{code, int64(code)} {
+		h := NewAlbionHandler()
+		h.SetDiscoveryMode(true)
+		h.OnEvent(0, map[byte]interface{}{events.ParamEventCode: codeVal})
+
+		if h.GetSessionKills() != 0 {
+			t.Errorf("%T: code %d was dispatched as EventKilledPlayer", codeVal, code)
+		}
+		event, ok := h.GetDiscoveredEvents()[code]
+		if !ok {
+			t.Fatalf("%T: expected code %d to be recorded", codeVal, code)
+		}
+		if event.Code != code || event.Handled {
+			t.Errorf("%T: expected unhandled code %d, got %d (handled %v)", codeVal, code, event.Code, event.Handled)
+		}
+	}
+}
+
 // TestConcurrentDiscoveryAccess tests thread safety of discovery mode
 func TestConcurrentDiscoveryAccess(t *testing.T) {
 	handler := NewAlbionHandler()
@@ -610,6 +997,65 @@ func TestConcurrentDiscoveryAccess(t *testing.T) {
 	// If we get here without race conditions, the test passes
 }
 
+// TestConcurrentSessionCounters tests that session counters are consistent when events
+// are handled on several goroutines while the getters are called (run with -race)
+func TestConcurrentSessionCounters(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{})
+				handler.OnEvent(byte(events.EventDied), map[byte]interface{}{})
+				handler.OnEvent(0, map[byte]interface{}{
+					events.ParamEventCode: int16(events.EventOtherGrabbedLoot),
+					3:                     true,
+					5:                     int64(100_000), // 10 silver
+				})
+				handler.OnEvent(0, map[byte]interface{}{
+					events.ParamEventCode: int16(events.EventUpdateFame),
+					1:                     int64(1_000_000 + (worker*50+j)*10_000),
+					2:                     int64(10_000),
+				})
+			}
+		}(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = handler.GetSessionFame()
+				_ = handler.GetSessionSilver()
+				_ = handler.GetSessionKills()
+				_ = handler.GetSessionDeaths()
+				_ = handler.GetSessionLoot()
+				_ = handler.GetZoneStats()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if handler.GetSessionKills() != 400 {
+		t.Errorf("expected 400 kills, got %d", handler.GetSessionKills())
+	}
+	if handler.GetSessionDeaths() != 400 {
+		t.Errorf("expected 400 deaths, got %d", handler.GetSessionDeaths())
+	}
+	if handler.GetSessionSilver() != 4000 {
+		t.Errorf("expected 4000 silver, got %d", handler.GetSessionSilver())
+	}
+	// Out-of-order fame totals are dropped, but at least one gain is always counted
+	if fame := handler.GetSessionFame(); fame < 1 || fame > 400 {
+		t.Errorf("expected between 1 and 400 fame, got %d", fame)
+	}
+}
+
 // TestHelperGetInt64 tests the getInt64 helper function
 func TestHelperGetInt64(t *testing.T) {
 	params := map[byte]interface{}{
@@ -755,8 +1201,19 @@ func TestOnRequestDebugMode(t *testing.T) {
 	handler := NewAlbionHandler()
 	handler.SetDebug(true)
 
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		messages = append(messages, message)
+	})
+
 	// Should not panic
 	handler.OnRequest(1, map[byte]interface{}{1: "test"})
+	handler.OnRequest(1, map[byte]interface{}{events.ParamOperationCode: int16(events.OpMove)})
+
+	expected := []string{"Request Ping (1 params)", "Request Move (1 params)"}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("expected %q, got %q", expected, messages)
+	}
 }
 
 // TestOnResponseDebugMode tests response handling in debug mode
@@ -764,8 +1221,19 @@ func TestOnResponseDebugMode(t *testing.T) {
 	handler := NewAlbionHandler()
 	handler.SetDebug(true)
 
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		messages = append(messages, message)
+	})
+
 	// Should not panic
 	handler.OnResponse(1, 0, "debug message", map[byte]interface{}{1: "test"})
+	handler.OnResponse(1, 3, "", map[byte]interface{}{events.ParamOperationCode: int16(9999)})
+
+	expected := []string{"Response Ping: return 0 (1 params): debug message", "Response Unknown(9999): return 3 (1 params)"}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("expected %q, got %q", expected, messages)
+	}
 }
 
 // TestFameEventDataStructure tests the FameEventData struct fields
@@ -835,3 +1303,125 @@ func TestDiscoveredEventStructure(t *testing.T) {
 		t.Errorf("ParamTypes field incorrect")
 	}
 }
+
+// TestPlayerNameCache tests that NewCharacter names are resolved by ID until the player leaves
+func TestPlayerNameCache(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(42), 1: "Nearby"})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(43), 1: "Other"})
+
+	if name := handler.GetPlayerName(42); name != "Nearby" {
+		t.Errorf("expected Nearby, got %q", name)
+	}
+
+	handler.OnEvent(byte(events.EventLeave), map[byte]interface{}{0: int64(42)})
+	if name := handler.GetPlayerName(42); name != "" {
+		t.Errorf("expected empty name after leave, got %q", name)
+	}
+	if name := handler.GetPlayerName(43); name != "Other" {
+		t.Errorf("expected Other to stay cached, got %q", name)
+	}
+
+	// Leave events without an object ID don't evict anyone
+	handler.OnEvent(byte(events.EventLeave), map[byte]interface{}{})
+	if name := handler.GetPlayerName(43); name != "Other" {
+		t.Errorf("expected Other to stay cached, got %q", name)
+	}
+}
+
+// TestPlayerNameCacheBounded tests that the cache never grows beyond maxTrackedPlayers
+func TestPlayerNameCacheBounded(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	for i := 0; i <= maxTrackedPlayers; i++ {
+		handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(i), 1: fmt.Sprintf("Player%d", i)})
+	}
+
+	handler.playersMu.RLock()
+	size := len(handler.players)
+	handler.playersMu.RUnlock()
+	if size > maxTrackedPlayers {
+		t.Errorf("expected at most %d players, got %d", maxTrackedPlayers, size)
+	}
+	if name := handler.GetPlayerName(int64(maxTrackedPlayers)); name == "" {
+		t.Error("expected the latest player to be cached")
+	}
+}
+
+// TestKillDeathAttribution tests that only the local player's kills and deaths count once it is known
+func TestKillDeathAttribution(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{0: int64(100), 2: "Me"})
+
+	var kills []*KillEventData
+	var deaths []*DeathEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		switch d := data.(type) {
+		case *KillEventData:
+			kills = append(kills, d)
+		case *DeathEventData:
+			deaths = append(deaths, d)
+		}
+	})
+
+	handler.OnEvent(byte(events.EventDied), map[byte]interface{}{0: int64(200), 2: "Other", 10: "Bandit"})
+	handler.OnEvent(byte(events.EventDied), map[byte]interface{}{0: int64(100), 10: "Bandit"})
+	handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{0: int64(100), 2: "Bandit"})
+	handler.OnEvent(byte(events.EventKilledPlayer), map[byte]interface{}{0: int64(300), 1: "Bandit", 2: "Other"})
+
+	if handler.GetSessionDeaths() != 1 || handler.GetSessionKills() != 1 {
+		t.Errorf("expected 1 death and 1 kill, got %d and %d", handler.GetSessionDeaths(), handler.GetSessionKills())
+	}
+	if len(deaths) != 2 || deaths[0].IsLocal || deaths[0].Victim != "Other" {
+		t.Fatalf("expected another player's death first, got %+v", deaths)
+	}
+	if !deaths[1].IsLocal || deaths[1].Victim != "Me" || deaths[1].SessionDeaths != 1 {
+		t.Errorf("expected the local player's death, got %+v", deaths[1])
+	}
+	if len(kills) != 2 || !kills[0].IsLocal || kills[0].Killer != "Me" || kills[0].Victim != "Bandit" {
+		t.Fatalf("expected the local player's kill first, got %+v", kills)
+	}
+	if kills[1].IsLocal || kills[1].Killer != "Bandit" || kills[1].SessionKills != 1 {
+		t.Errorf("expected another player's kill, got %+v", kills[1])
+	}
+}
+
+// TestFameThreshold tests that the fame total threshold is configurable and dedup works without it
+func TestFameThreshold(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDebug(true)
+
+	var gains []int64
+	var debugMessages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		switch eventType {
+		case "fame":
+			gains = append(gains, data.(*FameEventData).Gained)
+		case "debug":
+			debugMessages = append(debugMessages, message)
+		}
+	})
+	lowFame := func(total, gained int64) {
+		handler.OnEvent(byte(events.EventUpdateFame), map[byte]interface{}{0: int64(1), 1: total, 2: gained})
+	}
+
+	// 50 fame total is below the default threshold (100 fame)
+	lowFame(500000, 200000)
+	if len(gains) != 0 || len(debugMessages) != 1 {
+		t.Fatalf("expected the event to be ignored with a debug message, got %v and %v", gains, debugMessages)
+	}
+
+	handler.SetFameThreshold(0)
+	lowFame(500000, 200000)
+	lowFame(500000, 200000) // Duplicate (same total)
+	lowFame(0, 0)           // No total
+	lowFame(700000, 200000)
+
+	if len(gains) != 2 || gains[0] != 20 || gains[1] != 20 {
+		t.Errorf("expected two gains of 20 fame, got %v", gains)
+	}
+	if handler.GetSessionFame() != 40 {
+		t.Errorf("expected 40 session fame, got %d", handler.GetSessionFame())
+	}
+}
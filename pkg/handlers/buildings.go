@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxTrackedBuildings bounds the building registry
+const maxTrackedBuildings = 500
+
+// Building types, derived from the building unique name
+const (
+	BuildingTypeHideout = "hideout"
+	BuildingTypeOther   = "other"
+)
+
+// Building is a building seen in the current zone (hideouts, island and territory buildings)
+type Building struct {
+	ObjectID   int64   // Object ID of the building
+	UniqueName string  // Building unique name (e.g., "HIDEOUT_T8")
+	Type       string  // One of the BuildingType* values
+	Owner      string  // Owner player name, empty until PlayerBuildingInfo is received
+	Guild      string  // Owner guild name
+	Alliance   string  // Owner alliance tag
+	PosX       float32 // World position X
+	PosY       float32 // World position Y
+}
+
+// GetBuildings returns the tracked buildings ordered by object ID
+func (h *AlbionHandler) GetBuildings() []Building {
+	h.buildingsMu.Lock()
+	defer h.buildingsMu.Unlock()
+
+	buildings := make([]Building, 0, len(h.buildings))
+	for _, building := range h.buildings {
+		buildings = append(buildings, *building)
+	}
+	slices.SortFunc(buildings, func(a, b Building) int {
+		return cmp.Compare(a.ObjectID, b.ObjectID)
+	})
+	return buildings
+}
+
+// handleNewBuilding handles a building coming into view
+// Parameters: [0]=object ID, [1]=unique name, [3]=position [x, y]
+func (h *AlbionHandler) handleNewBuilding(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	uniqueName := getString(params, 1)
+
+	h.buildingsMu.Lock()
+	defer h.buildingsMu.Unlock()
+
+	building, known := h.buildings[objectID]
+	if !known {
+		// Buildings are only seen in the current zone; once the registry
+		// fills up, the oldest entries belong to zones left long ago
+		if len(h.buildings) >= maxTrackedBuildings {
+			clear(h.buildings)
+		}
+		building = &Building{ObjectID: objectID}
+		h.buildings[objectID] = building
+	}
+
+	building.UniqueName = uniqueName
+	building.Type = buildingType(uniqueName)
+	if pos := getFloat32Slice(params, 3); len(pos) >= 2 {
+		building.PosX, building.PosY = pos[0], pos[1]
+	}
+}
+
+// handlePlayerBuildingInfo handles the ownership details of a player building
+// Parameters: [0]=object ID, [1]=owner name, [2]=guild name, [3]=alliance tag
+func (h *AlbionHandler) handlePlayerBuildingInfo(params map[byte]interface{}) {
+	h.buildingsMu.Lock()
+	building, ok := h.buildings[getInt64(params, 0)]
+	if !ok {
+		h.buildingsMu.Unlock()
+		return
+	}
+
+	firstInfo := building.Owner == "" && building.Guild == ""
+	building.Owner = getString(params, 1)
+	building.Guild = getString(params, 2)
+	building.Alliance = getString(params, 3)
+	snapshot := *building
+	h.buildingsMu.Unlock()
+
+	// Only hideouts are reported, and only once, to keep the event log quiet
+	if !firstInfo || snapshot.Type != BuildingTypeHideout {
+		return
+	}
+
+	owner := snapshot.Guild
+	if owner == "" {
+		owner = snapshot.Owner
+	}
+	if snapshot.Alliance != "" {
+		owner = fmt.Sprintf("%s [%s]", owner, snapshot.Alliance)
+	}
+
+	h.notifyEvent("info", fmt.Sprintf("🏰 Hideout of %s in view", owner), &snapshot)
+}
+
+// buildingType derives the building type from its unique name
+func buildingType(uniqueName string) string {
+	if strings.Contains(strings.ToUpper(uniqueName), "HIDEOUT") {
+		return BuildingTypeHideout
+	}
+	return BuildingTypeOther
+}
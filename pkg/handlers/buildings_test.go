@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newBuildingTestHandler creates a handler that records info events with building data
+func newBuildingTestHandler() (*AlbionHandler, *[]string) {
+	handler := NewAlbionHandler()
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if _, ok := data.(*Building); ok && eventType == "info" {
+			messages = append(messages, message)
+		}
+	})
+	return handler, &messages
+}
+
+// sendBuilding sends a NewBuilding and a PlayerBuildingInfo event
+func sendBuilding(handler *AlbionHandler, objectID int64, uniqueName, owner, guild, alliance string) {
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     objectID,
+		1:                     uniqueName,
+		3:                     []interface{}{float32(100), float32(-50)},
+		events.ParamEventCode: int16(events.EventNewBuilding),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     objectID,
+		1:                     owner,
+		2:                     guild,
+		3:                     alliance,
+		events.ParamEventCode: int16(events.EventPlayerBuildingInfo),
+	})
+}
+
+// TestBuildingRegistry tests registering a hideout and an island building
+func TestBuildingRegistry(t *testing.T) {
+	handler, messages := newBuildingTestHandler()
+
+	sendBuilding(handler, 20, "PLAYERISLAND_FARM", "Farmer", "", "")
+	sendBuilding(handler, 10, "HIDEOUT_T8", "Leader", "Enemy Guild", "ENMY")
+
+	buildings := handler.GetBuildings()
+	if len(buildings) != 2 {
+		t.Fatalf("expected 2 buildings, got %d", len(buildings))
+	}
+
+	hideout := buildings[0]
+	if hideout.ObjectID != 10 || hideout.Type != BuildingTypeHideout {
+		t.Errorf("unexpected hideout: %+v", hideout)
+	}
+	if hideout.Owner != "Leader" || hideout.Guild != "Enemy Guild" || hideout.Alliance != "ENMY" {
+		t.Errorf("expected owner Leader of Enemy Guild [ENMY], got %+v", hideout)
+	}
+	if hideout.PosX != 100 || hideout.PosY != -50 {
+		t.Errorf("expected position (100, -50), got (%v, %v)", hideout.PosX, hideout.PosY)
+	}
+
+	farm := buildings[1]
+	if farm.ObjectID != 20 || farm.Type != BuildingTypeOther || farm.Owner != "Farmer" {
+		t.Errorf("unexpected farm: %+v", farm)
+	}
+
+	// Only the hideout is reported
+	if len(*messages) != 1 {
+		t.Fatalf("expected 1 info event, got %d: %v", len(*messages), *messages)
+	}
+	if expected := "🏰 Hideout of Enemy Guild [ENMY] in view"; (*messages)[0] != expected {
+		t.Errorf("expected %q, got %q", expected, (*messages)[0])
+	}
+}
+
+// TestBuildingInfoReportedOnce tests that repeated ownership info does not emit again
+func TestBuildingInfoReportedOnce(t *testing.T) {
+	handler, messages := newBuildingTestHandler()
+
+	sendBuilding(handler, 10, "HIDEOUT_T8", "Leader", "Enemy Guild", "")
+	sendBuilding(handler, 10, "HIDEOUT_T8", "Leader", "Enemy Guild", "")
+
+	if len(*messages) != 1 {
+		t.Errorf("expected 1 info event, got %d: %v", len(*messages), *messages)
+	}
+}
+
+// TestPlayerBuildingInfoUnknownBuilding tests that info for an unseen building is ignored
+func TestPlayerBuildingInfoUnknownBuilding(t *testing.T) {
+	handler, messages := newBuildingTestHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(99),
+		1:                     "Leader",
+		events.ParamEventCode: int16(events.EventPlayerBuildingInfo),
+	})
+
+	if len(handler.GetBuildings()) != 0 || len(*messages) != 0 {
+		t.Errorf("expected unknown building to be ignored, got %v and %v", handler.GetBuildings(), *messages)
+	}
+}
+
+// TestConcurrentBuildingAccess tests reading buildings while events update them (run with -race)
+func TestConcurrentBuildingAccess(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			handler.OnEvent(0, map[byte]interface{}{
+				0:                     int64(i),
+				1:                     "HIDEOUT_T8",
+				events.ParamEventCode: int16(events.EventNewBuilding),
+			})
+			handler.OnEvent(0, map[byte]interface{}{0: int64(i), 1: "Owner", events.ParamEventCode: int16(events.EventPlayerBuildingInfo)})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = handler.GetBuildings()
+	}
+	wg.Wait()
+
+	if n := len(handler.GetBuildings()); n > maxTrackedBuildings {
+		t.Errorf("expected at most %d buildings, got %d", maxTrackedBuildings, n)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+// Chat channels
+const (
+	ChatSay      = "say"
+	ChatWhisper  = "whisper"
+	ChatGuild    = "guild"
+	ChatAlliance = "alliance"
+	ChatParty    = "party"
+	ChatWorld    = "world"
+)
+
+// chatChannelTypes maps the channel type of ChatMessage events to a chat channel
+var chatChannelTypes = map[int64]string{
+	1: ChatGuild,
+	2: ChatAlliance,
+	3: ChatParty,
+}
+
+// ChatEventData contains chat message event data
+type ChatEventData struct {
+	Sender    string // Name of the player who sent the message
+	Recipient string // Name of the recipient (whispers only)
+	Channel   string // One of the Chat* channels
+	Text      string // Message text
+}
+
+// handleChatMessage handles a message in a chat channel (guild, alliance, party, world)
+// Parameters: [0]=sender name, [1]=text, [2]=channel type
+func (h *AlbionHandler) handleChatMessage(params map[byte]interface{}) {
+	channel, ok := chatChannelTypes[toInt64(params[2])]
+	if !ok {
+		channel = ChatWorld
+	}
+	h.notifyChat(&ChatEventData{
+		Sender:  getString(params, 0),
+		Channel: channel,
+		Text:    getString(params, 1),
+	})
+}
+
+// handleChatSay handles a message said by a nearby player
+// Parameters: [0]=sender object ID, [1]=sender name, [2]=text
+func (h *AlbionHandler) handleChatSay(params map[byte]interface{}) {
+	h.notifyChat(&ChatEventData{
+		Sender:  h.resolvePlayerName(getString(params, 1), params, 0),
+		Channel: ChatSay,
+		Text:    getString(params, 2),
+	})
+}
+
+// handleChatWhisper handles a private message
+// Parameters: [0]=sender name, [1]=recipient name, [2]=text
+func (h *AlbionHandler) handleChatWhisper(params map[byte]interface{}) {
+	h.notifyChat(&ChatEventData{
+		Sender:    getString(params, 0),
+		Recipient: getString(params, 1),
+		Channel:   ChatWhisper,
+		Text:      getString(params, 2),
+	})
+}
+
+// notifyChat emits a chat event, skipping messages without text
+func (h *AlbionHandler) notifyChat(data *ChatEventData) {
+	if data.Text == "" {
+		return
+	}
+	if data.Sender == "" {
+		data.Sender = "Someone"
+	}
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("chat", "", data)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newChatTestHandler creates a handler that records chat event data
+func newChatTestHandler() (*AlbionHandler, *[]*ChatEventData) {
+	handler := NewAlbionHandler()
+
+	var received []*ChatEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if chat, ok := data.(*ChatEventData); ok && eventType == "chat" {
+			received = append(received, chat)
+		}
+	})
+
+	return handler, &received
+}
+
+// sendChatEvent sends a chat event with the given parameters
+func sendChatEvent(handler *AlbionHandler, code events.EventCode, params map[byte]interface{}) {
+	params[events.ParamEventCode] = int16(code)
+	handler.OnEvent(0, params)
+}
+
+// TestChatMessageChannels tests that channel messages are tagged with their channel
+func TestChatMessageChannels(t *testing.T) {
+	tests := []struct {
+		channelType byte
+		expected    string
+	}{
+		{1, ChatGuild},
+		{2, ChatAlliance},
+		{3, ChatParty},
+		{0, ChatWorld},
+	}
+
+	for _, tt := range tests {
+		handler, received := newChatTestHandler()
+		sendChatEvent(handler, events.EventChatMessage, map[byte]interface{}{
+			0: "Sender",
+			1: "hello",
+			2: tt.channelType,
+		})
+
+		if len(*received) != 1 {
+			t.Fatalf("channel %d: expected 1 chat event, got %d", tt.channelType, len(*received))
+		}
+		got := (*received)[0]
+		if got.Channel != tt.expected || got.Sender != "Sender" || got.Text != "hello" {
+			t.Errorf("channel %d: expected %s message from Sender, got %+v", tt.channelType, tt.expected, got)
+		}
+	}
+}
+
+// TestChatSay tests that say messages resolve the sender from tracked players
+func TestChatSay(t *testing.T) {
+	handler, received := newChatTestHandler()
+
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{
+		0: int64(555),
+		1: "Nearby",
+	})
+	sendChatEvent(handler, events.EventChatSay, map[byte]interface{}{
+		0: int64(555),
+		2: "hey",
+	})
+
+	if len(*received) != 1 || (*received)[0].Sender != "Nearby" || (*received)[0].Channel != ChatSay {
+		t.Errorf("expected say message from Nearby, got %+v", *received)
+	}
+}
+
+// TestChatWhisper tests that whispers carry both the sender and the recipient
+func TestChatWhisper(t *testing.T) {
+	handler, received := newChatTestHandler()
+
+	sendChatEvent(handler, events.EventChatWhisper, map[byte]interface{}{
+		0: "Sender",
+		1: "Recipient",
+		2: "psst",
+	})
+
+	if len(*received) != 1 {
+		t.Fatalf("expected 1 chat event, got %d", len(*received))
+	}
+	got := (*received)[0]
+	if got.Channel != ChatWhisper || got.Sender != "Sender" || got.Recipient != "Recipient" || got.Text != "psst" {
+		t.Errorf("expected whisper from Sender to Recipient, got %+v", got)
+	}
+}
+
+// TestChatEmptyText tests that messages without text are ignored
+func TestChatEmptyText(t *testing.T) {
+	handler, received := newChatTestHandler()
+
+	sendChatEvent(handler, events.EventChatWhisper, map[byte]interface{}{0: "Sender"})
+
+	if len(*received) != 0 {
+		t.Errorf("expected no chat events, got %+v", *received)
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// maxTrackedChests bounds the chest registry
+const maxTrackedChests = 200
+
+// Chest actions reported in ChestEventData
+const (
+	ChestSpawned = "spawned"
+	ChestOpened  = "opened"
+)
+
+// Chest types, derived from the chest unique name
+const (
+	ChestTypeStandard  = "standard"
+	ChestTypeUncommon  = "uncommon"
+	ChestTypeRare      = "rare"
+	ChestTypeLegendary = "legendary"
+)
+
+// Chest is a loot chest tracked in the current zone
+type Chest struct {
+	ObjectID    int64   // Object ID of the chest
+	UniqueName  string  // Chest unique name (e.g., "AVALON_CHEST_RARE")
+	Type        string  // One of the ChestType* values
+	ChargeLevel int     // Charge level (higher levels hold better loot)
+	PosX        float32 // World position X
+	PosY        float32 // World position Y
+	Opened      bool    // Whether the chest has been opened
+}
+
+// ChestEventData contains loot chest event data
+type ChestEventData struct {
+	Action string // ChestSpawned or ChestOpened
+	Chest  Chest  // Chest state at the time of the event
+}
+
+// GetChests returns the tracked chests ordered by object ID
+func (h *AlbionHandler) GetChests() []Chest {
+	h.chestsMu.Lock()
+	defer h.chestsMu.Unlock()
+
+	chests := make([]Chest, 0, len(h.chests))
+	for _, chest := range h.chests {
+		chests = append(chests, *chest)
+	}
+	slices.SortFunc(chests, func(a, b Chest) int {
+		return cmp.Compare(a.ObjectID, b.ObjectID)
+	})
+	return chests
+}
+
+// handleNewLootChest handles a chest spawning (or coming into view)
+// Parameters: [0]=object ID, [1]=position [x, y], [3]=unique name, [5]=charge level
+func (h *AlbionHandler) handleNewLootChest(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	uniqueName := getString(params, 3)
+
+	chest := &Chest{
+		ObjectID:    objectID,
+		UniqueName:  uniqueName,
+		Type:        chestType(uniqueName),
+		ChargeLevel: int(getInt32(params, 5)),
+	}
+	if pos := getFloat32Slice(params, 1); len(pos) >= 2 {
+		chest.PosX, chest.PosY = pos[0], pos[1]
+	}
+
+	h.chestsMu.Lock()
+	if _, known := h.chests[objectID]; !known && len(h.chests) >= maxTrackedChests {
+		// Leave events were missed (e.g. capture started mid-zone), so start over
+		clear(h.chests)
+	}
+	h.chests[objectID] = chest
+	h.chestsMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("chest", "", &ChestEventData{Action: ChestSpawned, Chest: *chest})
+}
+
+// handleUpdateLootChest handles a chest state change
+// Parameters: [0]=object ID, [1]=charge level
+func (h *AlbionHandler) handleUpdateLootChest(params map[byte]interface{}) {
+	h.chestsMu.Lock()
+	defer h.chestsMu.Unlock()
+
+	chest, ok := h.chests[getInt64(params, 0)]
+	if !ok {
+		return
+	}
+	if _, hasCharge := params[1]; hasCharge {
+		chest.ChargeLevel = int(getInt32(params, 1))
+	}
+}
+
+// handleLootChestOpened handles a chest being opened
+// Parameters: [0]=object ID
+func (h *AlbionHandler) handleLootChestOpened(params map[byte]interface{}) {
+	h.chestsMu.Lock()
+	chest, ok := h.chests[getInt64(params, 0)]
+	if !ok || chest.Opened {
+		h.chestsMu.Unlock()
+		return
+	}
+	chest.Opened = true
+	opened := *chest
+	h.chestsMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("chest", "", &ChestEventData{Action: ChestOpened, Chest: opened})
+}
+
+// clearChests forgets the chests of the zone being left
+func (h *AlbionHandler) clearChests() {
+	h.chestsMu.Lock()
+	defer h.chestsMu.Unlock()
+	clear(h.chests)
+}
+
+// chestType derives the chest type from its unique name
+func chestType(uniqueName string) string {
+	name := strings.ToUpper(uniqueName)
+	switch {
+	case strings.Contains(name, "LEGENDARY"):
+		return ChestTypeLegendary
+	case strings.Contains(name, "RARE"):
+		return ChestTypeRare
+	case strings.Contains(name, "UNCOMMON"):
+		return ChestTypeUncommon
+	}
+	return ChestTypeStandard
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestLootChestLifecycle tests registering, updating and opening a chest
+func TestLootChestLifecycle(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received []*ChestEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "chest" {
+			received = append(received, data.(*ChestEventData))
+		}
+	})
+
+	// Chest spawns
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(42),
+		1:                     []interface{}{float32(10.5), float32(-3)},
+		3:                     "AVALON_CHEST_RARE",
+		5:                     int32(1),
+		events.ParamEventCode: int16(events.EventNewLootChest),
+	})
+
+	chests := handler.GetChests()
+	if len(chests) != 1 {
+		t.Fatalf("expected 1 chest, got %d", len(chests))
+	}
+	chest := chests[0]
+	if chest.ObjectID != 42 || chest.Type != ChestTypeRare || chest.ChargeLevel != 1 {
+		t.Errorf("unexpected chest: %+v", chest)
+	}
+	if chest.PosX != 10.5 || chest.PosY != -3 {
+		t.Errorf("expected position (10.5, -3), got (%v, %v)", chest.PosX, chest.PosY)
+	}
+	if len(received) != 1 || received[0].Action != ChestSpawned {
+		t.Fatalf("expected a spawned event, got %v", received)
+	}
+
+	// Chest charges up (no event)
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(42),
+		1:                     int32(3),
+		events.ParamEventCode: int16(events.EventUpdateLootChest),
+	})
+
+	if level := handler.GetChests()[0].ChargeLevel; level != 3 {
+		t.Errorf("expected charge level 3, got %d", level)
+	}
+	if len(received) != 1 {
+		t.Errorf("expected no event for an update, got %d events", len(received))
+	}
+
+	// Chest is opened
+	opened := map[byte]interface{}{
+		0:                     int64(42),
+		events.ParamEventCode: int16(events.EventLootChestOpened),
+	}
+	handler.OnEvent(0, opened)
+
+	if !handler.GetChests()[0].Opened {
+		t.Error("expected chest to be marked opened")
+	}
+	if len(received) != 2 || received[1].Action != ChestOpened || received[1].Chest.ChargeLevel != 3 {
+		t.Fatalf("expected an opened event with charge level 3, got %v", received)
+	}
+
+	// Opening again is not reported twice
+	handler.OnEvent(0, opened)
+	if len(received) != 2 {
+		t.Errorf("expected no duplicate opened event, got %d events", len(received))
+	}
+}
+
+// TestLootChestUnknownObject tests that updates for unknown chests are ignored
+func TestLootChestUnknownObject(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	calls := 0
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		calls++
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(7),
+		1:                     int32(2),
+		events.ParamEventCode: int16(events.EventUpdateLootChest),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(7),
+		events.ParamEventCode: int16(events.EventLootChestOpened),
+	})
+
+	if len(handler.GetChests()) != 0 {
+		t.Errorf("expected no chests, got %d", len(handler.GetChests()))
+	}
+	if calls != 0 {
+		t.Errorf("expected no events, got %d", calls)
+	}
+}
+
+// TestChestType tests deriving the chest type from its unique name
+func TestChestType(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"AVALON_CHEST_LEGENDARY", ChestTypeLegendary},
+		{"DUNGEON_CHEST_RARE", ChestTypeRare},
+		{"dungeon_chest_uncommon", ChestTypeUncommon},
+		{"DUNGEON_CHEST", ChestTypeStandard},
+		{"", ChestTypeStandard},
+	}
+
+	for _, tt := range tests {
+		if got := chestType(tt.name); got != tt.expected {
+			t.Errorf("chestType(%q): expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+// spawnChest sends a NewLootChest event for objectID
+func spawnChest(handler *AlbionHandler, objectID int64) {
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     objectID,
+		3:                     "AVALON_CHEST_RARE",
+		events.ParamEventCode: int16(events.EventNewLootChest),
+	})
+}
+
+// TestChestLeave tests that a chest leaving view is removed from the registry
+func TestChestLeave(t *testing.T) {
+	handler := NewAlbionHandler()
+	spawnChest(handler, 42)
+	spawnChest(handler, 43)
+
+	handler.OnEvent(0, map[byte]interface{}{0: int64(42), events.ParamEventCode: int16(events.EventLeave)})
+
+	chests := handler.GetChests()
+	if len(chests) != 1 || chests[0].ObjectID != 43 {
+		t.Errorf("expected only chest 43, got %+v", chests)
+	}
+}
+
+// TestChestsClearedOnZoneChange tests that the chests of a zone are forgotten when leaving it
+func TestChestsClearedOnZoneChange(t *testing.T) {
+	handler := NewAlbionHandler()
+	joinZone(handler, "3005")
+	spawnChest(handler, 42)
+
+	joinZone(handler, "3005") // Same zone
+	if n := len(handler.GetChests()); n != 1 {
+		t.Fatalf("expected the chest to be kept in the same zone, got %d chests", n)
+	}
+
+	joinZone(handler, "@RANDOMDUNGEON@abc")
+	if n := len(handler.GetChests()); n != 0 {
+		t.Errorf("expected no chests after a zone change, got %d", n)
+	}
+}
+
+// TestChestRegistryCap tests that the registry starts over once full, unopened chests included
+func TestChestRegistryCap(t *testing.T) {
+	handler := NewAlbionHandler()
+	for i := 0; i < maxTrackedChests; i++ {
+		spawnChest(handler, int64(i))
+	}
+	spawnChest(handler, maxTrackedChests)
+
+	chests := handler.GetChests()
+	if len(chests) != 1 || chests[0].ObjectID != maxTrackedChests {
+		t.Errorf("expected only the newest chest after reaching the cap, got %d chests", len(chests))
+	}
+}
+
+// TestConcurrentChestAccess tests reading chests while events update them (run with -race)
+func TestConcurrentChestAccess(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			handler.OnEvent(0, map[byte]interface{}{
+				0:                     int64(i),
+				3:                     "AVALON_CHEST_RARE",
+				events.ParamEventCode: int16(events.EventNewLootChest),
+			})
+			handler.OnEvent(0, map[byte]interface{}{0: int64(i), events.ParamEventCode: int16(events.EventLootChestOpened)})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = handler.GetChests()
+	}
+	wg.Wait()
+
+	if n := len(handler.GetChests()); n > maxTrackedChests {
+		t.Errorf("expected at most %d chests, got %d", maxTrackedChests, n)
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// operationJoin is the operation code of the Join response (local player info)
+	operationJoin byte = 2
+
+	// maxTrackedPlayers bounds the nearby player map
+	maxTrackedPlayers = 500
+
+	// maxTrackedPositions bounds the object position map (players, mobs and objects)
+	maxTrackedPositions = 2000
+
+	// combatRateLimit is the minimum interval between combat events of the
+	// same kind for the same player
+	combatRateLimit = 2 * time.Second
+)
+
+// Combat event kinds
+const (
+	CombatForcedMovement       = "forced_movement"
+	CombatForcedMovementCancel = "forced_movement_cancel"
+	CombatCloak                = "cloak"
+	CombatUncloak              = "uncloak"
+	CombatMountLowHealth       = "mount_low_health"
+	CombatMountDismounted      = "mount_dismounted"
+)
+
+// CombatEventData contains combat awareness event data (displacement, stealth)
+type CombatEventData struct {
+	Kind     string // One of the Combat* kinds
+	ObjectID int64  // Object ID of the affected player
+	Name     string // Name of the affected player
+	IsLocal  bool   // Whether the affected player is the local player
+}
+
+// combatKey identifies a rate-limited combat event stream
+type combatKey struct {
+	objectID int64
+	kind     string
+}
+
+// SetVerboseCombat enables combat events for nearby players.
+// By default, only combat events affecting the local player are emitted.
+func (h *AlbionHandler) SetVerboseCombat(verbose bool) {
+	h.verboseCombat.Store(verbose)
+}
+
+// handleJoinResponse records the local player and zone from the Join operation response
+// Parameters: [0]=object ID, [2]=name, [8]=zone (cluster) ID, [57]=guild name
+func (h *AlbionHandler) handleJoinResponse(params map[byte]interface{}) {
+	h.setLocalPlayer(getInt64(params, 0), getString(params, 2), getString(params, 57))
+
+	if zone := getString(params, 8); zone != "" {
+		h.changeZone(zone)
+	}
+}
+
+// handleJoinFinished handles the end of a zone load (no callback).
+// If the Join response was missed (capture started after it), the local player
+// is taken from the first NewCharacter event that follows.
+func (h *AlbionHandler) handleJoinFinished(params map[byte]interface{}) {
+	h.relationsMu.Lock()
+	defer h.relationsMu.Unlock()
+	h.joinPending = h.localPlayerID == 0
+}
+
+// takeJoinPending reports whether the local player is awaited from the next
+// character (see handleJoinFinished), clearing the flag
+func (h *AlbionHandler) takeJoinPending() bool {
+	h.relationsMu.Lock()
+	defer h.relationsMu.Unlock()
+	pending := h.joinPending
+	h.joinPending = false
+	return pending
+}
+
+// setLocalPlayer records the local player's object ID, name and guild
+func (h *AlbionHandler) setLocalPlayer(objectID int64, name, guild string) {
+	h.relationsMu.Lock()
+	defer h.relationsMu.Unlock()
+
+	h.joinPending = false
+	h.localPlayerID = objectID
+	h.localPlayerName = name
+	h.relations.localName = name
+	h.relations.localGuild = guild
+}
+
+// LocalPlayerID returns the object ID of the local player, 0 until it is known
+// (the Join response or, failing that, the first character after JoinFinished)
+func (h *AlbionHandler) LocalPlayerID() int64 {
+	h.relationsMu.RLock()
+	defer h.relationsMu.RUnlock()
+	return h.localPlayerID
+}
+
+// localPlayer returns the object ID and name of the local player (0 and "" until known)
+func (h *AlbionHandler) localPlayer() (int64, string) {
+	h.relationsMu.RLock()
+	defer h.relationsMu.RUnlock()
+	return h.localPlayerID, h.localPlayerName
+}
+
+// handleForcedMovement handles knockbacks and pulls
+// Parameters: [0]=object ID
+func (h *AlbionHandler) handleForcedMovement(params map[byte]interface{}) {
+	h.notifyCombat(getInt64(params, 0), CombatForcedMovement)
+}
+
+// handleForcedMovementCancel handles the end of a knockback or pull
+// Parameters: [0]=object ID
+func (h *AlbionHandler) handleForcedMovementCancel(params map[byte]interface{}) {
+	h.notifyCombat(getInt64(params, 0), CombatForcedMovementCancel)
+}
+
+// handleCloak handles stealth toggles
+// Parameters: [0]=object ID, [1]=cloaked (absent when uncloaking)
+func (h *AlbionHandler) handleCloak(params map[byte]interface{}) {
+	kind := CombatUncloak
+	if getBool(params, 1) {
+		kind = CombatCloak
+	}
+	h.notifyCombat(getInt64(params, 0), kind)
+}
+
+// IsInCombat reports whether the local player is in active or passive combat
+func (h *AlbionHandler) IsInCombat() bool {
+	h.inCombatMu.RLock()
+	defer h.inCombatMu.RUnlock()
+	return h.inCombat
+}
+
+// handleInCombatStateUpdate handles a player entering or leaving combat, emitting
+// an info event when the local player's state changes
+// Parameters: [0]=object ID, [1]=in active combat, [2]=in passive combat (absent when false)
+func (h *AlbionHandler) handleInCombatStateUpdate(params map[byte]interface{}) {
+	localID, localName := h.localPlayer()
+	if localID == 0 || getInt64(params, 0) != localID {
+		return
+	}
+	inCombat := getBool(params, 1) || getBool(params, 2)
+
+	h.inCombatMu.Lock()
+	changed := h.inCombat != inCombat
+	h.inCombat = inCombat
+	h.inCombatMu.Unlock()
+
+	if !changed {
+		return
+	}
+	if inCombat {
+		h.notifyEvent("info", fmt.Sprintf("⚔️ %s entered combat", localName), nil)
+	} else {
+		h.notifyEvent("info", fmt.Sprintf("🕊️ %s left combat", localName), nil)
+	}
+}
+
+// notifyCombat emits a combat event for the local player, or for a tracked
+// nearby player in verbose mode, rate-limited per player and kind
+func (h *AlbionHandler) notifyCombat(objectID int64, kind string) {
+	localID, name := h.localPlayer()
+	isLocal := localID != 0 && objectID == localID
+
+	if !isLocal {
+		var tracked bool
+		name, tracked = h.trackedPlayer(objectID)
+		if !tracked || !h.verboseCombat.Load() {
+			return
+		}
+	}
+
+	key := combatKey{objectID: objectID, kind: kind}
+	now := time.Now()
+	if last, ok := h.combatLastSent[key]; ok && now.Sub(last) < combatRateLimit {
+		return
+	}
+	h.combatLastSent[key] = now
+
+	if len(h.combatLastSent) > maxTrackedPlayers {
+		for k, last := range h.combatLastSent {
+			if now.Sub(last) >= combatRateLimit {
+				delete(h.combatLastSent, k)
+			}
+		}
+	}
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("combat", "", &CombatEventData{
+		Kind:     kind,
+		ObjectID: objectID,
+		Name:     name,
+		IsLocal:  isLocal,
+	})
+}
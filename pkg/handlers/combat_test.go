@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newCombatTestHandler creates a handler with a local player and one nearby player
+func newCombatTestHandler() (*AlbionHandler, *[]*CombatEventData) {
+	handler := NewAlbionHandler()
+
+	var received []*CombatEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "combat" {
+			received = append(received, data.(*CombatEventData))
+		}
+	})
+
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{
+		0: int64(100),
+		2: "LocalPlayer",
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(200),
+		1:                     "NearbyPlayer",
+		events.ParamEventCode: int16(events.EventNewCharacter),
+	})
+
+	return handler, &received
+}
+
+// TestHandleForcedMovement tests a forced movement event on the local player
+func TestHandleForcedMovement(t *testing.T) {
+	handler, received := newCombatTestHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventForcedMovement),
+	})
+
+	if len(*received) != 1 {
+		t.Fatalf("expected 1 combat event, got %d", len(*received))
+	}
+
+	data := (*received)[0]
+	if data.Kind != CombatForcedMovement {
+		t.Errorf("expected kind %q, got %q", CombatForcedMovement, data.Kind)
+	}
+	if data.Name != "LocalPlayer" || !data.IsLocal {
+		t.Errorf("expected local player 'LocalPlayer', got '%s' (local=%v)", data.Name, data.IsLocal)
+	}
+}
+
+// TestHandleCloak tests cloak and uncloak toggles
+func TestHandleCloak(t *testing.T) {
+	handler, received := newCombatTestHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     true,
+		events.ParamEventCode: int16(events.EventCloak),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventCloak),
+	})
+
+	if len(*received) != 2 {
+		t.Fatalf("expected 2 combat events, got %d", len(*received))
+	}
+	if (*received)[0].Kind != CombatCloak {
+		t.Errorf("expected kind %q, got %q", CombatCloak, (*received)[0].Kind)
+	}
+	if (*received)[1].Kind != CombatUncloak {
+		t.Errorf("expected kind %q, got %q", CombatUncloak, (*received)[1].Kind)
+	}
+}
+
+// TestCombatNearbyPlayerVerboseOnly tests that nearby player events require verbose mode
+func TestCombatNearbyPlayerVerboseOnly(t *testing.T) {
+	handler, received := newCombatTestHandler()
+
+	params := map[byte]interface{}{
+		0:                     int64(200),
+		events.ParamEventCode: int16(events.EventForcedMovement),
+	}
+
+	handler.OnEvent(0, params)
+	if len(*received) != 0 {
+		t.Fatalf("expected no events for nearby player without verbose mode, got %d", len(*received))
+	}
+
+	handler.SetVerboseCombat(true)
+	handler.OnEvent(0, params)
+	if len(*received) != 1 {
+		t.Fatalf("expected 1 event in verbose mode, got %d", len(*received))
+	}
+	if (*received)[0].Name != "NearbyPlayer" || (*received)[0].IsLocal {
+		t.Errorf("expected nearby player 'NearbyPlayer', got '%s' (local=%v)", (*received)[0].Name, (*received)[0].IsLocal)
+	}
+
+	// Untracked objects (e.g., mobs) are ignored even in verbose mode
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(999),
+		events.ParamEventCode: int16(events.EventForcedMovement),
+	})
+	if len(*received) != 1 {
+		t.Errorf("expected untracked object to be ignored, got %d events", len(*received))
+	}
+}
+
+// TestCombatRateLimit tests that repeated events are rate-limited per player and kind
+func TestCombatRateLimit(t *testing.T) {
+	handler, received := newCombatTestHandler()
+
+	params := map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventForcedMovement),
+	}
+
+	handler.OnEvent(0, params)
+	handler.OnEvent(0, params)
+	if len(*received) != 1 {
+		t.Errorf("expected repeated event to be rate-limited, got %d events", len(*received))
+	}
+
+	// A different kind is not affected
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventForcedMovementCancel),
+	})
+	if len(*received) != 2 {
+		t.Errorf("expected 2 events, got %d", len(*received))
+	}
+
+	// After the rate limit window, events are emitted again
+	key := combatKey{objectID: 100, kind: CombatForcedMovement}
+	handler.combatLastSent[key] = time.Now().Add(-combatRateLimit)
+	handler.OnEvent(0, params)
+	if len(*received) != 3 {
+		t.Errorf("expected 3 events after rate limit window, got %d", len(*received))
+	}
+}
+
+// TestLocalPlayerID tests learning the local player from the Join response
+func TestLocalPlayerID(t *testing.T) {
+	handler := NewAlbionHandler()
+	if handler.LocalPlayerID() != 0 {
+		t.Errorf("expected unknown local player, got %d", handler.LocalPlayerID())
+	}
+
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{0: int64(100), 2: "LocalPlayer"})
+	handler.OnEvent(byte(events.EventJoinFinished), map[byte]interface{}{})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(200), 1: "NearbyPlayer"})
+
+	if handler.LocalPlayerID() != 100 {
+		t.Errorf("expected local player 100, got %d", handler.LocalPlayerID())
+	}
+}
+
+// TestLocalPlayerFromJoinFinished tests the fallback when the Join response was missed
+func TestLocalPlayerFromJoinFinished(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	// Characters seen before JoinFinished are other players
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(200), 1: "NearbyPlayer"})
+	handler.OnEvent(byte(events.EventJoinFinished), map[byte]interface{}{})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(300), 1: "LocalPlayer", 8: "Lens"})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(400), 1: "OtherPlayer"})
+
+	if handler.LocalPlayerID() != 300 {
+		t.Errorf("expected local player 300, got %d", handler.LocalPlayerID())
+	}
+	if rel := handler.Relationship("LocalPlayer"); rel != RelationshipSelf {
+		t.Errorf("expected LocalPlayer to be self, got %v", rel)
+	}
+}
+
+// TestHandleInCombatStateUpdate tests the combat flag and info events on transitions
+func TestHandleInCombatStateUpdate(t *testing.T) {
+	handler, _ := newCombatTestHandler()
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			messages = append(messages, message)
+		}
+	})
+	update := func(objectID int64, active, passive bool) {
+		handler.OnEvent(0, map[byte]interface{}{
+			0:                     objectID,
+			1:                     active,
+			2:                     passive,
+			events.ParamEventCode: int16(events.EventInCombatStateUpdate),
+		})
+	}
+
+	// Nearby players don't change the local state
+	update(200, true, false)
+	if handler.IsInCombat() || len(messages) != 0 {
+		t.Fatalf("expected no combat for a nearby player, got %v", messages)
+	}
+
+	update(100, true, false)
+	update(100, false, true)
+	if !handler.IsInCombat() {
+		t.Error("expected local player in combat")
+	}
+	update(100, false, false)
+	if handler.IsInCombat() {
+		t.Error("expected local player out of combat")
+	}
+
+	expected := []string{"⚔️ LocalPlayer entered combat", "🕊️ LocalPlayer left combat"}
+	if len(messages) != len(expected) {
+		t.Fatalf("expected %d info events on transitions, got %v", len(expected), messages)
+	}
+	for i, message := range messages {
+		if message != expected[i] {
+			t.Errorf("event %d: expected %q, got %q", i, expected[i], message)
+		}
+	}
+}
+
+// TestConcurrentVerboseCombat tests toggling verbose combat while events are handled
+func TestConcurrentVerboseCombat(t *testing.T) {
+	handler, _ := newCombatTestHandler()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			handler.OnEvent(0, map[byte]interface{}{
+				0:                     int64(200),
+				events.ParamEventCode: int16(events.EventForcedMovement),
+			})
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		handler.SetVerboseCombat(i%2 == 0)
+	}
+	wg.Wait()
+}
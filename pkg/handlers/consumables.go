@@ -0,0 +1,113 @@
+package handlers
+
+import "maps"
+
+// ConsumeEventData contains consumable usage event data (potions, food)
+type ConsumeEventData struct {
+	ItemID         int32  // Item ID of the consumable
+	ItemName       string // Name of the consumable
+	Quantity       int    // Quantity used in this event
+	Session        int    // Total quantity of this item used this session
+	EstimatedValue int64  // Estimated market value of the items used in silver, 0 if unknown
+	SessionCost    int64  // Estimated value of all consumables used this session
+}
+
+// pendingBatchUse is a batch item use that has started but not yet ended
+type pendingBatchUse struct {
+	itemID int32
+	count  int
+}
+
+// GetSessionConsumables returns the quantity used per consumable (by item name) this session
+func (h *AlbionHandler) GetSessionConsumables() map[string]int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return maps.Clone(h.sessionConsumables)
+}
+
+// GetSessionConsumableCost returns the estimated market value (silver) of the consumables used this session.
+// Consumables without a known market value are not counted.
+func (h *AlbionHandler) GetSessionConsumableCost() int64 {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.sessionConsumableCost
+}
+
+// handleBatchUseItemStart handles the start of a batch item use
+// Parameters: [0]=object ID, [1]=item ID, [2]=count
+func (h *AlbionHandler) handleBatchUseItemStart(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	if !h.isLocalPlayer(objectID) {
+		return
+	}
+
+	count := int(getInt32(params, 2))
+	if count <= 0 {
+		count = 1
+	}
+	h.pendingBatchUses[objectID] = pendingBatchUse{
+		itemID: getInt32(params, 1),
+		count:  count,
+	}
+}
+
+// handleBatchUseItemEnd handles the end of a batch item use
+// Parameters: [0]=object ID, [1]=count actually used (optional, defaults to the started count)
+func (h *AlbionHandler) handleBatchUseItemEnd(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	batch, ok := h.pendingBatchUses[objectID]
+	if !ok {
+		return
+	}
+	delete(h.pendingBatchUses, objectID)
+
+	count := batch.count
+	if _, hasCount := params[1]; hasCount {
+		count = min(int(getInt32(params, 1)), batch.count)
+	}
+	h.recordConsumable(batch.itemID, count)
+}
+
+// handleUseFunction handles a single item use
+// Parameters: [0]=object ID, [1]=item ID
+func (h *AlbionHandler) handleUseFunction(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	itemID := getInt32(params, 1)
+	if itemID <= 0 || !h.isLocalPlayer(objectID) {
+		return
+	}
+	h.recordConsumable(itemID, 1)
+}
+
+// isLocalPlayer reports whether objectID is the local player.
+// Before the local player is known, every object is accepted.
+func (h *AlbionHandler) isLocalPlayer(objectID int64) bool {
+	localID := h.LocalPlayerID()
+	return localID == 0 || objectID == localID
+}
+
+// recordConsumable adds used consumables and their estimated value to the session tally and notifies the frontend
+func (h *AlbionHandler) recordConsumable(itemID int32, count int) {
+	if count <= 0 {
+		return
+	}
+
+	itemName := h.resolveItemName(itemID)
+	value := h.GetEstimatedValue(itemID) * int64(count)
+	h.sessionMu.Lock()
+	h.sessionConsumables[itemName] += count
+	session := h.sessionConsumables[itemName]
+	h.sessionConsumableCost += value
+	sessionCost := h.sessionConsumableCost
+	h.sessionMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("consume", "", &ConsumeEventData{
+		ItemID:         itemID,
+		ItemName:       itemName,
+		Quantity:       count,
+		Session:        session,
+		EstimatedValue: value,
+		SessionCost:    sessionCost,
+	})
+}
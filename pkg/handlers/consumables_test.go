@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestBatchUseItem tests counting consumable usage across a batch
+func TestBatchUseItem(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received []*ConsumeEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "consume" {
+			received = append(received, data.(*ConsumeEventData))
+		}
+	})
+
+	// Batch of 5 potions, all used
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(555),
+		2:                     int32(5),
+		events.ParamEventCode: int16(events.EventBatchUseItemStart),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventBatchUseItemEnd),
+	})
+
+	// Batch of 4 interrupted after 2
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(555),
+		2:                     int32(4),
+		events.ParamEventCode: int16(events.EventBatchUseItemStart),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(2),
+		events.ParamEventCode: int16(events.EventBatchUseItemEnd),
+	})
+
+	// Single use of another item
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(777),
+		events.ParamEventCode: int16(events.EventUseFunction),
+	})
+
+	consumables := handler.GetSessionConsumables()
+	if consumables["Item#555"] != 7 {
+		t.Errorf("expected 7 uses of Item#555, got %d", consumables["Item#555"])
+	}
+	if consumables["Item#777"] != 1 {
+		t.Errorf("expected 1 use of Item#777, got %d", consumables["Item#777"])
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("expected 3 consume events, got %d", len(received))
+	}
+	if received[1].Quantity != 2 || received[1].Session != 7 {
+		t.Errorf("expected quantity 2 and session 7, got %d and %d", received[1].Quantity, received[1].Session)
+	}
+}
+
+// TestBatchUseItemEndWithoutStart tests that an unmatched end is ignored
+func TestBatchUseItemEndWithoutStart(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventBatchUseItemEnd),
+	})
+
+	if len(handler.GetSessionConsumables()) != 0 {
+		t.Errorf("expected no consumables, got %v", handler.GetSessionConsumables())
+	}
+}
+
+// TestConsumablesOtherPlayersIgnored tests that other players' usage is not counted
+func TestConsumablesOtherPlayersIgnored(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{0: int64(100), 2: "LocalPlayer"})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(200),
+		1:                     int32(555),
+		events.ParamEventCode: int16(events.EventUseFunction),
+	})
+
+	if len(handler.GetSessionConsumables()) != 0 {
+		t.Errorf("expected no consumables, got %v", handler.GetSessionConsumables())
+	}
+}
+
+// TestGetSessionConsumablesReturnsCopy tests that the returned map is a copy
+func TestGetSessionConsumablesReturnsCopy(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.recordConsumable(555, 1)
+
+	consumables := handler.GetSessionConsumables()
+	consumables["Item#555"] = 99
+
+	if handler.GetSessionConsumables()["Item#555"] != 1 {
+		t.Error("modifying the returned map should not affect the handler")
+	}
+}
+
+// TestConsumableCost tests the estimated value of used consumables and the session cost
+func TestConsumableCost(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received *ConsumeEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "consume" {
+			received = data.(*ConsumeEventData)
+		}
+	})
+
+	// 250 silver per unit in FixPoint
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int32(555),
+		1:                     int64(2500000),
+		events.ParamEventCode: int16(events.EventEstimatedMarketValueUpdate),
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(555),
+		2:                     int32(3),
+		events.ParamEventCode: int16(events.EventBatchUseItemStart),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		events.ParamEventCode: int16(events.EventBatchUseItemEnd),
+	})
+	if received == nil || received.EstimatedValue != 750 || received.SessionCost != 750 {
+		t.Fatalf("expected value 750 and session cost 750, got %+v", received)
+	}
+
+	// Unknown value adds nothing to the cost
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     int32(777),
+		events.ParamEventCode: int16(events.EventUseFunction),
+	})
+	if received.EstimatedValue != 0 || received.SessionCost != 750 {
+		t.Errorf("expected value 0 and session cost 750, got %d and %d", received.EstimatedValue, received.SessionCost)
+	}
+
+	if cost := handler.GetSessionConsumableCost(); cost != 750 {
+		t.Errorf("expected session cost 750, got %d", cost)
+	}
+	handler.ResetSession()
+	if cost := handler.GetSessionConsumableCost(); cost != 0 {
+		t.Errorf("expected session cost 0 after reset, got %d", cost)
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+)
+
+// DamageEventData contains a single spell hit (emitted as a "combat" event)
+type DamageEventData struct {
+	Caster    string  // Name of the caster
+	Target    string  // Name of the target
+	Amount    float64 // Damage dealt by the hit
+	SpellID   int32   // Spell index (see pkg/spells)
+	SpellName string  // Spell name, "Spell#<id>" if the spell database is not loaded
+}
+
+// GetSessionDamageDealt returns the damage dealt by the local player this session
+func (h *AlbionHandler) GetSessionDamageDealt() float64 {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.sessionDamageDealt
+}
+
+// GetSessionDamageTaken returns the damage taken by the local player this session
+func (h *AlbionHandler) GetSessionDamageTaken() float64 {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.sessionDamageTaken
+}
+
+// handleCastHit handles a spell hitting a single target
+// Parameters: [0]=caster ID, [1]=target ID, [2]=spell index, [3]=damage
+func (h *AlbionHandler) handleCastHit(params map[byte]interface{}) {
+	h.recordHit(getInt64(params, 0), getInt64(params, 1), getInt32(params, 2), float64(getFloat32(params, 3)))
+}
+
+// handleCastHits handles a spell hitting several targets
+// Parameters: [0]=caster ID, [1]=target IDs, [2]=spell index, [3]=damage per target
+func (h *AlbionHandler) handleCastHits(params map[byte]interface{}) {
+	casterID := getInt64(params, 0)
+	spellID := getInt32(params, 2)
+	targets := getInt32Slice(params, 1)
+	damages := getFloat32Slice(params, 3)
+
+	for i, target := range targets {
+		if i >= len(damages) {
+			break
+		}
+		h.recordHit(casterID, int64(target), spellID, float64(damages[i]))
+	}
+}
+
+// recordHit adds a hit involving the local player to the session damage and notifies
+// the frontend. Hits between other players are only reported in verbose combat mode.
+func (h *AlbionHandler) recordHit(casterID, targetID int64, spellID int32, amount float64) {
+	// A misparsed value would poison the session totals and can't be exported as JSON
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return
+	}
+	// Damage is sent as a negative health change
+	if amount < 0 {
+		amount = -amount
+	}
+	if amount == 0 {
+		return
+	}
+
+	localID := h.LocalPlayerID()
+	dealt := localID != 0 && casterID == localID
+	taken := localID != 0 && targetID == localID
+	if !dealt && !taken && !h.verboseCombat.Load() {
+		return
+	}
+
+	h.sessionMu.Lock()
+	if dealt {
+		h.sessionDamageDealt += amount
+	}
+	if taken {
+		h.sessionDamageTaken += amount
+	}
+	h.sessionMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("combat", "", &DamageEventData{
+		Caster:    h.combatantName(casterID),
+		Target:    h.combatantName(targetID),
+		Amount:    amount,
+		SpellID:   spellID,
+		SpellName: h.resolveSpellName(spellID),
+	})
+}
+
+// combatantName returns the name of the local player, a nearby player or a mob in
+// view, "Unknown" otherwise
+func (h *AlbionHandler) combatantName(objectID int64) string {
+	if localID, localName := h.localPlayer(); localID != 0 && objectID == localID {
+		return localName
+	}
+	if name := h.GetPlayerName(objectID); name != "" {
+		return name
+	}
+	if name := h.GetMobName(objectID); name != "" {
+		return name
+	}
+	return "Unknown"
+}
+
+// resolveSpellName returns the spell name from the database, or a placeholder if unavailable
+func (h *AlbionHandler) resolveSpellName(spellID int32) string {
+	if h.spellDB != nil && h.spellDB.IsLoaded() {
+		return h.spellDB.GetSpellName(spellID)
+	}
+	return fmt.Sprintf("Spell#%d", spellID)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newDamageHandler returns a handler with a known local player and one nearby player
+func newDamageHandler() (*AlbionHandler, *[]*DamageEventData) {
+	handler := NewAlbionHandler()
+	handler.localPlayerID = 100
+	handler.localPlayerName = "Lens"
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(200), 1: "Bandit"})
+
+	received := &[]*DamageEventData{}
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if damage, ok := data.(*DamageEventData); ok && eventType == "combat" {
+			*received = append(*received, damage)
+		}
+	})
+	return handler, received
+}
+
+// TestCastHit tests that hits dealt and taken by the local player are reported and accumulated
+func TestCastHit(t *testing.T) {
+	handler, received := newDamageHandler()
+
+	handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(100), 1: int64(200), 2: int32(7), 3: float32(-120)})
+	handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(200), 1: int64(100), 2: int32(8), 3: float32(-45.5)})
+
+	if handler.GetSessionDamageDealt() != 120 {
+		t.Errorf("expected 120 damage dealt, got %v", handler.GetSessionDamageDealt())
+	}
+	if handler.GetSessionDamageTaken() != 45.5 {
+		t.Errorf("expected 45.5 damage taken, got %v", handler.GetSessionDamageTaken())
+	}
+	if len(*received) != 2 {
+		t.Fatalf("expected 2 combat events, got %d", len(*received))
+	}
+	first := (*received)[0]
+	if first.Caster != "Lens" || first.Target != "Bandit" || first.Amount != 120 || first.SpellID != 7 || first.SpellName != "Spell#7" {
+		t.Errorf("unexpected hit: %+v", first)
+	}
+	if second := (*received)[1]; second.Caster != "Bandit" || second.Target != "Lens" {
+		t.Errorf("unexpected hit: %+v", second)
+	}
+
+	handler.ResetSession()
+	if handler.GetSessionDamageDealt() != 0 || handler.GetSessionDamageTaken() != 0 {
+		t.Error("expected damage to be reset with the session")
+	}
+}
+
+// TestCastHitInvalidAmount tests that NaN and infinite damage values are ignored
+func TestCastHitInvalidAmount(t *testing.T) {
+	handler, received := newDamageHandler()
+
+	for _, amount := range []float32{float32(math.NaN()), float32(math.Inf(-1)), float32(math.Inf(1))} {
+		handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(100), 1: int64(200), 2: int32(7), 3: amount})
+		handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(200), 1: int64(100), 2: int32(7), 3: amount})
+	}
+	handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(100), 1: int64(200), 2: int32(7), 3: float32(-10)})
+
+	if handler.GetSessionDamageDealt() != 10 || handler.GetSessionDamageTaken() != 0 {
+		t.Errorf("expected 10 dealt and 0 taken, got %v and %v", handler.GetSessionDamageDealt(), handler.GetSessionDamageTaken())
+	}
+	if len(*received) != 1 {
+		t.Errorf("expected only the valid hit to be reported, got %d events", len(*received))
+	}
+}
+
+// TestCastHits tests that multi-target hits emit one event per target
+func TestCastHits(t *testing.T) {
+	handler, received := newDamageHandler()
+
+	handler.OnEvent(byte(events.EventCastHits), map[byte]interface{}{
+		0: int64(100),
+		1: []int32{200, 300, 400},
+		2: int32(7),
+		3: []float32{-10, -20}, // The third target has no damage value
+	})
+
+	if len(*received) != 2 {
+		t.Fatalf("expected 2 combat events, got %d", len(*received))
+	}
+	if (*received)[1].Target != "Unknown" || (*received)[1].Amount != 20 {
+		t.Errorf("unexpected hit: %+v", (*received)[1])
+	}
+	if handler.GetSessionDamageDealt() != 30 {
+		t.Errorf("expected 30 damage dealt, got %v", handler.GetSessionDamageDealt())
+	}
+}
+
+// TestCastHitOtherPlayers tests that hits between other players are only reported in verbose mode
+func TestCastHitOtherPlayers(t *testing.T) {
+	handler, received := newDamageHandler()
+	hit := map[byte]interface{}{0: int64(200), 1: int64(300), 2: int32(7), 3: float32(-50)}
+
+	handler.OnEvent(byte(events.EventCastHit), hit)
+	if len(*received) != 0 {
+		t.Errorf("expected no events, got %d", len(*received))
+	}
+
+	handler.SetVerboseCombat(true)
+	handler.OnEvent(byte(events.EventCastHit), hit)
+	if len(*received) != 1 {
+		t.Errorf("expected 1 event in verbose mode, got %d", len(*received))
+	}
+	if handler.GetSessionDamageDealt() != 0 || handler.GetSessionDamageTaken() != 0 {
+		t.Error("expected other players' damage not to count for the session")
+	}
+}
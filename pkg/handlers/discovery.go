@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// discoveryCSVHeader is the header row written by SaveDiscoveredEventsCSV
+var discoveryCSVHeader = []string{"code", "name", "count", "first_seen", "last_seen", "param_keys", "param_types"}
+
+// SaveDiscoveredEventsCSV saves discovered events to a CSV file for spreadsheet analysis,
+// most frequent events first
+func (h *AlbionHandler) SaveDiscoveredEventsCSV(filename string) error {
+	h.discoveryMu.RLock()
+	defer h.discoveryMu.RUnlock()
+
+	// Create output directory if it doesn't exist
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	discovered := slices.SortedFunc(maps.Values(h.discoveredEvents), func(a, b *DiscoveredEvent) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Code, b.Code))
+	})
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	_ = w.Write(discoveryCSVHeader)
+	for _, event := range discovered {
+		_ = w.Write(discoveryCSVRow(event))
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// discoveryCSVRow formats a discovered event as a CSV row (see discoveryCSVHeader).
+// Parameter keys are sorted and joined with ";", types are written as key:type.
+func discoveryCSVRow(event *DiscoveredEvent) []string {
+	name, ok := events.EventCodeNames[events.EventCode(event.Code)]
+	if !ok {
+		name = "Unknown"
+	}
+
+	keys := slices.Sorted(maps.Keys(event.ParamTypes))
+	paramKeys := make([]string, len(keys))
+	paramTypes := make([]string, len(keys))
+	for i, key := range keys {
+		paramKeys[i] = strconv.Itoa(int(key))
+		paramTypes[i] = fmt.Sprintf("%d:%s", key, event.ParamTypes[key])
+	}
+
+	return []string{
+		strconv.Itoa(int(event.Code)),
+		name,
+		strconv.Itoa(event.Count),
+		event.FirstSeen.Format(time.RFC3339),
+		event.LastSeen.Format(time.RFC3339),
+		strings.Join(paramKeys, ";"),
+		strings.Join(paramTypes, ";"),
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestSaveDiscoveredEventsCSV tests the CSV columns and that rows are sorted by count
+func TestSaveDiscoveredEventsCSV(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	handler.OnEvent(0, map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventUpdateFame),
+		1:                     int64(10000),
+		2:                     int64(10000),
+	})
+	for i := 0; i < 3; i++ {
+		handler.OnEvent(0, map[byte]interface{}{
+			events.ParamEventCode: int16(4000),
+			0:                     int64(1),
+			5:                     "test",
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "output", "discovered.csv")
+	if err := handler.SaveDiscoveredEventsCSV(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error reading CSV: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d rows", len(rows))
+	}
+	if rows[0][0] != "code" || rows[0][6] != "param_types" {
+		t.Errorf("unexpected header: %v", rows[0])
+	}
+
+	// The most frequent event comes first
+	unknown := rows[1]
+	if unknown[0] != "4000" || unknown[1] != "Unknown" || unknown[2] != "3" {
+		t.Errorf("expected unknown event 4000 seen 3 times, got %v", unknown)
+	}
+	if unknown[5] != "0;5;252" || unknown[6] != "0:int64;5:string;252:int16" {
+		t.Errorf("unexpected param columns: %v", unknown[5:])
+	}
+
+	if fame := rows[2]; fame[1] != "UpdateFame" || fame[2] != "1" {
+		t.Errorf("expected UpdateFame seen once, got %v", fame)
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+// FishingEventData contains fishing catch event data
+type FishingEventData struct {
+	ItemID   int32  // Item ID of the catch, 0 if the fish got away
+	ItemName string // Name of the catch, empty if the fish got away
+	Caught   bool   // Whether the fish was caught
+	Session  int    // Total fish caught this session
+}
+
+// GetSessionFishCaught returns the number of fish caught this session
+func (h *AlbionHandler) GetSessionFishCaught() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.sessionFishCaught
+}
+
+// handleFishingCatch handles the result of reeling in a fish
+// Parameters: [0]=object ID, [1]=item ID (missing when the fish got away)
+func (h *AlbionHandler) handleFishingCatch(params map[byte]interface{}) {
+	if !h.isLocalPlayer(getInt64(params, 0)) {
+		return
+	}
+	h.fishingReported = true
+	h.notifyFishing(getInt32(params, 1))
+}
+
+// handleFishingFinished handles the end of a fishing attempt.
+// The result is only reported here when no FishingCatch event preceded it.
+// Parameters: [0]=object ID, [1]=item ID (missing when nothing was caught)
+func (h *AlbionHandler) handleFishingFinished(params map[byte]interface{}) {
+	if !h.isLocalPlayer(getInt64(params, 0)) {
+		return
+	}
+	if h.fishingReported {
+		h.fishingReported = false
+		return
+	}
+	h.notifyFishing(getInt32(params, 1))
+}
+
+// notifyFishing counts a catch (itemID > 0) and emits a fishing event, or reports
+// that the fish got away
+func (h *AlbionHandler) notifyFishing(itemID int32) {
+	if itemID <= 0 {
+		h.notifyEvent("fishing", "", &FishingEventData{Session: h.GetSessionFishCaught()})
+		return
+	}
+
+	h.sessionMu.Lock()
+	h.sessionFishCaught++
+	session := h.sessionFishCaught
+	h.sessionMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("fishing", "", &FishingEventData{
+		ItemID:   itemID,
+		ItemName: h.resolveItemName(itemID),
+		Caught:   true,
+		Session:  session,
+	})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newFishingTestHandler creates a handler that records fishing event data
+func newFishingTestHandler() (*AlbionHandler, *[]*FishingEventData) {
+	handler := NewAlbionHandler()
+
+	var received []*FishingEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "fishing" {
+			received = append(received, data.(*FishingEventData))
+		}
+	})
+
+	return handler, &received
+}
+
+// sendFishingEvent sends a fishing event with the given parameters
+func sendFishingEvent(handler *AlbionHandler, code events.EventCode, params map[byte]interface{}) {
+	params[events.ParamEventCode] = int16(code)
+	handler.OnEvent(0, params)
+}
+
+// TestFishingCatch tests that a catch is counted once even though the attempt also finishes
+func TestFishingCatch(t *testing.T) {
+	handler, received := newFishingTestHandler()
+
+	sendFishingEvent(handler, events.EventFishingCatch, map[byte]interface{}{0: int64(100), 1: int32(555)})
+	sendFishingEvent(handler, events.EventFishingFinished, map[byte]interface{}{0: int64(100), 1: int32(555)})
+
+	if handler.GetSessionFishCaught() != 1 {
+		t.Errorf("expected 1 fish caught, got %d", handler.GetSessionFishCaught())
+	}
+	if len(*received) != 1 {
+		t.Fatalf("expected 1 fishing event, got %d", len(*received))
+	}
+	if got := (*received)[0]; !got.Caught || got.ItemName != "Item#555" || got.Session != 1 {
+		t.Errorf("expected Item#555 caught (session 1), got %+v", got)
+	}
+}
+
+// TestFishingGotAway tests that a catch without an item is reported as a fish that got away
+func TestFishingGotAway(t *testing.T) {
+	handler, received := newFishingTestHandler()
+
+	sendFishingEvent(handler, events.EventFishingCatch, map[byte]interface{}{0: int64(100)})
+
+	if handler.GetSessionFishCaught() != 0 {
+		t.Errorf("expected no fish caught, got %d", handler.GetSessionFishCaught())
+	}
+	if len(*received) != 1 || (*received)[0].Caught || (*received)[0].ItemID != 0 {
+		t.Errorf("expected a single got away event, got %+v", *received)
+	}
+}
+
+// TestFishingFinishedWithoutCatch tests that the result is taken from FishingFinished
+// when no catch event was seen
+func TestFishingFinishedWithoutCatch(t *testing.T) {
+	handler, received := newFishingTestHandler()
+
+	sendFishingEvent(handler, events.EventFishingFinished, map[byte]interface{}{0: int64(100), 1: int32(777)})
+	sendFishingEvent(handler, events.EventFishingFinished, map[byte]interface{}{0: int64(100)})
+
+	if handler.GetSessionFishCaught() != 1 {
+		t.Errorf("expected 1 fish caught, got %d", handler.GetSessionFishCaught())
+	}
+	if len(*received) != 2 || !(*received)[0].Caught || (*received)[1].Caught {
+		t.Errorf("expected a catch then a got away event, got %+v", *received)
+	}
+}
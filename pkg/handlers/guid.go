@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/hex"
+
+	"github.com/cantalupo555/albion-lens/pkg/photon"
+)
+
+// maxKnownGUIDs bounds the player name by GUID map, which is kept across zones
+const maxKnownGUIDs = 5000
+
+// FormatGUID formats a GUID in the canonical form (e.g.
+// "33221100-5544-7766-8899-aabbccddeeff"). The bytes are in the .NET
+// Guid.ToByteArray layout the game sends, whose first three groups are little-endian.
+func FormatGUID(guid [16]byte) string {
+	ordered := [16]byte{
+		guid[3], guid[2], guid[1], guid[0],
+		guid[5], guid[4],
+		guid[7], guid[6],
+	}
+	copy(ordered[8:], guid[8:])
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], ordered[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], ordered[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], ordered[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], ordered[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], ordered[10:16])
+	return string(buf[:])
+}
+
+// getGUID returns a GUID parameter (16-byte array) in canonical form, empty if
+// missing, malformed or all zero
+func getGUID(params map[byte]interface{}, key byte) string {
+	data, ok := params[key].([]byte)
+	if !ok || len(data) != 16 {
+		return ""
+	}
+	guid, err := photon.NewBufferReader(data).ReadGUID()
+	if err != nil || guid == [16]byte{} {
+		return ""
+	}
+	return FormatGUID(guid)
+}
+
+// rememberPlayerGUID records the GUID of a player in view, and its name under the
+// GUID, which stays valid after the player leaves or the zone changes
+func (h *AlbionHandler) rememberPlayerGUID(objectID int64, guid, name string) {
+	h.playersMu.Lock()
+	defer h.playersMu.Unlock()
+
+	if _, known := h.namesByGUID[guid]; !known && len(h.namesByGUID) >= maxKnownGUIDs {
+		clear(h.namesByGUID)
+	}
+	h.namesByGUID[guid] = name
+	h.playerGUIDs[objectID] = guid
+}
+
+// GetPlayerGUID returns the GUID of the nearby player with the given object ID,
+// or an empty string if the player is not in view or its GUID is unknown
+func (h *AlbionHandler) GetPlayerGUID(id int64) string {
+	h.playersMu.RLock()
+	defer h.playersMu.RUnlock()
+	return h.playerGUIDs[id]
+}
+
+// GetPlayerNameByGUID returns the name of the player with the given GUID (see
+// FormatGUID), or an empty string if it was never seen. Unlike object IDs, which
+// are reassigned on every zone change, GUIDs identify a player for good.
+func (h *AlbionHandler) GetPlayerNameByGUID(guid string) string {
+	h.playersMu.RLock()
+	defer h.playersMu.RUnlock()
+	return h.namesByGUID[guid]
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// testGUID is a GUID in the .NET byte layout, canonically 33221100-5544-7766-8899-aabbccddeeff
+var testGUID = []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+// TestFormatGUID tests the canonical formatting of GUIDs
+func TestFormatGUID(t *testing.T) {
+	var guid [16]byte
+	copy(guid[:], testGUID)
+
+	if got := FormatGUID(guid); got != "33221100-5544-7766-8899-aabbccddeeff" {
+		t.Errorf("expected 33221100-5544-7766-8899-aabbccddeeff, got %s", got)
+	}
+	if got := FormatGUID([16]byte{}); got != "00000000-0000-0000-0000-000000000000" {
+		t.Errorf("expected the nil GUID, got %s", got)
+	}
+}
+
+// TestGetGUID tests that only 16-byte, non-zero arrays are read as GUIDs
+func TestGetGUID(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{testGUID, "33221100-5544-7766-8899-aabbccddeeff"},
+		{testGUID[:8], ""},
+		{make([]byte, 16), ""},
+		{"33221100-5544-7766-8899-aabbccddeeff", ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := getGUID(map[byte]interface{}{2: tt.value}, 2); got != tt.expected {
+			t.Errorf("getGUID(%v): expected %q, got %q", tt.value, tt.expected, got)
+		}
+	}
+}
+
+// TestPlayerGUIDAcrossZones tests that names stay known by GUID after object IDs change
+func TestPlayerGUIDAcrossZones(t *testing.T) {
+	handler := NewAlbionHandler()
+	guid := "33221100-5544-7766-8899-aabbccddeeff"
+
+	newCharacter := func(objectID int64) {
+		handler.OnEvent(0, map[byte]interface{}{
+			0:                     objectID,
+			1:                     "Nearby",
+			2:                     testGUID,
+			events.ParamEventCode: int16(events.EventNewCharacter),
+		})
+	}
+
+	newCharacter(200)
+	if handler.GetPlayerGUID(200) != guid {
+		t.Errorf("expected GUID %s, got %q", guid, handler.GetPlayerGUID(200))
+	}
+
+	// The player leaves view; the name stays known by GUID
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(200),
+		events.ParamEventCode: int16(events.EventLeave),
+	})
+	if handler.GetPlayerGUID(200) != "" {
+		t.Errorf("expected no GUID after leave, got %q", handler.GetPlayerGUID(200))
+	}
+	if handler.GetPlayerNameByGUID(guid) != "Nearby" {
+		t.Errorf("expected Nearby by GUID, got %q", handler.GetPlayerNameByGUID(guid))
+	}
+
+	// Seen again in another zone, under a new object ID
+	newCharacter(900)
+	if handler.GetPlayerGUID(900) != guid {
+		t.Errorf("expected the same GUID for the new object ID, got %q", handler.GetPlayerGUID(900))
+	}
+	if handler.GetPlayerNameByGUID("00000000-0000-0000-0000-000000000001") != "" {
+		t.Error("expected no name for an unknown GUID")
+	}
+}
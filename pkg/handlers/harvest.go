@@ -0,0 +1,55 @@
+package handlers
+
+// HarvestEventData contains gathered resource event data
+type HarvestEventData struct {
+	ItemID   int32  // Item ID of the resource
+	Resource string // Name of the resource
+	Amount   int    // Amount gathered in this event, bonuses included
+	Tier     int    // Resource tier, 0 if the item database is not loaded
+	Session  int    // Total resources gathered this session
+}
+
+// GetSessionHarvested returns the number of resources gathered this session
+func (h *AlbionHandler) GetSessionHarvested() int {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return h.sessionHarvested
+}
+
+// handleHarvestFinished handles the end of a gathering action
+// Parameters: [0]=object ID, [3]=item ID, [4]=standard amount, [5]=collector bonus, [6]=premium bonus
+func (h *AlbionHandler) handleHarvestFinished(params map[byte]interface{}) {
+	if !h.isLocalPlayer(getInt64(params, 0)) {
+		return
+	}
+
+	itemID := getInt32(params, 3)
+	amount := int(getInt32(params, 4) + getInt32(params, 5) + getInt32(params, 6))
+	if itemID <= 0 || amount <= 0 {
+		return
+	}
+
+	h.sessionMu.Lock()
+	h.sessionHarvested += amount
+	session := h.sessionHarvested
+	h.sessionMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("harvest", "", &HarvestEventData{
+		ItemID:   itemID,
+		Resource: h.resolveItemName(itemID),
+		Amount:   amount,
+		Tier:     h.resolveTier(itemID),
+		Session:  session,
+	})
+}
+
+// resolveTier returns the item tier from the database, or 0 if unavailable
+func (h *AlbionHandler) resolveTier(itemID int32) int {
+	if h.itemDB != nil && h.itemDB.IsLoaded() {
+		if info, ok := h.itemDB.GetByID(int(itemID)); ok {
+			return info.Tier
+		}
+	}
+	return 0
+}
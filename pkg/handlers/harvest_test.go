@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestHarvestFinished tests that gathered resources include bonuses and accumulate per session
+func TestHarvestFinished(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received []*HarvestEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "harvest" {
+			received = append(received, data.(*HarvestEventData))
+		}
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		3:                     int32(555),
+		4:                     int32(3),
+		5:                     int32(1),
+		6:                     int32(1),
+		events.ParamEventCode: int16(events.EventHarvestFinished),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		3:                     int32(555),
+		4:                     int32(2),
+		events.ParamEventCode: int16(events.EventHarvestFinished),
+	})
+
+	if handler.GetSessionHarvested() != 7 {
+		t.Errorf("expected 7 harvested, got %d", handler.GetSessionHarvested())
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 harvest events, got %d", len(received))
+	}
+	first := received[0]
+	if first.Resource != "Item#555" || first.Amount != 5 || first.Tier != 0 || first.Session != 5 {
+		t.Errorf("expected 5x Item#555 (session 5), got %+v", first)
+	}
+	if received[1].Session != 7 {
+		t.Errorf("expected session 7, got %d", received[1].Session)
+	}
+}
+
+// TestHarvestFinishedIgnored tests that empty harvests and other players' harvests are ignored
+func TestHarvestFinishedIgnored(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.localPlayerID = 100
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(200),
+		3:                     int32(555),
+		4:                     int32(3),
+		events.ParamEventCode: int16(events.EventHarvestFinished),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		3:                     int32(555),
+		events.ParamEventCode: int16(events.EventHarvestFinished),
+	})
+
+	if handler.GetSessionHarvested() != 0 {
+		t.Errorf("expected nothing harvested, got %d", handler.GetSessionHarvested())
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+// maxRecentMarketValues is the number of market values kept for RecentMarketValues
+const maxRecentMarketValues = 20
+
+// MarketEventData contains estimated market value event data
+type MarketEventData struct {
+	ItemName string // Name of the item
+	ItemID   int32  // Numeric item ID
+	Value    int64  // Estimated market value per unit in silver
+}
+
+// GetEstimatedValue returns the estimated market value per unit (silver) of an item,
+// as last reported by the server, or 0 if unknown
+func (h *AlbionHandler) GetEstimatedValue(itemID int32) int64 {
+	h.marketValuesMu.RLock()
+	defer h.marketValuesMu.RUnlock()
+
+	return h.marketValues[itemID]
+}
+
+// RecentMarketValues returns the most recent market value updates, oldest first
+func (h *AlbionHandler) RecentMarketValues() []MarketEventData {
+	h.marketValuesMu.RLock()
+	defer h.marketValuesMu.RUnlock()
+
+	recent := make([]MarketEventData, 0, len(h.recentMarket))
+	if len(h.recentMarket) == maxRecentMarketValues {
+		recent = append(recent, h.recentMarket[h.recentMarketNext:]...)
+		return append(recent, h.recentMarket[:h.recentMarketNext]...)
+	}
+	return append(recent, h.recentMarket...)
+}
+
+// handleEstimatedMarketValueUpdate caches the estimated market value of an item and
+// emits a market event
+// Parameters: [0]=item ID, [1]=estimated value per unit (FixPoint); an array with
+// one value per quality is also accepted, in which case the first (normal) is used
+func (h *AlbionHandler) handleEstimatedMarketValueUpdate(params map[byte]interface{}) {
+	itemID := getInt32(params, 0)
+	if itemID <= 0 {
+		return
+	}
+
+	var raw int64
+	switch v := params[1].(type) {
+	case []int64:
+		if len(v) > 0 {
+			raw = v[0]
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			raw = toInt64(v[0])
+		}
+	default:
+		raw = getInt64(params, 1)
+	}
+
+	// Values use FixPoint format (divide by 10000)
+	value := raw / 10000
+	if value <= 0 {
+		return
+	}
+
+	data := &MarketEventData{
+		ItemName: h.resolveItemName(itemID),
+		ItemID:   itemID,
+		Value:    value,
+	}
+
+	h.marketValuesMu.Lock()
+	h.marketValues[itemID] = value
+	if len(h.recentMarket) < maxRecentMarketValues {
+		h.recentMarket = append(h.recentMarket, *data)
+	} else {
+		// Ring buffer is full, overwrite the oldest value
+		h.recentMarket[h.recentMarketNext] = *data
+		h.recentMarketNext = (h.recentMarketNext + 1) % maxRecentMarketValues
+	}
+	h.marketValuesMu.Unlock()
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("market", "", data)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestEstimatedMarketValue tests caching market values and attaching them to loot
+func TestEstimatedMarketValue(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received *LootEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "loot" {
+			received = data.(*LootEventData)
+		}
+	})
+
+	loot := map[byte]interface{}{
+		1:                     "Mob",
+		2:                     "Me",
+		3:                     false,
+		4:                     int32(1234),
+		5:                     int32(3),
+		events.ParamEventCode: int16(events.EventOtherGrabbedLoot),
+	}
+
+	// Unknown value
+	handler.OnEvent(0, loot)
+	if received == nil || received.EstimatedValue != 0 {
+		t.Fatalf("expected loot without estimated value, got %+v", received)
+	}
+
+	// 1500 silver per unit in FixPoint
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int32(1234),
+		1:                     int64(15000000),
+		events.ParamEventCode: int16(events.EventEstimatedMarketValueUpdate),
+	})
+	if value := handler.GetEstimatedValue(1234); value != 1500 {
+		t.Errorf("expected cached value 1500, got %d", value)
+	}
+
+	handler.OnEvent(0, loot)
+	if received.EstimatedValue != 4500 {
+		t.Errorf("expected stack value 4500, got %d", received.EstimatedValue)
+	}
+}
+
+// TestEstimatedMarketValuePerQuality tests that the normal quality value is used from an array
+func TestEstimatedMarketValuePerQuality(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int16(77),
+		1:                     []interface{}{int64(20000), int64(40000)},
+		events.ParamEventCode: int16(events.EventEstimatedMarketValueUpdate),
+	})
+
+	if value := handler.GetEstimatedValue(77); value != 2 {
+		t.Errorf("expected value 2, got %d", value)
+	}
+}
+
+// TestMarketEvent tests that a market event is emitted with the resolved item
+func TestMarketEvent(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received *MarketEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "market" {
+			received = data.(*MarketEventData)
+		}
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int32(1234),
+		1:                     int64(15000000),
+		events.ParamEventCode: int16(events.EventEstimatedMarketValueUpdate),
+	})
+
+	if received == nil {
+		t.Fatal("expected market event, got none")
+	}
+	if received.ItemID != 1234 || received.Value != 1500 || received.ItemName != "Item#1234" {
+		t.Errorf("unexpected market event: %+v", received)
+	}
+}
+
+// TestRecentMarketValues tests that only the most recent values are kept, oldest first
+func TestRecentMarketValues(t *testing.T) {
+	handler := NewAlbionHandler()
+	if recent := handler.RecentMarketValues(); len(recent) != 0 {
+		t.Fatalf("expected no recent values, got %v", recent)
+	}
+
+	total := maxRecentMarketValues + 5
+	for i := 1; i <= total; i++ {
+		handler.OnEvent(0, map[byte]interface{}{
+			0:                     int32(i),
+			1:                     int64(i * 10000),
+			events.ParamEventCode: int16(events.EventEstimatedMarketValueUpdate),
+		})
+	}
+
+	recent := handler.RecentMarketValues()
+	if len(recent) != maxRecentMarketValues {
+		t.Fatalf("expected %d recent values, got %d", maxRecentMarketValues, len(recent))
+	}
+	if recent[0].ItemID != 6 || recent[len(recent)-1].ItemID != int32(total) {
+		t.Errorf("expected items 6 to %d, got %d to %d", total, recent[0].ItemID, recent[len(recent)-1].ItemID)
+	}
+}
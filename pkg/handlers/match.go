@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Match event phases
+const (
+	MatchStarted = "started"
+	MatchUpdated = "updated"
+	MatchEnded   = "ended"
+)
+
+// MatchPlayerStats are the stats of one participant in an arena or crystal match
+type MatchPlayerStats struct {
+	ObjectID int64  // Object ID of the player
+	Name     string // Player name
+	Kills    int32  // Kills in this match
+	Deaths   int32  // Deaths in this match
+	Assists  int32  // Assists in this match
+}
+
+// Match is the state of an arena or crystal league match
+type Match struct {
+	ID        int64              // Match ID
+	Type      string             // Match type reported by the server (e.g., "ARENA")
+	StartedAt time.Time          // When the match started
+	Scores    []int32            // Score of each team
+	Players   []MatchPlayerStats // Participants with stats, ordered by object ID
+}
+
+// MatchEventData contains match event data
+type MatchEventData struct {
+	Phase string // One of the Match* phases
+	Match Match  // Snapshot of the match
+}
+
+// CurrentMatch returns the match in progress, if any
+func (h *AlbionHandler) CurrentMatch() (Match, bool) {
+	h.matchMu.Lock()
+	defer h.matchMu.Unlock()
+
+	if h.match == nil {
+		return Match{}, false
+	}
+	return h.matchSnapshot(), true
+}
+
+// handleStartMatch handles the start of an arena or crystal match
+// Parameters: [0]=match ID, [1]=match type
+func (h *AlbionHandler) handleStartMatch(params map[byte]interface{}) {
+	h.matchMu.Lock()
+	h.match = &Match{
+		ID:        getInt64(params, 0),
+		Type:      getString(params, 1),
+		StartedAt: time.Now(),
+	}
+	h.matchPlayers = make(map[int64]*MatchPlayerStats)
+	match := h.matchSnapshot()
+	h.matchMu.Unlock()
+
+	name := match.Type
+	if name == "" {
+		name = "Arena"
+	}
+	h.notifyMatch(MatchStarted, fmt.Sprintf("🏟️ %s match started", name), match)
+}
+
+// handleMatchUpdate handles score updates of the current match
+// Parameters: [0]=match ID, [1]=team scores
+func (h *AlbionHandler) handleMatchUpdate(params map[byte]interface{}) {
+	scores := getInt32Slice(params, 1)
+
+	h.matchMu.Lock()
+	if h.match == nil || scores == nil || slices.Equal(scores, h.match.Scores) {
+		h.matchMu.Unlock()
+		return
+	}
+	h.match.Scores = slices.Clone(scores)
+	match := h.matchSnapshot()
+	h.matchMu.Unlock()
+
+	h.notifyMatch(MatchUpdated, fmt.Sprintf("🏟️ Score: %s", formatScores(match.Scores)), match)
+}
+
+// handleMatchPlayerStats handles the stats of a match participant
+// Parameters: [0]=object ID, [1]=player name, [2]=kills, [3]=deaths, [4]=assists
+func (h *AlbionHandler) handleMatchPlayerStats(params map[byte]interface{}) {
+	objectID := getInt64(params, 0)
+	name := h.resolvePlayerName(getString(params, 1), params, 0)
+
+	h.matchMu.Lock()
+	defer h.matchMu.Unlock()
+
+	if h.match == nil {
+		return
+	}
+	player, ok := h.matchPlayers[objectID]
+	if !ok {
+		player = &MatchPlayerStats{ObjectID: objectID}
+		h.matchPlayers[objectID] = player
+	}
+	player.Name = name
+	player.Kills = getInt32(params, 2)
+	player.Deaths = getInt32(params, 3)
+	player.Assists = getInt32(params, 4)
+}
+
+// handleEndArenaMatch handles the end of the current match and resets the match state
+// Parameters: [0]=match ID, [1]=final team scores
+func (h *AlbionHandler) handleEndArenaMatch(params map[byte]interface{}) {
+	h.matchMu.Lock()
+	if h.match == nil {
+		h.matchMu.Unlock()
+		return
+	}
+	if scores := getInt32Slice(params, 1); scores != nil {
+		h.match.Scores = slices.Clone(scores)
+	}
+	match := h.matchSnapshot()
+	h.match = nil
+	h.matchPlayers = nil
+	h.matchMu.Unlock()
+
+	message := "🏟️ Match ended"
+	if len(match.Scores) > 0 {
+		message += fmt.Sprintf(" (%s)", formatScores(match.Scores))
+	}
+	h.notifyMatch(MatchEnded, message, match)
+}
+
+// notifyMatch emits a match event with a snapshot of the match
+func (h *AlbionHandler) notifyMatch(phase, message string, match Match) {
+	h.notifyEvent("match", message, &MatchEventData{Phase: phase, Match: match})
+}
+
+// matchSnapshot copies the current match with its participants. The caller must
+// hold matchMu.
+func (h *AlbionHandler) matchSnapshot() Match {
+	match := *h.match
+	match.Scores = slices.Clone(h.match.Scores)
+	match.Players = make([]MatchPlayerStats, 0, len(h.matchPlayers))
+	for _, player := range h.matchPlayers {
+		match.Players = append(match.Players, *player)
+	}
+	slices.SortFunc(match.Players, func(a, b MatchPlayerStats) int {
+		return cmp.Compare(a.ObjectID, b.ObjectID)
+	})
+	return match
+}
+
+// formatScores formats team scores as "3 - 2"
+func formatScores(scores []int32) string {
+	parts := make([]string, len(scores))
+	for i, score := range scores {
+		parts[i] = fmt.Sprintf("%d", score)
+	}
+	return strings.Join(parts, " - ")
+}
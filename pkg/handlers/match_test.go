@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newMatchTestHandler creates a handler that records match events
+func newMatchTestHandler() (*AlbionHandler, *[]*MatchEventData, *[]string) {
+	handler := NewAlbionHandler()
+
+	var received []*MatchEventData
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if match, ok := data.(*MatchEventData); ok && eventType == "match" {
+			received = append(received, match)
+			messages = append(messages, message)
+		}
+	})
+
+	return handler, &received, &messages
+}
+
+// sendMatchEvent sends a match event with the given parameters
+func sendMatchEvent(handler *AlbionHandler, code events.EventCode, params map[byte]interface{}) {
+	params[events.ParamEventCode] = int16(code)
+	handler.OnEvent(0, params)
+}
+
+// TestMatchLifecycle tests the start, update and end transitions of a match
+func TestMatchLifecycle(t *testing.T) {
+	handler, received, messages := newMatchTestHandler()
+
+	if _, ok := handler.CurrentMatch(); ok {
+		t.Fatal("expected no match initially")
+	}
+
+	// Updates outside a match are ignored
+	sendMatchEvent(handler, events.EventMatchUpdate, map[byte]interface{}{0: int64(1), 1: []int32{1, 0}})
+	if len(*received) != 0 {
+		t.Fatalf("expected no events outside a match, got %d", len(*received))
+	}
+
+	sendMatchEvent(handler, events.EventStartMatch, map[byte]interface{}{0: int64(77), 1: "ARENA"})
+	match, ok := handler.CurrentMatch()
+	if !ok || match.ID != 77 || match.Type != "ARENA" {
+		t.Fatalf("expected match 77 (ARENA), got %+v (active %v)", match, ok)
+	}
+
+	sendMatchEvent(handler, events.EventMatchUpdate, map[byte]interface{}{0: int64(77), 1: []int32{2, 1}})
+	sendMatchEvent(handler, events.EventMatchUpdate, map[byte]interface{}{0: int64(77), 1: []int32{2, 1}}) // Unchanged
+	if match, _ := handler.CurrentMatch(); !slices.Equal(match.Scores, []int32{2, 1}) {
+		t.Errorf("expected scores [2 1], got %v", match.Scores)
+	}
+
+	sendMatchEvent(handler, events.EventEndArenaMatch, map[byte]interface{}{0: int64(77), 1: []int32{3, 1}})
+	if _, ok := handler.CurrentMatch(); ok {
+		t.Error("expected the match to be reset after it ended")
+	}
+
+	expected := []string{
+		"🏟️ ARENA match started",
+		"🏟️ Score: 2 - 1",
+		"🏟️ Match ended (3 - 1)",
+	}
+	if !slices.Equal(*messages, expected) {
+		t.Errorf("expected messages %q, got %q", expected, *messages)
+	}
+
+	phases := []string{MatchStarted, MatchUpdated, MatchEnded}
+	for i, data := range *received {
+		if data.Phase != phases[i] || data.Match.ID != 77 {
+			t.Errorf("event %d: expected phase %s of match 77, got %s of %d", i, phases[i], data.Phase, data.Match.ID)
+		}
+	}
+	if final := (*received)[2].Match; !slices.Equal(final.Scores, []int32{3, 1}) {
+		t.Errorf("expected final scores [3 1], got %v", final.Scores)
+	}
+}
+
+// TestMatchPlayerStats tests that participant stats are captured with resolved names
+func TestMatchPlayerStats(t *testing.T) {
+	handler, received, _ := newMatchTestHandler()
+
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{
+		0: int64(20),
+		1: "Tracked",
+	})
+
+	sendMatchEvent(handler, events.EventStartMatch, map[byte]interface{}{0: int64(5)})
+	sendMatchEvent(handler, events.EventMatchPlayerStatsEvent, map[byte]interface{}{0: int64(30), 1: "Named", 2: int32(1)})
+	sendMatchEvent(handler, events.EventMatchPlayerStatsEvent, map[byte]interface{}{0: int64(20), 2: int32(2), 3: int32(1), 4: int32(4)})
+	sendMatchEvent(handler, events.EventMatchPlayerStatsEvent, map[byte]interface{}{0: int64(30), 1: "Named", 2: int32(3), 3: int32(2)})
+
+	match, _ := handler.CurrentMatch()
+	expected := []MatchPlayerStats{
+		{ObjectID: 20, Name: "Tracked", Kills: 2, Deaths: 1, Assists: 4},
+		{ObjectID: 30, Name: "Named", Kills: 3, Deaths: 2},
+	}
+	if !slices.Equal(match.Players, expected) {
+		t.Errorf("expected players %+v, got %+v", expected, match.Players)
+	}
+
+	// The snapshot is a copy
+	match.Players[0].Kills = 99
+	if current, _ := handler.CurrentMatch(); current.Players[0].Kills != 2 {
+		t.Error("CurrentMatch should return a copy")
+	}
+
+	sendMatchEvent(handler, events.EventEndArenaMatch, map[byte]interface{}{0: int64(5)})
+	if final := (*received)[len(*received)-1]; final.Phase != MatchEnded || len(final.Match.Players) != 2 {
+		t.Errorf("expected the end event to carry the final stats, got %+v", final)
+	}
+	if (*received)[len(*received)-1].Match.Players[0].Kills != 2 {
+		t.Error("expected final stats to be captured")
+	}
+}
+
+// TestConcurrentMatchAccess tests reading the current match while match events are handled
+func TestConcurrentMatchAccess(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			sendMatchEvent(handler, events.EventStartMatch, map[byte]interface{}{0: int64(i), 1: "ARENA"})
+			sendMatchEvent(handler, events.EventMatchPlayerStatsEvent, map[byte]interface{}{0: int64(1), 1: "Lens", 2: int32(i)})
+			sendMatchEvent(handler, events.EventMatchUpdate, map[byte]interface{}{0: int64(i), 1: []int32{int32(i), 0}})
+			sendMatchEvent(handler, events.EventEndArenaMatch, map[byte]interface{}{0: int64(i)})
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		_, _ = handler.CurrentMatch()
+	}
+	wg.Wait()
+
+	if _, ok := handler.CurrentMatch(); ok {
+		t.Error("expected no match after the last one ended")
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/cantalupo555/albion-lens/pkg/mobs"
+)
+
+// maxTrackedMobs bounds the mob registry
+const maxTrackedMobs = 2000
+
+// LoadMobDatabase loads the mob (creature) database from ao-bin-dumps
+func (h *AlbionHandler) LoadMobDatabase(path string) error {
+	h.mobDB = mobs.GetDatabase()
+	return h.mobDB.LoadFromPath(path)
+}
+
+// GetMobType returns the type ID (see pkg/mobs) of the mob in view with the given
+// object ID, false if it is not a known mob
+func (h *AlbionHandler) GetMobType(objectID int64) (int32, bool) {
+	h.mobsMu.RLock()
+	defer h.mobsMu.RUnlock()
+	mobType, ok := h.mobs[objectID]
+	return mobType, ok
+}
+
+// GetMobs returns the type IDs of the mobs in view by object ID
+func (h *AlbionHandler) GetMobs() map[int64]int32 {
+	h.mobsMu.RLock()
+	defer h.mobsMu.RUnlock()
+	return maps.Clone(h.mobs)
+}
+
+// GetMobName returns the name of the mob in view with the given object ID (e.g.
+// "T6 Heretic Archer", "Mob#<type>" without the database), or an empty string if
+// it is not a known mob
+func (h *AlbionHandler) GetMobName(objectID int64) string {
+	mobType, ok := h.GetMobType(objectID)
+	if !ok {
+		return ""
+	}
+	return h.resolveMobName(mobType)
+}
+
+// handleNewMob handles a mob coming into view (no callback)
+// Parameters: [0]=object ID, [1]=mob type ID, [7]=position
+func (h *AlbionHandler) handleNewMob(params map[byte]interface{}) {
+	objectID := h.paramInt64(params, 0)
+	mobType, ok := getInt32Checked(params, 1)
+	if !ok {
+		h.warnParam(params, 1, "int32")
+		return
+	}
+	if pos, ok := getPosition(params, 7); ok {
+		h.setPosition(objectID, pos)
+	}
+
+	h.mobsMu.Lock()
+	defer h.mobsMu.Unlock()
+	if _, known := h.mobs[objectID]; !known && len(h.mobs) >= maxTrackedMobs {
+		// Leave events were missed (e.g. capture started mid-zone), so start over
+		clear(h.mobs)
+	}
+	h.mobs[objectID] = mobType
+}
+
+// resolveMobName returns the mob name from the database, or a placeholder if unavailable
+func (h *AlbionHandler) resolveMobName(mobType int32) string {
+	if h.mobDB != nil && h.mobDB.IsLoaded() {
+		return h.mobDB.GetMobName(int(mobType))
+	}
+	return fmt.Sprintf("Mob#%d", mobType)
+}
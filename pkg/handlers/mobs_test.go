@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newMob returns NewMob event parameters
+func newMob(objectID int64, mobType int32) map[byte]interface{} {
+	return map[byte]interface{}{
+		0:                     objectID,
+		1:                     mobType,
+		events.ParamEventCode: int16(events.EventNewMob),
+	}
+}
+
+// TestNewMobTracking tests that mobs are tracked by object ID until they leave view
+func TestNewMobTracking(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	handler.OnEvent(0, newMob(300, 42))
+	if mobType, ok := handler.GetMobType(300); !ok || mobType != 42 {
+		t.Errorf("expected mob type 42, got %d (ok %v)", mobType, ok)
+	}
+	if name := handler.GetMobName(300); name != "Mob#42" {
+		t.Errorf("expected Mob#42 without the database, got %q", name)
+	}
+	if name := handler.GetMobName(301); name != "" {
+		t.Errorf("expected no name for an unknown object, got %q", name)
+	}
+
+	// Without a type, the mob is ignored
+	handler.OnEvent(0, map[byte]interface{}{0: int64(302), events.ParamEventCode: int16(events.EventNewMob)})
+	if mobs := handler.GetMobs(); len(mobs) != 1 {
+		t.Errorf("expected 1 tracked mob, got %v", mobs)
+	}
+
+	handler.OnEvent(0, map[byte]interface{}{0: int64(300), events.ParamEventCode: int16(events.EventLeave)})
+	if _, ok := handler.GetMobType(300); ok {
+		t.Error("expected mob to be forgotten after leaving view")
+	}
+}
+
+// TestMobRegistryBounded tests that the registry never grows beyond maxTrackedMobs
+func TestMobRegistryBounded(t *testing.T) {
+	handler := NewAlbionHandler()
+	for i := 0; i <= maxTrackedMobs; i++ {
+		handler.OnEvent(0, newMob(int64(i), 1))
+	}
+	if n := len(handler.GetMobs()); n > maxTrackedMobs {
+		t.Errorf("expected at most %d mobs, got %d", maxTrackedMobs, n)
+	}
+}
+
+// TestMobNamesInCombat tests that hits on mobs name the mob from the database
+func TestMobNamesInCombat(t *testing.T) {
+	dir := t.TempDir()
+	mobsJSON := `{"Mobs": {"Mob": [{"@uniquename": "T1_MOB_RABBIT"}, {"@uniquename": "T6_MOB_HERETIC_ARCHER", "@tier": "6"}]}}`
+	if err := os.WriteFile(filepath.Join(dir, "mobs.json"), []byte(mobsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, received := newDamageHandler()
+	if err := handler.LoadMobDatabase(dir); err != nil {
+		t.Fatalf("LoadMobDatabase failed: %v", err)
+	}
+
+	handler.OnEvent(0, newMob(300, 1))
+	handler.OnEvent(byte(events.EventCastHit), map[byte]interface{}{0: int64(100), 1: int64(300), 2: int32(7), 3: float32(-80)})
+
+	if len(*received) != 1 {
+		t.Fatalf("expected 1 combat event, got %d", len(*received))
+	}
+	if target := (*received)[0].Target; target != "T6 Heretic Archer" {
+		t.Errorf("expected target T6 Heretic Archer, got %q", target)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import "time"
+
+// mountLowHealthRatio is the health ratio below which a low mount health event is emitted
+const mountLowHealthRatio = 0.5
+
+// mountState tracks the local player's mount
+type mountState struct {
+	objectID      int64     // Object ID of the mount, 0 when not mounted
+	health        float32   // Current mount health
+	maxHealth     float32   // Maximum mount health
+	lowHealth     bool      // Whether the low health event was emitted for the current drop
+	cooldownUntil time.Time // When the local player can mount again
+}
+
+// MountHealth returns the current and maximum health of the local player's mount.
+// Both are 0 when the local player is not mounted.
+func (h *AlbionHandler) MountHealth() (health, maxHealth float32) {
+	h.mountMu.Lock()
+	defer h.mountMu.Unlock()
+	return h.mount.health, h.mount.maxHealth
+}
+
+// MountCooldown returns the time left before the local player can mount again (0 when ready)
+func (h *AlbionHandler) MountCooldown() time.Duration {
+	h.mountMu.Lock()
+	defer h.mountMu.Unlock()
+	return max(time.Until(h.mount.cooldownUntil), 0)
+}
+
+// handleNewMountObject handles a mount spawning under its rider
+// Parameters: [0]=mount object ID, [1]=rider object ID, [2]=health, [3]=max health
+func (h *AlbionHandler) handleNewMountObject(params map[byte]interface{}) {
+	if localID := h.LocalPlayerID(); localID == 0 || getInt64(params, 1) != localID {
+		return
+	}
+
+	h.mountMu.Lock()
+	defer h.mountMu.Unlock()
+
+	h.mount.objectID = getInt64(params, 0)
+	h.mount.maxHealth = getFloat32(params, 3)
+	h.mount.health = getFloat32(params, 2)
+	if _, hasHealth := params[2]; !hasHealth {
+		h.mount.health = h.mount.maxHealth
+	}
+	h.mount.lowHealth = false
+}
+
+// handleMountHealthUpdate handles damage and healing on a mount
+// Parameters: [0]=mount object ID, [2]=health change (negative for damage), [3]=new health (absent in some updates)
+//
+// When only the change is sent, it is accumulated onto the last known health.
+// Health reaching zero means the local player was dismounted by damage.
+func (h *AlbionHandler) handleMountHealthUpdate(params map[byte]interface{}) {
+	h.mountMu.Lock()
+	if h.mount.objectID == 0 || getInt64(params, 0) != h.mount.objectID {
+		h.mountMu.Unlock()
+		return
+	}
+
+	if _, hasHealth := params[3]; hasHealth {
+		h.mount.health = getFloat32(params, 3)
+	} else {
+		h.mount.health += getFloat32(params, 2)
+	}
+	h.mount.health = min(max(h.mount.health, 0), h.mount.maxHealth)
+
+	kind := ""
+	switch {
+	case h.mount.health <= 0:
+		h.mount = mountState{cooldownUntil: h.mount.cooldownUntil}
+		kind = CombatMountDismounted
+	case h.mount.health < h.mount.maxHealth*mountLowHealthRatio:
+		if !h.mount.lowHealth {
+			h.mount.lowHealth = true
+			kind = CombatMountLowHealth
+		}
+	default:
+		h.mount.lowHealth = false
+	}
+	h.mountMu.Unlock()
+
+	if kind != "" {
+		h.notifyCombat(h.LocalPlayerID(), kind)
+	}
+}
+
+// handleMountCooldownUpdate handles the mount cooldown of a player
+// Parameters: [0]=object ID, [1]=cooldown in seconds
+func (h *AlbionHandler) handleMountCooldownUpdate(params map[byte]interface{}) {
+	if localID := h.LocalPlayerID(); localID == 0 || getInt64(params, 0) != localID {
+		return
+	}
+
+	cooldown := time.Duration(float64(getFloat32(params, 1)) * float64(time.Second))
+
+	h.mountMu.Lock()
+	defer h.mountMu.Unlock()
+	h.mount.cooldownUntil = time.Now().Add(cooldown)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newMountTestHandler creates a handler with a known local player that records combat event kinds
+func newMountTestHandler() (*AlbionHandler, *[]string) {
+	handler := NewAlbionHandler()
+
+	var kinds []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if combat, ok := data.(*CombatEventData); ok && eventType == "combat" {
+			kinds = append(kinds, combat.Kind)
+		}
+	})
+
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{
+		0: int64(100),
+		2: "LocalPlayer",
+	})
+
+	return handler, &kinds
+}
+
+// sendMountEvent sends a mount event with the given parameters
+func sendMountEvent(handler *AlbionHandler, code events.EventCode, params map[byte]interface{}) {
+	params[events.ParamEventCode] = int16(code)
+	handler.OnEvent(0, params)
+}
+
+// TestMountHealthAccumulation tests that health changes accumulate onto the known health
+func TestMountHealthAccumulation(t *testing.T) {
+	handler, kinds := newMountTestHandler()
+
+	if health, maxHealth := handler.MountHealth(); health != 0 || maxHealth != 0 {
+		t.Fatalf("expected no mount initially, got %v/%v", health, maxHealth)
+	}
+
+	// Another player's mount is ignored
+	sendMountEvent(handler, events.EventNewMountObject, map[byte]interface{}{0: int64(900), 1: int64(200), 2: float32(500), 3: float32(500)})
+	if _, maxHealth := handler.MountHealth(); maxHealth != 0 {
+		t.Error("expected other player's mount to be ignored")
+	}
+
+	sendMountEvent(handler, events.EventNewMountObject, map[byte]interface{}{0: int64(901), 1: int64(100), 2: float32(1000), 3: float32(1000)})
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-200)})
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-150)})
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(900), 2: float32(-500)}) // Other mount
+
+	if health, maxHealth := handler.MountHealth(); health != 650 || maxHealth != 1000 {
+		t.Errorf("expected 650/1000, got %v/%v", health, maxHealth)
+	}
+
+	// Healing is capped at max health; an absolute value replaces the accumulated one
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(900)})
+	if health, _ := handler.MountHealth(); health != 1000 {
+		t.Errorf("expected health capped at 1000, got %v", health)
+	}
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-10), 3: float32(800)})
+	if health, _ := handler.MountHealth(); health != 800 {
+		t.Errorf("expected health 800, got %v", health)
+	}
+
+	if len(*kinds) != 0 {
+		t.Errorf("expected no combat events above half health, got %v", *kinds)
+	}
+}
+
+// TestMountDismountDetection tests the low health and dismount events
+func TestMountDismountDetection(t *testing.T) {
+	handler, kinds := newMountTestHandler()
+
+	sendMountEvent(handler, events.EventNewMountObject, map[byte]interface{}{0: int64(901), 1: int64(100), 3: float32(1000)})
+	if health, _ := handler.MountHealth(); health != 1000 {
+		t.Errorf("expected a new mount at full health, got %v", health)
+	}
+
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-600)})
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-100)})
+	if len(*kinds) != 1 || (*kinds)[0] != CombatMountLowHealth {
+		t.Fatalf("expected a single low health event, got %v", *kinds)
+	}
+
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-500)})
+	if len(*kinds) != 2 || (*kinds)[1] != CombatMountDismounted {
+		t.Fatalf("expected a dismount event, got %v", *kinds)
+	}
+	if health, maxHealth := handler.MountHealth(); health != 0 || maxHealth != 0 {
+		t.Errorf("expected no mount after dismount, got %v/%v", health, maxHealth)
+	}
+
+	// Updates for the dead mount are ignored
+	sendMountEvent(handler, events.EventMountHealthUpdate, map[byte]interface{}{0: int64(901), 2: float32(-10)})
+	if len(*kinds) != 2 {
+		t.Errorf("expected no events after dismount, got %v", *kinds)
+	}
+}
+
+// TestMountCooldown tests the mount cooldown of the local player
+func TestMountCooldown(t *testing.T) {
+	handler, _ := newMountTestHandler()
+
+	if handler.MountCooldown() != 0 {
+		t.Errorf("expected no cooldown initially, got %v", handler.MountCooldown())
+	}
+
+	sendMountEvent(handler, events.EventMountCooldownUpdate, map[byte]interface{}{0: int64(200), 1: float32(30)})
+	if handler.MountCooldown() != 0 {
+		t.Error("expected other player's cooldown to be ignored")
+	}
+
+	sendMountEvent(handler, events.EventMountCooldownUpdate, map[byte]interface{}{0: int64(100), 1: float32(30)})
+	if cooldown := handler.MountCooldown(); cooldown <= 29*time.Second || cooldown > 30*time.Second {
+		t.Errorf("expected a 30s cooldown, got %v", cooldown)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import "github.com/cantalupo555/albion-lens/pkg/events"
+
+// maxObjectEventDepth bounds nested ObjectEvent unwrapping
+const maxObjectEventDepth = 4
+
+// Default ObjectEvent parameter layout
+const (
+	defaultObjectEventCodeParam   byte = 1 // Inner event code
+	defaultObjectEventParamsParam byte = 2 // Inner event parameters
+)
+
+// handleObjectEvent dispatches back into OnEvent, so it is registered at init time
+// to avoid an initialization cycle with eventHandlers
+func init() {
+	eventHandlers[events.EventObjectEvent] = (*AlbionHandler).handleObjectEvent
+}
+
+// SetObjectEventLayout sets the parameter keys holding the inner event code and
+// parameters of ObjectEvent, in case they move after a game update
+func (h *AlbionHandler) SetObjectEventLayout(codeParam, paramsParam byte) {
+	h.objectEventCodeParam = codeParam
+	h.objectEventParamsParam = paramsParam
+}
+
+// handleObjectEvent unwraps the generic world object container and re-dispatches
+// the inner event through dispatchEvent, so it reaches its specific handler.
+// Parameters: [0]=object ID, [1]=inner event code, [2]=inner parameters (default layout)
+func (h *AlbionHandler) handleObjectEvent(params map[byte]interface{}) {
+	// Guard against ObjectEvents nested without end (e.g. a malformed packet)
+	if h.objectEventDepth >= maxObjectEventDepth {
+		return
+	}
+	if _, hasCode := params[h.objectEventCodeParam]; !hasCode {
+		return
+	}
+
+	innerCode := events.EventCode(getInt64(params, h.objectEventCodeParam))
+	inner := toParamMap(params[h.objectEventParamsParam])
+
+	// Object-scoped events carry the object ID at [0]
+	if _, hasObject := inner[0]; !hasObject {
+		if objectID, ok := params[0]; ok {
+			inner[0] = objectID
+		}
+	}
+	inner[events.ParamEventCode] = int32(innerCode)
+
+	h.objectEventDepth++
+	defer func() { h.objectEventDepth-- }()
+	h.dispatchEvent(byte(innerCode), inner)
+}
+
+// toParamMap converts a decoded Photon dictionary into an event parameter map.
+// The result is always a new map.
+func toParamMap(val interface{}) map[byte]interface{} {
+	result := make(map[byte]interface{})
+	switch v := val.(type) {
+	case map[byte]interface{}:
+		for key, value := range v {
+			result[key] = value
+		}
+	case map[interface{}]interface{}:
+		for key, value := range v {
+			switch k := key.(type) {
+			case byte:
+				result[k] = value
+			case int16:
+				result[byte(k)] = value
+			case int32:
+				result[byte(k)] = value
+			case int64:
+				result[byte(k)] = value
+			case int:
+				result[byte(k)] = value
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// objectEvent builds ObjectEvent parameters wrapping an inner event
+func objectEvent(objectID int64, inner events.EventCode, params interface{}) map[byte]interface{} {
+	return map[byte]interface{}{
+		0:                     objectID,
+		1:                     int16(inner),
+		2:                     params,
+		events.ParamEventCode: int16(events.EventObjectEvent),
+	}
+}
+
+// TestObjectEventDispatchesInner tests that the inner event reaches its handler
+func TestObjectEventDispatchesInner(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	// Inner parameters as decoded from a Photon dictionary, object ID taken from the wrapper
+	handler.OnEvent(0, objectEvent(42, events.EventNewLootChest, map[interface{}]interface{}{
+		byte(3): "DUNGEON_CHEST_RARE",
+	}))
+
+	chests := handler.GetChests()
+	if len(chests) != 1 {
+		t.Fatalf("expected inner NewLootChest to register a chest, got %d chests", len(chests))
+	}
+	if chests[0].ObjectID != 42 || chests[0].Type != ChestTypeRare {
+		t.Errorf("unexpected chest: %+v", chests[0])
+	}
+}
+
+// TestObjectEventCustomHandler tests that custom handlers see the inner event code
+func TestObjectEventCustomHandler(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received map[byte]interface{}
+	handler.RegisterHandler(events.EventHarvestableChangeState, func(params map[byte]interface{}) {
+		received = params
+	})
+
+	handler.OnEvent(0, objectEvent(7, events.EventHarvestableChangeState, map[byte]interface{}{1: int32(3)}))
+
+	if received == nil {
+		t.Fatal("expected custom handler for the inner event to run")
+	}
+	if getInt64(received, 0) != 7 || getInt32(received, 1) != 3 {
+		t.Errorf("unexpected inner parameters: %v", received)
+	}
+}
+
+// TestObjectEventRecursionGuard tests that nested ObjectEvents stop at the depth limit
+func TestObjectEventRecursionGuard(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	calls := 0
+	handler.RegisterHandler(events.EventObjectEvent, func(params map[byte]interface{}) {
+		calls++
+	})
+
+	// An ObjectEvent wrapping itself
+	params := objectEvent(1, events.EventObjectEvent, nil)
+	params[2] = params
+
+	handler.OnEvent(0, params)
+
+	if calls != maxObjectEventDepth+1 {
+		t.Errorf("expected %d nested dispatches, got %d", maxObjectEventDepth+1, calls)
+	}
+	if handler.objectEventDepth != 0 {
+		t.Errorf("expected depth to be restored, got %d", handler.objectEventDepth)
+	}
+}
+
+// TestObjectEventLayout tests a custom parameter layout
+func TestObjectEventLayout(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetObjectEventLayout(4, 5)
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(9),
+		4:                     int16(events.EventNewLootChest),
+		5:                     map[byte]interface{}{3: "AVALON_CHEST"},
+		events.ParamEventCode: int16(events.EventObjectEvent),
+	})
+
+	if len(handler.GetChests()) != 1 {
+		t.Errorf("expected chest from custom layout, got %d chests", len(handler.GetChests()))
+	}
+}
+
+// TestObjectEventMissingCode tests that an ObjectEvent without an inner code is ignored
+func TestObjectEventMissingCode(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	calls := 0
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		calls++
+	})
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(9),
+		events.ParamEventCode: int16(events.EventObjectEvent),
+	})
+
+	if calls != 0 {
+		t.Errorf("expected no events, got %d", calls)
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// Checked variants of the parameter helpers: ok is false if the key is missing or
+// its value has an unexpected type, instead of silently returning zero
+
+func getInt64Checked(params map[byte]interface{}, key byte) (int64, bool) {
+	switch v := params[key].(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func getInt32Checked(params map[byte]interface{}, key byte) (int32, bool) {
+	switch v := params[key].(type) {
+	case int32:
+		return v, true
+	case int64:
+		return int32(v), true
+	case int16:
+		return int32(v), true
+	case int:
+		return int32(v), true
+	}
+	return 0, false
+}
+
+func getStringChecked(params map[byte]interface{}, key byte) (string, bool) {
+	v, ok := params[key].(string)
+	return v, ok
+}
+
+func getBoolChecked(params map[byte]interface{}, key byte) (bool, bool) {
+	v, ok := params[key].(bool)
+	return v, ok
+}
+
+// DebugParams formats event parameters for logging, in key order with their Go
+// types (e.g. "[0]=int64(42) [1]=string(Lens)")
+func DebugParams(params map[byte]interface{}) string {
+	keys := make([]byte, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("[%d]=%T(%v)", key, params[key], params[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// The param* methods return a parameter a handler expects, like the get* helpers,
+// and in debug mode report it when it is missing or mistyped: a sign the protocol
+// changed with a game update
+
+func (h *AlbionHandler) paramInt64(params map[byte]interface{}, key byte) int64 {
+	v, ok := getInt64Checked(params, key)
+	if !ok {
+		h.warnParam(params, key, "int64")
+	}
+	return v
+}
+
+func (h *AlbionHandler) paramInt32(params map[byte]interface{}, key byte) int32 {
+	v, ok := getInt32Checked(params, key)
+	if !ok {
+		h.warnParam(params, key, "int32")
+	}
+	return v
+}
+
+func (h *AlbionHandler) paramString(params map[byte]interface{}, key byte) string {
+	v, ok := getStringChecked(params, key)
+	if !ok {
+		h.warnParam(params, key, "string")
+	}
+	return v
+}
+
+// warnParam emits a debug event for an expected parameter that is missing or
+// mistyped, with all the event parameters
+func (h *AlbionHandler) warnParam(params map[byte]interface{}, key byte, expected string) {
+	if !h.debug {
+		return
+	}
+
+	problem := "missing"
+	if val, ok := params[key]; ok {
+		problem = fmt.Sprintf("is %T", val)
+	}
+	code, _ := getInt64Checked(params, events.ParamEventCode)
+	h.notifyEvent("debug", fmt.Sprintf("⚠️ %v: param [%d] %s, expected %s (%s)",
+		events.EventCode(code), key, problem, expected, DebugParams(params)), nil)
+}
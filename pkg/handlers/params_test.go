@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestCheckedParams tests that the checked helpers tell zero values apart from
+// missing and mistyped parameters
+func TestCheckedParams(t *testing.T) {
+	params := map[byte]interface{}{
+		0: int16(7),
+		1: "Lens",
+		2: true,
+		3: int64(0),
+		4: "7",
+	}
+
+	if v, ok := getInt64Checked(params, 0); !ok || v != 7 {
+		t.Errorf("expected 7, got %d (ok %v)", v, ok)
+	}
+	if v, ok := getInt32Checked(params, 3); !ok || v != 0 {
+		t.Errorf("expected a valid 0, got %d (ok %v)", v, ok)
+	}
+	if _, ok := getInt64Checked(params, 4); ok {
+		t.Error("expected a string to be rejected as an integer")
+	}
+	if _, ok := getInt32Checked(params, 9); ok {
+		t.Error("expected a missing key to be rejected")
+	}
+	if v, ok := getStringChecked(params, 1); !ok || v != "Lens" {
+		t.Errorf("expected Lens, got %q (ok %v)", v, ok)
+	}
+	if v, ok := getBoolChecked(params, 2); !ok || !v {
+		t.Errorf("expected true, got %v (ok %v)", v, ok)
+	}
+	if _, ok := getBoolChecked(params, 0); ok {
+		t.Error("expected an integer to be rejected as a bool")
+	}
+}
+
+// TestDebugParams tests the parameter dump, sorted by key
+func TestDebugParams(t *testing.T) {
+	params := map[byte]interface{}{
+		5: "Lens",
+		0: int64(42),
+		2: []int32{1, 2},
+	}
+
+	expected := "[0]=int64(42) [2]=[]int32([1 2]) [5]=string(Lens)"
+	if got := DebugParams(params); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestParamWarnings tests that missing and mistyped parameters are reported in
+// debug mode only
+func TestParamWarnings(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var warnings []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "debug" && strings.Contains(message, "param") {
+			warnings = append(warnings, message)
+		}
+	})
+
+	newCharacter := map[byte]interface{}{
+		0:                     "not an ID",
+		events.ParamEventCode: int16(events.EventNewCharacter),
+	}
+
+	handler.OnEvent(0, newCharacter)
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings outside debug mode, got %v", warnings)
+	}
+
+	handler.SetDebug(true)
+	handler.OnEvent(0, newCharacter)
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "NewCharacter: param [0] is string, expected int64") {
+		t.Errorf("expected a mistyped object ID warning, got %q", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "param [1] missing, expected string") || !strings.Contains(warnings[1], "[0]=string(not an ID)") {
+		t.Errorf("expected a missing name warning with the parameters, got %q", warnings[1])
+	}
+}
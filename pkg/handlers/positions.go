@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"maps"
+	"math"
+)
+
+// Move event position layout: [1] is a byte array with a timestamp and flags followed
+// by the X and Y coordinates as little-endian float32s
+const (
+	moveOffsetX = 9
+	moveOffsetY = 13
+)
+
+// Position is the map position of an object
+type Position struct {
+	X, Y float32
+}
+
+// SetTrackPositions enables tracking the positions of objects in view from Move and
+// NewCharacter events (see GetPositions). Off by default, as Move is the most frequent
+// event. Disabling it drops the tracked positions.
+func (h *AlbionHandler) SetTrackPositions(track bool) {
+	h.positionsMu.Lock()
+	defer h.positionsMu.Unlock()
+
+	h.trackPositions = track
+	if !track {
+		clear(h.positions)
+	}
+}
+
+// GetPositions returns the last known positions of objects in view by object ID
+func (h *AlbionHandler) GetPositions() map[int64]Position {
+	h.positionsMu.RLock()
+	defer h.positionsMu.RUnlock()
+	return maps.Clone(h.positions)
+}
+
+// handleMove handles an object moving (no callback)
+// Parameters: [0]=object ID, [1]=position data (see moveOffsetX); a float pair is also accepted
+func (h *AlbionHandler) handleMove(params map[byte]interface{}) {
+	pos, ok := movePosition(params)
+	if ok {
+		h.setPosition(getInt64(params, 0), pos)
+	}
+}
+
+// movePosition decodes the position of a Move event
+func movePosition(params map[byte]interface{}) (Position, bool) {
+	data, isBytes := params[1].([]byte)
+	if !isBytes {
+		return getPosition(params, 1)
+	}
+	if len(data) < moveOffsetY+4 {
+		return Position{}, false
+	}
+	return Position{
+		X: math.Float32frombits(binary.LittleEndian.Uint32(data[moveOffsetX:])),
+		Y: math.Float32frombits(binary.LittleEndian.Uint32(data[moveOffsetY:])),
+	}, true
+}
+
+// getPosition returns a position sent as a float pair or a Vector2/Vector3 custom value
+func getPosition(params map[byte]interface{}, key byte) (Position, bool) {
+	values := getFloat32Slice(params, key)
+	if len(values) < 2 {
+		return Position{}, false
+	}
+	return Position{X: values[0], Y: values[1]}, true
+}
+
+// setPosition records the position of an object if position tracking is enabled
+func (h *AlbionHandler) setPosition(objectID int64, pos Position) {
+	h.positionsMu.Lock()
+	defer h.positionsMu.Unlock()
+
+	if !h.trackPositions {
+		return
+	}
+	if _, known := h.positions[objectID]; !known && len(h.positions) >= maxTrackedPositions {
+		// Leave events were missed (e.g. capture started mid-zone), so start over
+		clear(h.positions)
+	}
+	h.positions[objectID] = pos
+}
+
+// removePosition stops tracking the position of an object that left the view
+func (h *AlbionHandler) removePosition(objectID int64) {
+	h.positionsMu.Lock()
+	delete(h.positions, objectID)
+	h.positionsMu.Unlock()
+}
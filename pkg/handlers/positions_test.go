@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// moveData builds the position data of a Move event
+func moveData(x, y float32) []byte {
+	data := make([]byte, moveOffsetY+4)
+	binary.LittleEndian.PutUint32(data[moveOffsetX:], math.Float32bits(x))
+	binary.LittleEndian.PutUint32(data[moveOffsetY:], math.Float32bits(y))
+	return data
+}
+
+// TestTrackPositions tests positions from NewCharacter and Move, and eviction on Leave
+func TestTrackPositions(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetTrackPositions(true)
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(42),
+		1:                     "Nearby",
+		7:                     []float32{10, 20},
+		events.ParamEventCode: int16(events.EventNewCharacter),
+	})
+	if pos := handler.GetPositions()[42]; pos != (Position{X: 10, Y: 20}) {
+		t.Errorf("expected position (10, 20), got %+v", pos)
+	}
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(42),
+		1:                     moveData(12.5, -3),
+		events.ParamEventCode: int16(events.EventMove),
+	})
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(7),
+		1:                     []interface{}{float32(1), float32(2)},
+		events.ParamEventCode: int16(events.EventMove),
+	})
+
+	positions := handler.GetPositions()
+	if positions[42] != (Position{X: 12.5, Y: -3}) {
+		t.Errorf("expected moved position (12.5, -3), got %+v", positions[42])
+	}
+	if positions[7] != (Position{X: 1, Y: 2}) {
+		t.Errorf("expected position (1, 2) from a float pair, got %+v", positions[7])
+	}
+
+	handler.OnEvent(0, map[byte]interface{}{0: int64(42), events.ParamEventCode: int16(events.EventLeave)})
+	if _, ok := handler.GetPositions()[42]; ok {
+		t.Error("expected position removed on leave")
+	}
+}
+
+// TestTrackPositionsDisabled tests that positions are not tracked by default, and
+// dropped when tracking is disabled
+func TestTrackPositionsDisabled(t *testing.T) {
+	handler := NewAlbionHandler()
+	move := map[byte]interface{}{
+		0:                     int64(1),
+		1:                     moveData(5, 5),
+		events.ParamEventCode: int16(events.EventMove),
+	}
+
+	handler.OnEvent(0, move)
+	if positions := handler.GetPositions(); len(positions) != 0 {
+		t.Errorf("expected no positions by default, got %v", positions)
+	}
+
+	handler.SetTrackPositions(true)
+	handler.OnEvent(0, move)
+	handler.SetTrackPositions(false)
+	if positions := handler.GetPositions(); len(positions) != 0 {
+		t.Errorf("expected positions dropped when disabled, got %v", positions)
+	}
+}
+
+// TestMoveShortData tests that truncated Move data is ignored
+func TestMoveShortData(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetTrackPositions(true)
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     int64(1),
+		1:                     []byte{1, 2, 3},
+		events.ParamEventCode: int16(events.EventMove),
+	})
+	if positions := handler.GetPositions(); len(positions) != 0 {
+		t.Errorf("expected no position from short data, got %v", positions)
+	}
+}
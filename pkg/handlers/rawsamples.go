@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"maps"
+	"reflect"
+	"time"
+)
+
+// DefaultRawSampleThreshold is the number of occurrences below which an unknown
+// event code keeps every occurrence's full parameters in discovery mode
+const DefaultRawSampleThreshold = 10
+
+// DefaultDiscoverySamples is the number of distinct parameter tables kept per
+// discovered event code (see DiscoveredEvent.Samples)
+const DefaultDiscoverySamples = 5
+
+// RawSample is one occurrence of a rare unknown event with its full parameters
+type RawSample struct {
+	Time   time.Time            `json:"time"`
+	Params map[byte]interface{} `json:"params"`
+}
+
+// discoveryFile is the JSON layout written by SaveDiscoveredEvents.
+// Both sections are sorted by event code so repeated saves produce stable, diffable files.
+type discoveryFile struct {
+	Events     []*DiscoveredEvent `json:"events"`
+	RawSamples []rawSampleSet     `json:"raw_samples"`
+}
+
+// rawSampleSet is the raw samples of one event code in a discovery file
+type rawSampleSet struct {
+	Code    int32       `json:"code"`
+	Samples []RawSample `json:"samples"`
+}
+
+// SetRawSampleThreshold sets how many occurrences of an unknown event code are kept
+// in full. Codes seen more often keep only the summary (count, parameter types and
+// one sample), bounding memory for common events. 0 disables raw samples.
+func (h *AlbionHandler) SetRawSampleThreshold(threshold int) {
+	h.discoveryMu.Lock()
+	defer h.discoveryMu.Unlock()
+
+	h.rawSampleThreshold = threshold
+	for code, samples := range h.rawSamples {
+		if len(samples) >= threshold {
+			delete(h.rawSamples, code)
+		}
+	}
+}
+
+// GetRawSamples returns the full parameter samples of rare unknown events by code
+func (h *AlbionHandler) GetRawSamples() map[int32][]RawSample {
+	h.discoveryMu.RLock()
+	defer h.discoveryMu.RUnlock()
+
+	result := make(map[int32][]RawSample, len(h.rawSamples))
+	for code, samples := range h.rawSamples {
+		result[code] = append([]RawSample(nil), samples...)
+	}
+	return result
+}
+
+// sampleRawEvent keeps the full parameters of an unknown event while it is rare.
+// Once the code crosses the threshold, its samples are dropped.
+// Must be called with discoveryMu held.
+func (h *AlbionHandler) sampleRawEvent(event *DiscoveredEvent, params map[byte]interface{}) {
+	if event.Count >= h.rawSampleThreshold {
+		delete(h.rawSamples, event.Code)
+		return
+	}
+
+	h.rawSamples[event.Code] = append(h.rawSamples[event.Code], RawSample{
+		Time:   event.LastSeen,
+		Params: maps.Clone(params),
+	})
+}
+
+// SetDiscoverySamples sets how many distinct parameter tables are kept per discovered
+// event code. Samples already kept beyond the new limit are dropped. 0 disables them.
+func (h *AlbionHandler) SetDiscoverySamples(max int) {
+	h.discoveryMu.Lock()
+	defer h.discoveryMu.Unlock()
+
+	h.discoverySamples = max
+	for _, event := range h.discoveredEvents {
+		if len(event.Samples) > max {
+			event.Samples = event.Samples[:max]
+		}
+	}
+}
+
+// sampleDiscoveredEvent keeps the parameters of an event occurrence if there is room
+// and they differ from every sample already kept, so repeated identical events
+// (e.g. a mob standing still) don't fill the samples.
+// Must be called with discoveryMu held.
+func (h *AlbionHandler) sampleDiscoveredEvent(event *DiscoveredEvent, params map[byte]interface{}) {
+	if len(event.Samples) >= h.discoverySamples {
+		return
+	}
+	for _, sample := range event.Samples {
+		if reflect.DeepEqual(sample, params) {
+			return
+		}
+	}
+	event.Samples = append(event.Samples, maps.Clone(params))
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRawSamplesRareCode tests that rare unknown codes keep every occurrence in full
+func TestRawSamplesRareCode(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+	handler.SetRawSampleThreshold(3)
+
+	handler.OnEvent(200, map[byte]interface{}{1: int32(1)})
+	handler.OnEvent(200, map[byte]interface{}{1: int32(2), 2: "extra"})
+
+	samples := handler.GetRawSamples()[200]
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 raw samples, got %d", len(samples))
+	}
+	if samples[0].Params[1] != int32(1) || samples[1].Params[1] != int32(2) || samples[1].Params[2] != "extra" {
+		t.Errorf("unexpected raw samples: %+v", samples)
+	}
+}
+
+// TestRawSamplesFrequentCodeSummarized tests that samples are dropped once a code crosses the threshold
+func TestRawSamplesFrequentCodeSummarized(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+	handler.SetRawSampleThreshold(3)
+
+	for i := 0; i < 5; i++ {
+		handler.OnEvent(201, map[byte]interface{}{1: int32(i)})
+	}
+
+	if samples, ok := handler.GetRawSamples()[201]; ok {
+		t.Errorf("expected no raw samples for a frequent code, got %d", len(samples))
+	}
+
+	// The summary is still tracked
+	event := handler.GetDiscoveredEvents()[201]
+	if event == nil || event.Count != 5 || event.ParamTypes[1] != "int32" {
+		t.Errorf("expected summary with count 5, got %+v", event)
+	}
+}
+
+// TestRawSamplesSkipHandledCodes tests that handled codes are not sampled
+func TestRawSamplesSkipHandledCodes(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	for code := range eventHandlers {
+		handler.OnEvent(0, map[byte]interface{}{252: int16(code)})
+	}
+
+	if samples := handler.GetRawSamples(); len(samples) != 0 {
+		t.Errorf("expected no raw samples for handled codes, got %d codes", len(samples))
+	}
+}
+
+// TestSaveDiscoveredEventsRawSamples tests that raw samples are saved in their own section
+func TestSaveDiscoveredEventsRawSamples(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	handler.OnEvent(202, map[byte]interface{}{1: "rare"})
+
+	filename := filepath.Join(t.TempDir(), "discovered.json")
+	if err := handler.SaveDiscoveredEvents(filename); err != nil {
+		t.Fatalf("SaveDiscoveredEvents failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	var output struct {
+		Events []struct {
+			Code int32 `json:"code"`
+		} `json:"events"`
+		RawSamples []struct {
+			Code    int32 `json:"code"`
+			Samples []struct {
+				Params map[string]interface{} `json:"params"`
+			} `json:"samples"`
+		} `json:"raw_samples"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(output.Events) != 1 || output.Events[0].Code != 202 {
+		t.Errorf("expected event 202 in the events section, got %+v", output.Events)
+	}
+	if len(output.RawSamples) != 1 || output.RawSamples[0].Code != 202 {
+		t.Fatalf("expected raw samples for 202, got %+v", output.RawSamples)
+	}
+	if samples := output.RawSamples[0].Samples; len(samples) != 1 || samples[0].Params["1"] != "rare" {
+		t.Errorf("expected one raw sample for 202, got %+v", samples)
+	}
+}
+
+// TestDiscoverySamplesDistinct tests that only distinct parameter tables are kept, up to the limit
+func TestDiscoverySamplesDistinct(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+	handler.SetDiscoverySamples(3)
+
+	// Repeated identical events are kept once
+	for i := 0; i < 10; i++ {
+		handler.OnEvent(203, map[byte]interface{}{1: int32(7), 2: "idle"})
+	}
+	if samples := handler.GetDiscoveredEvents()[203].Samples; len(samples) != 1 {
+		t.Fatalf("expected 1 sample for identical events, got %d", len(samples))
+	}
+
+	for i := 0; i < 10; i++ {
+		handler.OnEvent(203, map[byte]interface{}{1: int32(i), 2: "moving"})
+	}
+	samples := handler.GetDiscoveredEvents()[203].Samples
+	if len(samples) != 3 {
+		t.Fatalf("expected samples capped at 3, got %d", len(samples))
+	}
+	if samples[0][2] != "idle" || samples[1][1] != int32(0) || samples[2][1] != int32(1) {
+		t.Errorf("unexpected samples: %v", samples)
+	}
+
+	// Lowering the limit trims kept samples
+	handler.SetDiscoverySamples(1)
+	if samples := handler.GetDiscoveredEvents()[203].Samples; len(samples) != 1 {
+		t.Errorf("expected samples trimmed to 1, got %d", len(samples))
+	}
+}
+
+// TestSaveDiscoveredEventsSamples tests that samples are included in the JSON output
+func TestSaveDiscoveredEventsSamples(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetDiscoveryMode(true)
+
+	handler.OnEvent(204, map[byte]interface{}{1: "first"})
+	handler.OnEvent(204, map[byte]interface{}{1: "second"})
+
+	filename := filepath.Join(t.TempDir(), "discovered.json")
+	if err := handler.SaveDiscoveredEvents(filename); err != nil {
+		t.Fatalf("SaveDiscoveredEvents failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	var output struct {
+		Events []struct {
+			Samples []map[string]interface{} `json:"samples"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(output.Events) != 1 || len(output.Events[0].Samples) != 2 {
+		t.Fatalf("expected 2 samples for 204, got %+v", output.Events)
+	}
+	if output.Events[0].Samples[1]["1"] != "second" {
+		t.Errorf("expected second sample, got %v", output.Events[0].Samples[1])
+	}
+}
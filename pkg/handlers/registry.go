@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// EventHandlerFunc handles the parameters of a single game event
+type EventHandlerFunc func(parameters map[byte]interface{})
+
+// RegisterHandler registers a custom handler for the given event code.
+// Custom handlers run in registration order, before the built-in handler (if any).
+func (h *AlbionHandler) RegisterHandler(code events.EventCode, fn EventHandlerFunc) {
+	if fn == nil {
+		return
+	}
+
+	h.customHandlersMu.Lock()
+	defer h.customHandlersMu.Unlock()
+
+	h.customHandlers[code] = append(h.customHandlers[code], fn)
+}
+
+// RegisterHandlerByName registers a custom handler using the event name
+// (e.g., "OtherGrabbedLoot") instead of its numeric code.
+// Returns an error if the name is not a known event.
+func (h *AlbionHandler) RegisterHandlerByName(name string, fn EventHandlerFunc) error {
+	code, ok := events.EventCodeByName(name)
+	if !ok {
+		return fmt.Errorf("unknown event name: %q", name)
+	}
+
+	h.RegisterHandler(code, fn)
+	return nil
+}
+
+// runCustomHandlers calls all custom handlers registered for the event code.
+// Returns true if at least one handler was called.
+func (h *AlbionHandler) runCustomHandlers(code events.EventCode, parameters map[byte]interface{}) bool {
+	h.customHandlersMu.RLock()
+	fns := h.customHandlers[code]
+	h.customHandlersMu.RUnlock()
+
+	for _, fn := range fns {
+		fn(parameters)
+	}
+	return len(fns) > 0
+}
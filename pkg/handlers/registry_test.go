@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestRegisterHandler tests that custom handlers receive their event
+func TestRegisterHandler(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	calls := 0
+	handler.RegisterHandler(events.EventMove, func(parameters map[byte]interface{}) {
+		calls++
+	})
+
+	handler.OnEvent(byte(events.EventMove), map[byte]interface{}{})
+	handler.OnEvent(byte(events.EventLeave), map[byte]interface{}{})
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+// TestRegisterHandlerByName tests registration by a valid event name
+func TestRegisterHandlerByName(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var received map[byte]interface{}
+	err := handler.RegisterHandlerByName("OtherGrabbedLoot", func(parameters map[byte]interface{}) {
+		received = parameters
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	handler.customHandlersMu.RLock()
+	count := len(handler.customHandlers[events.EventOtherGrabbedLoot])
+	handler.customHandlersMu.RUnlock()
+	if count != 1 {
+		t.Errorf("expected handler registered for code %d, got %d handlers", events.EventOtherGrabbedLoot, count)
+	}
+
+	// Built-in handler still runs alongside the custom one
+	handler.OnEvent(0, map[byte]interface{}{
+		2:                     "Player1",
+		3:                     true,
+		5:                     int64(10000),
+		events.ParamEventCode: int16(events.EventOtherGrabbedLoot),
+	})
+
+	if received == nil {
+		t.Fatal("custom handler was not called")
+	}
+	if handler.GetSessionSilver() != 1 {
+		t.Errorf("expected session silver 1, got %d", handler.GetSessionSilver())
+	}
+}
+
+// TestRegisterHandlerByNameUnknown tests registration by an invalid event name
+func TestRegisterHandlerByNameUnknown(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	err := handler.RegisterHandlerByName("NotARealEvent", func(parameters map[byte]interface{}) {})
+	if err == nil {
+		t.Error("expected error for unknown event name, got nil")
+	}
+
+	if len(handler.customHandlers) != 0 {
+		t.Errorf("expected no handlers registered, got %d", len(handler.customHandlers))
+	}
+}
+
+// TestEventCodeByName tests the reverse lookup of event names
+func TestEventCodeByName(t *testing.T) {
+	code, ok := events.EventCodeByName("OtherGrabbedLoot")
+	if !ok || code != events.EventOtherGrabbedLoot {
+		t.Errorf("expected %d, got %d (ok=%v)", events.EventOtherGrabbedLoot, code, ok)
+	}
+
+	if _, ok := events.EventCodeByName("otherGrabbedLoot"); ok {
+		t.Error("expected lookup to be case-sensitive")
+	}
+}
+
+// TestOperationCodeNames tests operation code names and their reverse lookup
+func TestOperationCodeNames(t *testing.T) {
+	if events.OpJoin.String() != "Join" || events.OpJoin != events.OperationCode(operationJoin) {
+		t.Errorf("expected Join (%d), got %s (%d)", operationJoin, events.OpJoin, events.OpJoin)
+	}
+	if name := events.OperationCode(9999).String(); name != "Unknown(9999)" {
+		t.Errorf("expected Unknown(9999), got %s", name)
+	}
+
+	code, ok := events.OperationCodeByName("AuctionGetOffers")
+	if !ok || code != events.OpAuctionGetOffers {
+		t.Errorf("expected %d, got %d (ok=%v)", events.OpAuctionGetOffers, code, ok)
+	}
+}
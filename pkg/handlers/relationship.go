@@ -0,0 +1,135 @@
+package handlers
+
+// factionHostile is the faction flag of players flagged for open PvP (hostile to everyone)
+const factionHostile = 255
+
+// Relationship is the relationship of a player to the local player
+type Relationship int
+
+const (
+	RelationshipNeutral Relationship = iota // Unknown or unaffiliated player
+	RelationshipSelf                        // The local player
+	RelationshipParty                       // Member of the local player's party
+	RelationshipGuild                       // Member of the local player's guild
+	RelationshipHostile                     // Player flagged hostile
+)
+
+// String returns the name of the relationship
+func (r Relationship) String() string {
+	switch r {
+	case RelationshipSelf:
+		return "self"
+	case RelationshipParty:
+		return "party"
+	case RelationshipGuild:
+		return "guild"
+	case RelationshipHostile:
+		return "hostile"
+	}
+	return "neutral"
+}
+
+// playerAffiliation is what is known about a nearby player's allegiance
+type playerAffiliation struct {
+	guild   string // Guild name, empty if none
+	faction byte   // Faction flag (0 = unflagged, factionHostile = hostile)
+}
+
+// relationshipRegistry holds the state relationships are derived from
+type relationshipRegistry struct {
+	localName  string
+	localGuild string
+	party      map[string]bool              // Party members by name
+	players    map[string]playerAffiliation // Nearby players by name
+}
+
+// newRelationshipRegistry creates an empty relationship registry
+func newRelationshipRegistry() relationshipRegistry {
+	return relationshipRegistry{
+		party:   make(map[string]bool),
+		players: make(map[string]playerAffiliation),
+	}
+}
+
+// classifyRelationship determines the relationship of a player to the local player.
+// Self and party take precedence over guild, and guild over the hostile flag.
+func classifyRelationship(name string, reg relationshipRegistry) Relationship {
+	if name == "" {
+		return RelationshipNeutral
+	}
+	if name == reg.localName {
+		return RelationshipSelf
+	}
+	if reg.party[name] {
+		return RelationshipParty
+	}
+
+	player, known := reg.players[name]
+	if !known {
+		return RelationshipNeutral
+	}
+	if reg.localGuild != "" && player.guild == reg.localGuild {
+		return RelationshipGuild
+	}
+	if player.faction == factionHostile {
+		return RelationshipHostile
+	}
+	return RelationshipNeutral
+}
+
+// Relationship returns the relationship of the named player to the local player,
+// based on the party roster, guild membership and flagging state seen so far
+func (h *AlbionHandler) Relationship(name string) Relationship {
+	h.relationsMu.RLock()
+	defer h.relationsMu.RUnlock()
+	return classifyRelationship(name, h.relations)
+}
+
+// trackAffiliation records the guild and faction flag of a nearby player
+func (h *AlbionHandler) trackAffiliation(name, guild string, faction byte) {
+	h.relationsMu.Lock()
+	defer h.relationsMu.Unlock()
+
+	if len(h.relations.players) >= maxTrackedPlayers {
+		// Players who left are never removed individually, so start over
+		clear(h.relations.players)
+	}
+	h.relations.players[name] = playerAffiliation{guild: guild, faction: faction}
+}
+
+// handlePartyJoined handles the local player joining a party
+// Parameters: [5]=member names
+func (h *AlbionHandler) handlePartyJoined(params map[byte]interface{}) {
+	h.relationsMu.Lock()
+	defer h.relationsMu.Unlock()
+
+	clear(h.relations.party)
+	for _, name := range getStringSlice(params, 5) {
+		h.relations.party[name] = true
+	}
+}
+
+// handlePartyPlayerJoined handles a player joining the local player's party
+// Parameters: [2]=player name
+func (h *AlbionHandler) handlePartyPlayerJoined(params map[byte]interface{}) {
+	if name := getString(params, 2); name != "" {
+		h.relationsMu.Lock()
+		h.relations.party[name] = true
+		h.relationsMu.Unlock()
+	}
+}
+
+// handlePartyPlayerLeft handles a player leaving the local player's party
+// Parameters: [2]=player name
+func (h *AlbionHandler) handlePartyPlayerLeft(params map[byte]interface{}) {
+	h.relationsMu.Lock()
+	delete(h.relations.party, getString(params, 2))
+	h.relationsMu.Unlock()
+}
+
+// handlePartyDisbanded handles the local player's party being disbanded (or left)
+func (h *AlbionHandler) handlePartyDisbanded(params map[byte]interface{}) {
+	h.relationsMu.Lock()
+	clear(h.relations.party)
+	h.relationsMu.Unlock()
+}
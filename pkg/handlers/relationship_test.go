@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestClassifyRelationship tests relationship classification from registry states
+func TestClassifyRelationship(t *testing.T) {
+	reg := relationshipRegistry{
+		localName:  "Me",
+		localGuild: "Lens",
+		party:      map[string]bool{"Buddy": true, "GuildBuddy": true},
+		players: map[string]playerAffiliation{
+			"GuildBuddy": {guild: "Lens", faction: factionHostile},
+			"Guildmate":  {guild: "Lens"},
+			"Bandit":     {guild: "Other", faction: factionHostile},
+			"RedGuildie": {guild: "Lens", faction: factionHostile},
+			"Stranger":   {guild: "Other"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expected Relationship
+	}{
+		{"Me", RelationshipSelf},
+		{"Buddy", RelationshipParty},
+		{"GuildBuddy", RelationshipParty}, // Party takes precedence over guild
+		{"Guildmate", RelationshipGuild},
+		{"RedGuildie", RelationshipGuild}, // Guild takes precedence over the flag
+		{"Bandit", RelationshipHostile},
+		{"Stranger", RelationshipNeutral},
+		{"Unknown", RelationshipNeutral},
+		{"", RelationshipNeutral},
+	}
+
+	for _, tt := range tests {
+		if got := classifyRelationship(tt.name, reg); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	// Without a local guild, players without a guild are not guildmates
+	reg.localGuild = ""
+	reg.players["Guildless"] = playerAffiliation{}
+	if got := classifyRelationship("Guildless", reg); got != RelationshipNeutral {
+		t.Errorf("expected neutral for guildless players, got %v", got)
+	}
+}
+
+// TestRelationshipTracking tests that the registry is built from game events
+func TestRelationshipTracking(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{
+		0:  int64(1),
+		2:  "Me",
+		57: "Lens",
+	})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(2), 1: "Guildmate", 8: "Lens"})
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{0: int64(3), 1: "Bandit", 8: "Other", 53: byte(factionHostile)})
+
+	send := func(code events.EventCode, params map[byte]interface{}) {
+		params[events.ParamEventCode] = int16(code)
+		handler.OnEvent(0, params)
+	}
+	send(events.EventPartyJoined, map[byte]interface{}{5: []string{"Me", "Buddy"}})
+	send(events.EventPartyPlayerJoined, map[byte]interface{}{2: "Newcomer"})
+
+	expected := map[string]Relationship{
+		"Me":        RelationshipSelf,
+		"Buddy":     RelationshipParty,
+		"Newcomer":  RelationshipParty,
+		"Guildmate": RelationshipGuild,
+		"Bandit":    RelationshipHostile,
+	}
+	for name, relationship := range expected {
+		if got := handler.Relationship(name); got != relationship {
+			t.Errorf("%q: expected %v, got %v", name, relationship, got)
+		}
+	}
+
+	send(events.EventPartyPlayerLeft, map[byte]interface{}{2: "Buddy"})
+	if got := handler.Relationship("Buddy"); got != RelationshipNeutral {
+		t.Errorf("expected Buddy to be neutral after leaving, got %v", got)
+	}
+
+	send(events.EventPartyDisbanded, map[byte]interface{}{})
+	if got := handler.Relationship("Newcomer"); got != RelationshipNeutral {
+		t.Errorf("expected Newcomer to be neutral after disband, got %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// sessionFile is the on-disk format of the session counters (see SaveSession)
+type sessionFile struct {
+	TotalFame         int64 `json:"total_fame"`
+	SessionFame       int64 `json:"session_fame"`
+	SessionSilver     int64 `json:"session_silver"`
+	SessionKills      int   `json:"session_kills"`
+	SessionDeaths     int   `json:"session_deaths"`
+	SessionLoot       int   `json:"session_loot"`
+	SessionHarvested  int   `json:"session_harvested"`
+	SessionFishCaught int   `json:"session_fish_caught"`
+}
+
+// SessionTotals is a consistent snapshot of the session counters (see GetSessionTotals)
+type SessionTotals struct {
+	Fame   int64
+	Silver int64
+	Kills  int
+	Deaths int
+	Loot   int
+}
+
+// GetSessionTotals returns the session counters read together, so that they are
+// consistent with each other (unlike separate GetSessionFame, GetSessionKills... calls)
+func (h *AlbionHandler) GetSessionTotals() SessionTotals {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+	return SessionTotals{
+		Fame:   h.sessionFame,
+		Silver: h.sessionSilver,
+		Kills:  h.sessionKills,
+		Deaths: h.sessionDeaths,
+		Loot:   h.sessionLoot,
+	}
+}
+
+// SaveSession writes the session counters to path as JSON, so that a session can be
+// resumed after a restart with LoadSession. The file is replaced atomically.
+func (h *AlbionHandler) SaveSession(path string) error {
+	h.sessionMu.RLock()
+	state := sessionFile{
+		TotalFame:         h.totalFame,
+		SessionFame:       h.sessionFame,
+		SessionSilver:     h.sessionSilver,
+		SessionKills:      h.sessionKills,
+		SessionDeaths:     h.sessionDeaths,
+		SessionLoot:       h.sessionLoot,
+		SessionHarvested:  h.sessionHarvested,
+		SessionFishCaught: h.sessionFishCaught,
+	}
+	h.sessionMu.RUnlock()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated session
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSession restores the session counters saved by SaveSession, so that they
+// resume from the saved values instead of starting from zero.
+// It should be called before events are handled.
+func (h *AlbionHandler) LoadSession(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var state sessionFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid session file: %w", err)
+	}
+
+	h.sessionMu.Lock()
+	defer h.sessionMu.Unlock()
+
+	h.totalFame = state.TotalFame
+	h.sessionFame = state.SessionFame
+	h.sessionSilver = state.SessionSilver
+	h.sessionKills = state.SessionKills
+	h.sessionDeaths = state.SessionDeaths
+	h.sessionLoot = state.SessionLoot
+	h.sessionHarvested = state.SessionHarvested
+	h.sessionFishCaught = state.SessionFishCaught
+	return nil
+}
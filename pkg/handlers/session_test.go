@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestSaveLoadSession tests that saved counters are restored and resume on new events
+func TestSaveLoadSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session", "state.json")
+
+	handler := NewAlbionHandler()
+	handler.OnEvent(0, map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventUpdateFame),
+		1:                     int64(50_000_000),
+		2:                     int64(1_000_000),
+	})
+	handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventKilledPlayer)})
+
+	if err := handler.SaveSession(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored := NewAlbionHandler()
+	if err := restored.LoadSession(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.GetSessionFame() != 100 {
+		t.Errorf("expected restored fame 100, got %d", restored.GetSessionFame())
+	}
+	if restored.GetSessionKills() != 1 {
+		t.Errorf("expected restored kills 1, got %d", restored.GetSessionKills())
+	}
+
+	// The same total fame is a duplicate; a higher one adds to the restored session
+	for _, total := range []int64{50_000_000, 52_000_000} {
+		restored.OnEvent(0, map[byte]interface{}{
+			events.ParamEventCode: int16(events.EventUpdateFame),
+			1:                     total,
+			2:                     int64(2_000_000),
+		})
+	}
+	restored.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventKilledPlayer)})
+
+	if restored.GetSessionFame() != 300 {
+		t.Errorf("expected fame to resume at 300, got %d", restored.GetSessionFame())
+	}
+	if restored.GetSessionKills() != 2 {
+		t.Errorf("expected kills to resume at 2, got %d", restored.GetSessionKills())
+	}
+}
+
+// TestLoadSessionErrors tests that missing and invalid session files are reported
+func TestLoadSessionErrors(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	if err := handler.LoadSession(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler.LoadSession(path); err == nil {
+		t.Error("expected error for invalid file, got nil")
+	}
+}
+
+// TestGetSessionTotals tests that the totals match the individual getters
+func TestGetSessionTotals(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.OnEvent(0, map[byte]interface{}{
+		events.ParamEventCode: int16(events.EventUpdateFame),
+		1:                     int64(50_000_000),
+		2:                     int64(1_000_000),
+	})
+	handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventKilledPlayer)})
+
+	totals := handler.GetSessionTotals()
+	if totals.Fame != handler.GetSessionFame() || totals.Silver != handler.GetSessionSilver() {
+		t.Errorf("expected fame %d and silver %d, got %+v", handler.GetSessionFame(), handler.GetSessionSilver(), totals)
+	}
+	if totals.Kills != 1 || totals.Deaths != 0 {
+		t.Errorf("expected 1 kill and 0 deaths, got %+v", totals)
+	}
+}
+
+// TestSessionConcurrentEvents tests that counters stay consistent when events are
+// handled on several goroutines while the session is saved
+func TestSessionConcurrentEvents(t *testing.T) {
+	handler := NewAlbionHandler()
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventKilledPlayer)})
+				_ = handler.SaveSession(path)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if handler.GetSessionKills() != 400 {
+		t.Errorf("expected 400 kills, got %d", handler.GetSessionKills())
+	}
+}
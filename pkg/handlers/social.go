@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+)
+
+// socialRateLimit is the minimum interval between social events of the same kind from the same player
+const socialRateLimit = 30 * time.Second
+
+// Social event kinds
+const (
+	SocialGuildInvite   = "guild_invite"
+	SocialPartyInvite   = "party_invite"
+	SocialFriendRequest = "friend_request"
+)
+
+// SocialEventData contains social interaction event data (invitations, friend requests)
+type SocialEventData struct {
+	Kind  string // One of the Social* kinds
+	From  string // Name of the player who sent the invitation
+	Guild string // Guild name (guild invites only)
+}
+
+// socialKey identifies a rate-limited social event stream
+type socialKey struct {
+	kind string
+	from string
+}
+
+// handleInvitedToGuild handles an invitation to join a guild
+// Parameters: [1]=guild name, [2]=inviter name, [3]=inviter object ID
+func (h *AlbionHandler) handleInvitedToGuild(params map[byte]interface{}) {
+	h.notifySocial(&SocialEventData{
+		Kind:  SocialGuildInvite,
+		From:  h.resolvePlayerName(getString(params, 2), params, 3),
+		Guild: getString(params, 1),
+	})
+}
+
+// handlePartyInvitation handles an invitation to join a party
+// Parameters: [0]=inviter object ID, [1]=inviter name
+func (h *AlbionHandler) handlePartyInvitation(params map[byte]interface{}) {
+	h.notifySocial(&SocialEventData{
+		Kind: SocialPartyInvite,
+		From: h.resolvePlayerName(getString(params, 1), params, 0),
+	})
+}
+
+// handleFriendRequest handles a friend request
+// Parameters: [0]=requester name
+func (h *AlbionHandler) handleFriendRequest(params map[byte]interface{}) {
+	h.notifySocial(&SocialEventData{
+		Kind: SocialFriendRequest,
+		From: getString(params, 0),
+	})
+}
+
+// resolvePlayerName returns name, or the tracked name of the player whose object ID
+// is at idKey when the event doesn't carry one
+func (h *AlbionHandler) resolvePlayerName(name string, params map[byte]interface{}, idKey byte) string {
+	if name != "" {
+		return name
+	}
+	if _, ok := params[idKey]; ok {
+		if tracked, ok := h.trackedPlayer(getInt64(params, idKey)); ok {
+			return tracked
+		}
+	}
+	return "Someone"
+}
+
+// notifySocial emits a social event, rate-limited per kind and sender so that
+// repeated invitations don't flood the log
+func (h *AlbionHandler) notifySocial(data *SocialEventData) {
+	key := socialKey{kind: data.Kind, from: data.From}
+	now := time.Now()
+	if last, ok := h.socialLastSent[key]; ok && now.Sub(last) < socialRateLimit {
+		return
+	}
+	if len(h.socialLastSent) >= maxTrackedPlayers {
+		clear(h.socialLastSent)
+	}
+	h.socialLastSent[key] = now
+
+	var message string
+	switch data.Kind {
+	case SocialGuildInvite:
+		message = fmt.Sprintf("🤝 %s invited you to guild %s", data.From, data.Guild)
+	case SocialPartyInvite:
+		message = fmt.Sprintf("🤝 %s invited you to their party", data.From)
+	case SocialFriendRequest:
+		message = fmt.Sprintf("🤝 %s sent you a friend request", data.From)
+	}
+	h.notifyEvent("social", message, data)
+}
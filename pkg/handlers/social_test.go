@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newSocialTestHandler creates a handler that records social event messages
+func newSocialTestHandler() (*AlbionHandler, *[]string) {
+	handler := NewAlbionHandler()
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if _, ok := data.(*SocialEventData); ok && eventType == "social" {
+			messages = append(messages, message)
+		}
+	})
+
+	return handler, &messages
+}
+
+// sendSocialEvent sends a social event with the given parameters
+func sendSocialEvent(handler *AlbionHandler, code events.EventCode, params map[byte]interface{}) {
+	params[events.ParamEventCode] = int16(code)
+	handler.OnEvent(0, params)
+}
+
+// TestGuildInvite tests that a guild invite names the inviter and the guild
+func TestGuildInvite(t *testing.T) {
+	handler, messages := newSocialTestHandler()
+
+	sendSocialEvent(handler, events.EventInvitedToGuild, map[byte]interface{}{
+		0: int64(7),
+		1: "Lens Guild",
+		2: "Inviter",
+	})
+
+	expected := "🤝 Inviter invited you to guild Lens Guild"
+	if len(*messages) != 1 || (*messages)[0] != expected {
+		t.Errorf("expected [%q], got %q", expected, *messages)
+	}
+}
+
+// TestPartyInvite tests that a party invite resolves the inviter from tracked players
+func TestPartyInvite(t *testing.T) {
+	handler, messages := newSocialTestHandler()
+
+	handler.OnEvent(byte(events.EventNewCharacter), map[byte]interface{}{
+		0: int64(555),
+		1: "PartyLeader",
+	})
+	sendSocialEvent(handler, events.EventPartyInvitation, map[byte]interface{}{0: int64(555)})
+
+	expected := "🤝 PartyLeader invited you to their party"
+	if len(*messages) != 1 || (*messages)[0] != expected {
+		t.Errorf("expected [%q], got %q", expected, *messages)
+	}
+
+	// Unknown inviters still produce a message
+	sendSocialEvent(handler, events.EventPartyInvitation, map[byte]interface{}{0: int64(999)})
+	if len(*messages) != 2 || (*messages)[1] != "🤝 Someone invited you to their party" {
+		t.Errorf("expected an anonymous invite, got %q", *messages)
+	}
+}
+
+// TestSocialRateLimit tests that repeated invitations from the same player are collapsed
+func TestSocialRateLimit(t *testing.T) {
+	handler, messages := newSocialTestHandler()
+
+	for i := 0; i < 3; i++ {
+		sendSocialEvent(handler, events.EventFriendRequest, map[byte]interface{}{0: "Spammer"})
+	}
+	sendSocialEvent(handler, events.EventFriendRequest, map[byte]interface{}{0: "Friend"})
+
+	if len(*messages) != 2 {
+		t.Fatalf("expected 2 friend requests, got %q", *messages)
+	}
+	if (*messages)[1] != "🤝 Friend sent you a friend request" {
+		t.Errorf("unexpected message %q", (*messages)[1])
+	}
+}
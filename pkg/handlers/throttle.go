@@ -0,0 +1,68 @@
+package handlers
+
+import "time"
+
+// tokenBucket limits an event type to a rate, allowing bursts of up to one second of events
+type tokenBucket struct {
+	rate   float64 // Tokens added per second, also the bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket for the time elapsed since the last call and takes a token,
+// returning false if none is left
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// SetEventRateLimit limits the events of a type (e.g. "combat") passed to the event
+// callback to perSecond, with bursts of up to perSecond events. Events over the limit
+// are dropped and reported to the throttle callback. perSecond <= 0 removes the limit.
+func (h *AlbionHandler) SetEventRateLimit(eventType string, perSecond int) {
+	h.rateLimitsMu.Lock()
+	defer h.rateLimitsMu.Unlock()
+
+	if perSecond <= 0 {
+		delete(h.rateLimits, eventType)
+		return
+	}
+	if h.rateLimits == nil {
+		h.rateLimits = make(map[string]*tokenBucket)
+	}
+	h.rateLimits[eventType] = &tokenBucket{
+		rate:   float64(perSecond),
+		tokens: float64(perSecond),
+		last:   time.Now(),
+	}
+}
+
+// SetThrottleCallback sets a function called for every event dropped by a rate limit,
+// so throttled events can be counted apart from events dropped by full channels
+func (h *AlbionHandler) SetThrottleCallback(callback func()) {
+	h.rateLimitsMu.Lock()
+	defer h.rateLimitsMu.Unlock()
+	h.onThrottle = callback
+}
+
+// allowEvent reports whether an event of the given type is within its rate limit
+func (h *AlbionHandler) allowEvent(eventType string) bool {
+	h.rateLimitsMu.Lock()
+	bucket, limited := h.rateLimits[eventType]
+	if !limited || bucket.take(time.Now()) {
+		h.rateLimitsMu.Unlock()
+		return true
+	}
+	onThrottle := h.onThrottle
+	h.rateLimitsMu.Unlock()
+
+	if onThrottle != nil {
+		onThrottle()
+	}
+	return false
+}
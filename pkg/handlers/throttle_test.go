@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEventRateLimit tests that events over the limit are dropped and reported, per type
+func TestEventRateLimit(t *testing.T) {
+	handler := NewAlbionHandler()
+	handler.SetEventRateLimit("combat", 2)
+
+	received := make(map[string]int)
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		received[eventType]++
+	})
+	throttled := 0
+	handler.SetThrottleCallback(func() { throttled++ })
+
+	for i := 0; i < 5; i++ {
+		handler.notifyEvent("combat", "", nil)
+		handler.notifyEvent("fame", "", nil)
+	}
+
+	if received["combat"] != 2 || throttled != 3 {
+		t.Errorf("expected 2 combat events and 3 throttled, got %d and %d", received["combat"], throttled)
+	}
+	if received["fame"] != 5 {
+		t.Errorf("expected unlimited fame events, got %d", received["fame"])
+	}
+
+	// Removing the limit lets every event through
+	handler.SetEventRateLimit("combat", 0)
+	handler.notifyEvent("combat", "", nil)
+	if received["combat"] != 3 {
+		t.Errorf("expected combat event after removing the limit, got %d", received["combat"])
+	}
+}
+
+// TestTokenBucketRefill tests that tokens are refilled with time, up to the burst size
+func TestTokenBucketRefill(t *testing.T) {
+	now := time.Now()
+	bucket := &tokenBucket{rate: 10, tokens: 0, last: now}
+
+	if bucket.take(now) {
+		t.Fatal("expected empty bucket to refuse")
+	}
+	if !bucket.take(now.Add(100 * time.Millisecond)) {
+		t.Error("expected a token after 100ms at 10/s")
+	}
+
+	// A long pause refills to the burst size only
+	later := now.Add(time.Minute)
+	taken := 0
+	for bucket.take(later) {
+		taken++
+	}
+	if taken != 10 {
+		t.Errorf("expected a burst of 10, got %d", taken)
+	}
+}
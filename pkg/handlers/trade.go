@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// TradeEventData contains the outcome of a finished player trade, from the local
+// player's point of view
+type TradeEventData struct {
+	Partner       string          // Name of the other party
+	ItemsGiven    []LootEventData // Items handed to the partner
+	ItemsReceived []LootEventData // Items received from the partner
+	SilverDelta   int64           // Silver received minus silver given
+}
+
+// handlePlayerTradeFinished emits a trade event for a completed trade
+// Parameters: [0]=first party name, [1]=second party name, [2]=items given by the
+// first party, [3]=items given by the second party, [4]=silver given by the first
+// party (FixPoint), [5]=silver given by the second party (FixPoint)
+//
+// Item containers are arrays of entries (each an array or dictionary of
+// [0]=item ID, [1]=quantity) or dictionaries of item ID to quantity (see tradeItems).
+// The local player is assumed to be the first party unless they are named second.
+func (h *AlbionHandler) handlePlayerTradeFinished(params map[byte]interface{}) {
+	local, partner := getString(params, 0), getString(params, 1)
+	given, received := params[2], params[3]
+	silverGiven, silverReceived := getInt64(params, 4), getInt64(params, 5)
+
+	_, localName := h.localPlayer()
+	if localName != "" && partner == localName {
+		local, partner = partner, local
+		given, received = received, given
+		silverGiven, silverReceived = silverReceived, silverGiven
+	}
+	if partner == "" {
+		return
+	}
+
+	// Silver uses FixPoint format (divide by 10000)
+	data := &TradeEventData{
+		Partner:       partner,
+		ItemsGiven:    h.tradeItems(given, local, partner),
+		ItemsReceived: h.tradeItems(received, partner, local),
+		SilverDelta:   int64(math.Floor(float64(silverReceived-silverGiven) / 10000.0)),
+	}
+	if len(data.ItemsGiven) == 0 && len(data.ItemsReceived) == 0 && data.SilverDelta == 0 {
+		return
+	}
+
+	// Message formatting is handled by the frontend (TUI)
+	h.notifyEvent("trade", "", data)
+}
+
+// tradeItems decodes an item container of a trade handed from one party to the
+// other, skipping malformed entries
+func (h *AlbionHandler) tradeItems(container interface{}, from, to string) []LootEventData {
+	var items []LootEventData
+	add := func(itemID int32, quantity int64) {
+		if itemID <= 0 {
+			return
+		}
+		if quantity <= 0 {
+			quantity = 1
+		}
+		items = append(items, LootEventData{
+			LootedBy:       to,
+			ItemID:         itemID,
+			UniqueName:     h.resolveUniqueName(itemID),
+			ItemName:       h.resolveItemName(itemID),
+			Quantity:       quantity,
+			LootedFrom:     from,
+			EstimatedValue: h.GetEstimatedValue(itemID) * quantity,
+		})
+	}
+
+	switch v := container.(type) {
+	case []interface{}:
+		for _, entry := range v {
+			switch e := entry.(type) {
+			case []interface{}:
+				if len(e) >= 2 {
+					add(int32(toInt64(e[0])), toInt64(e[1]))
+				} else if len(e) == 1 {
+					add(int32(toInt64(e[0])), 1)
+				}
+			case []int32:
+				if len(e) >= 2 {
+					add(e[0], int64(e[1]))
+				}
+			case map[byte]interface{}, map[interface{}]interface{}:
+				fields := toParamMap(e)
+				add(getInt32(fields, 0), getInt64(fields, 1))
+			}
+		}
+	case []int32:
+		// Bare item IDs, one unit each
+		for _, itemID := range v {
+			add(itemID, 1)
+		}
+	case map[interface{}]interface{}:
+		for itemID, quantity := range v {
+			add(int32(toInt64(itemID)), toInt64(quantity))
+		}
+		// Dictionaries have no order
+		slices.SortFunc(items, func(a, b LootEventData) int { return cmp.Compare(a.ItemID, b.ItemID) })
+	}
+	return items
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// tradeHandler returns a handler recording its trade events
+func tradeHandler() (*AlbionHandler, *[]*TradeEventData) {
+	handler := NewAlbionHandler()
+	var trades []*TradeEventData
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "trade" {
+			trades = append(trades, data.(*TradeEventData))
+		}
+	})
+	return handler, &trades
+}
+
+// TestPlayerTradeFinished tests decoding the items and silver exchanged in a trade
+func TestPlayerTradeFinished(t *testing.T) {
+	handler, trades := tradeHandler()
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0: "Me",
+		1: "Merchant",
+		2: []interface{}{
+			[]interface{}{int32(100), int16(3)},
+			map[interface{}]interface{}{byte(0): int16(101), byte(1): int32(1)},
+		},
+		3:                     []interface{}{[]int32{200, 5}},
+		4:                     int64(1000000),
+		5:                     int64(25000000),
+		events.ParamEventCode: int16(events.EventPlayerTradeFinished),
+	})
+
+	if len(*trades) != 1 {
+		t.Fatalf("expected 1 trade event, got %d", len(*trades))
+	}
+	trade := (*trades)[0]
+	if trade.Partner != "Merchant" {
+		t.Errorf("expected partner Merchant, got %q", trade.Partner)
+	}
+	if trade.SilverDelta != 2400 {
+		t.Errorf("expected silver delta 2400, got %d", trade.SilverDelta)
+	}
+	if len(trade.ItemsGiven) != 2 || trade.ItemsGiven[0].ItemID != 100 || trade.ItemsGiven[0].Quantity != 3 || trade.ItemsGiven[1].ItemID != 101 {
+		t.Fatalf("expected items 100 (x3) and 101 given, got %+v", trade.ItemsGiven)
+	}
+	if given := trade.ItemsGiven[0]; given.LootedBy != "Merchant" || given.LootedFrom != "Me" || given.ItemName != "Item#100" {
+		t.Errorf("expected item handed from Me to Merchant, got %+v", given)
+	}
+	if len(trade.ItemsReceived) != 1 || trade.ItemsReceived[0].ItemID != 200 || trade.ItemsReceived[0].Quantity != 5 {
+		t.Errorf("expected item 200 (x5) received, got %+v", trade.ItemsReceived)
+	}
+}
+
+// TestPlayerTradeFinishedLocalSecond tests that the trade is mirrored when the
+// local player is the second party
+func TestPlayerTradeFinishedLocalSecond(t *testing.T) {
+	handler, trades := tradeHandler()
+	handler.localPlayerName = "Me"
+
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     "Merchant",
+		1:                     "Me",
+		2:                     map[interface{}]interface{}{int32(300): int32(2), int32(100): int32(1)},
+		5:                     int64(10000000),
+		events.ParamEventCode: int16(events.EventPlayerTradeFinished),
+	})
+
+	if len(*trades) != 1 {
+		t.Fatalf("expected 1 trade event, got %d", len(*trades))
+	}
+	trade := (*trades)[0]
+	if trade.Partner != "Merchant" || trade.SilverDelta != -1000 {
+		t.Errorf("expected Merchant with -1000 silver, got %q with %d", trade.Partner, trade.SilverDelta)
+	}
+	if len(trade.ItemsGiven) != 0 {
+		t.Errorf("expected no items given, got %+v", trade.ItemsGiven)
+	}
+	if len(trade.ItemsReceived) != 2 || trade.ItemsReceived[0].ItemID != 100 || trade.ItemsReceived[1].ItemID != 300 {
+		t.Errorf("expected items 100 and 300 received, got %+v", trade.ItemsReceived)
+	}
+}
+
+// TestPlayerTradeFinishedEmpty tests that malformed or empty trades are ignored
+func TestPlayerTradeFinishedEmpty(t *testing.T) {
+	handler, trades := tradeHandler()
+
+	for _, params := range []map[byte]interface{}{
+		{0: "Me", 1: "Merchant"},
+		{0: "Me", 2: []interface{}{[]interface{}{int32(100), int32(1)}}},
+		{0: "Me", 1: "Merchant", 2: []interface{}{"junk", []interface{}{}, []interface{}{int32(-1), int32(1)}}},
+	} {
+		params[events.ParamEventCode] = int16(events.EventPlayerTradeFinished)
+		handler.OnEvent(0, params)
+	}
+
+	if len(*trades) != 0 {
+		t.Errorf("expected no trade events, got %d", len(*trades))
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransformationState describes whether the local player is transformed
+// (e.g., into a creature form), which changes the abilities they can use
+type TransformationState struct {
+	Active bool      // Whether the local player is currently transformed
+	FormID int32     // Transformation (form) ID reported by the server, 0 if unknown
+	Since  time.Time // When the current transformation started (zero if not transformed)
+}
+
+// TransformationState returns the local player's current transformation state.
+// Combat features can use it to avoid attributing form abilities to the player's build.
+func (h *AlbionHandler) TransformationState() TransformationState {
+	h.transformMu.Lock()
+	defer h.transformMu.Unlock()
+	return h.transformation
+}
+
+// handleTransformation handles a player transforming
+// Parameters: [0]=object ID, [1]=transformation (form) ID
+func (h *AlbionHandler) handleTransformation(params map[byte]interface{}) {
+	localID, localName := h.localPlayer()
+	if localID == 0 || getInt64(params, 0) != localID {
+		return
+	}
+
+	state := TransformationState{
+		Active: true,
+		FormID: getInt32(params, 1),
+		Since:  time.Now(),
+	}
+	h.transformMu.Lock()
+	h.transformation = state
+	h.transformMu.Unlock()
+	h.notifyEvent("info", fmt.Sprintf("🐾 %s transformed", localName), &state)
+}
+
+// handleTransformationEnd handles a player returning to their normal form
+// Parameters: [0]=object ID
+func (h *AlbionHandler) handleTransformationEnd(params map[byte]interface{}) {
+	localID, localName := h.localPlayer()
+	if getInt64(params, 0) != localID {
+		return
+	}
+
+	h.transformMu.Lock()
+	if !h.transformation.Active {
+		h.transformMu.Unlock()
+		return
+	}
+	duration := time.Since(h.transformation.Since).Round(time.Second)
+	h.transformation = TransformationState{}
+	h.transformMu.Unlock()
+
+	h.notifyEvent("info", fmt.Sprintf("🐾 %s transformation ended (%s)", localName, duration), &TransformationState{})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// newTransformationTestHandler creates a handler with a known local player that records info events
+func newTransformationTestHandler() (*AlbionHandler, *[]string) {
+	handler := NewAlbionHandler()
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			messages = append(messages, message)
+		}
+	})
+
+	handler.OnResponse(operationJoin, 0, "", map[byte]interface{}{
+		0: int64(100),
+		2: "LocalPlayer",
+	})
+
+	return handler, &messages
+}
+
+// sendTransformation sends a Transformation or TransformationEnd event for objectID
+func sendTransformation(handler *AlbionHandler, code events.EventCode, objectID int64, formID int32) {
+	handler.OnEvent(0, map[byte]interface{}{
+		0:                     objectID,
+		1:                     formID,
+		events.ParamEventCode: int16(code),
+	})
+}
+
+// TestTransformationLocalPlayer tests the transform/untransform transitions of the local player
+func TestTransformationLocalPlayer(t *testing.T) {
+	handler, messages := newTransformationTestHandler()
+
+	if handler.TransformationState().Active {
+		t.Fatal("expected no transformation initially")
+	}
+
+	sendTransformation(handler, events.EventTransformation, 100, 42)
+
+	state := handler.TransformationState()
+	if !state.Active {
+		t.Fatal("expected local player to be transformed")
+	}
+	if state.FormID != 42 {
+		t.Errorf("expected form ID 42, got %d", state.FormID)
+	}
+	if state.Since.IsZero() {
+		t.Error("expected transformation start time to be set")
+	}
+
+	sendTransformation(handler, events.EventTransformationEnd, 100, 0)
+
+	if state := handler.TransformationState(); state.Active || state.FormID != 0 {
+		t.Errorf("expected transformation to be cleared, got %+v", state)
+	}
+	if len(*messages) != 2 {
+		t.Fatalf("expected 2 info events, got %d: %v", len(*messages), *messages)
+	}
+}
+
+// TestTransformationOtherPlayer tests that other players' transformations are ignored
+func TestTransformationOtherPlayer(t *testing.T) {
+	handler, messages := newTransformationTestHandler()
+
+	sendTransformation(handler, events.EventTransformation, 200, 42)
+	if handler.TransformationState().Active {
+		t.Error("expected other player's transformation to be ignored")
+	}
+
+	sendTransformation(handler, events.EventTransformation, 100, 42)
+	sendTransformation(handler, events.EventTransformationEnd, 200, 0)
+	if !handler.TransformationState().Active {
+		t.Error("expected local transformation to survive another player's TransformationEnd")
+	}
+
+	if len(*messages) != 1 {
+		t.Errorf("expected 1 info event, got %d: %v", len(*messages), *messages)
+	}
+}
+
+// TestTransformationEndWithoutStart tests that a stray TransformationEnd emits nothing
+func TestTransformationEndWithoutStart(t *testing.T) {
+	handler, messages := newTransformationTestHandler()
+
+	sendTransformation(handler, events.EventTransformationEnd, 100, 0)
+
+	if len(*messages) != 0 {
+		t.Errorf("expected no info events, got %v", *messages)
+	}
+}
+
+// TestConcurrentTransformationAccess tests reading the transformation state while transformations are handled
+func TestConcurrentTransformationAccess(t *testing.T) {
+	handler, _ := newTransformationTestHandler()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			sendTransformation(handler, events.EventTransformation, 100, int32(i))
+			sendTransformation(handler, events.EventTransformationEnd, 100, 0)
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		_ = handler.TransformationState()
+	}
+	wg.Wait()
+
+	if state := handler.TransformationState(); state.Active {
+		t.Errorf("expected no transformation after the last one ended, got %+v", state)
+	}
+}
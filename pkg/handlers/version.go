@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// versionPattern matches client version strings such as "1.25.310" or "1.25.310.1"
+var versionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(\.\d+)?$`)
+
+// defaultVersionParam is the operation request parameter holding the client version
+const defaultVersionParam byte = 3
+
+// SetVersionParam sets the operation request parameter key holding the client
+// version, in case it moves after a game update
+func (h *AlbionHandler) SetVersionParam(key byte) {
+	h.versionParam = key
+}
+
+// DetectedGameVersion returns the client version seen in traffic, empty if not detected yet
+func (h *AlbionHandler) DetectedGameVersion() string {
+	h.versionMu.Lock()
+	defer h.versionMu.Unlock()
+	return h.detectedVersion
+}
+
+// detectGameVersion reads the client version from the version parameter of an
+// operation request (the client reports it when connecting) and emits a warning
+// once if it differs significantly from the version the event codes were derived from
+func (h *AlbionHandler) detectGameVersion(params map[byte]interface{}) {
+	version, ok := getStringChecked(params, h.versionParam)
+	if !ok || !versionPattern.MatchString(version) {
+		return
+	}
+
+	h.versionMu.Lock()
+	if h.detectedVersion != "" {
+		h.versionMu.Unlock()
+		return
+	}
+	h.detectedVersion = version
+	h.versionMu.Unlock()
+
+	// Builds without events.GameVersion have nothing to compare the client with
+	if events.GameVersion == "" {
+		return
+	}
+	if warning := gameVersionWarning(events.GameVersion, version); warning != "" {
+		h.notifyEvent("info", warning, nil)
+	}
+}
+
+// gameVersionWarning compares the version the event codes were derived from with the
+// detected client version and returns a warning if they differ in major or minor version.
+// Patch differences rarely renumber events, and unknown versions are not compared.
+func gameVersionWarning(codesVersion, detected string) string {
+	codes, ok := parseGameVersion(codesVersion)
+	if !ok {
+		return ""
+	}
+	client, ok := parseGameVersion(detected)
+	if !ok {
+		return ""
+	}
+
+	if codes[0] == client[0] && codes[1] == client[1] {
+		return ""
+	}
+	return fmt.Sprintf("⚠️ Event codes may be outdated for this game version (client %s, event codes from %s)", detected, codesVersion)
+}
+
+// parseGameVersion parses the major, minor and patch numbers of a version string
+func parseGameVersion(version string) ([3]int, bool) {
+	var parsed [3]int
+	if !versionPattern.MatchString(version) {
+		return parsed, false
+	}
+
+	parts := strings.Split(version, ".")
+	for i := range parsed {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// TestGameVersionWarning tests the comparison between the event codes version and the client version
+func TestGameVersionWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    string
+		detected string
+		warn     bool
+	}{
+		{"same version", "1.25.310", "1.25.310", false},
+		{"patch difference", "1.25.310", "1.25.312", false},
+		{"build suffix", "1.25.310", "1.25.310.4", false},
+		{"minor difference", "1.25.310", "1.26.001", true},
+		{"major difference", "1.25.310", "2.0.0", true},
+		{"unknown codes version", "", "1.26.001", false},
+		{"invalid client version", "1.25.310", "abc", false},
+	}
+
+	for _, tt := range tests {
+		warning := gameVersionWarning(tt.codes, tt.detected)
+		if (warning != "") != tt.warn {
+			t.Errorf("%s: expected warning=%v, got %q", tt.name, tt.warn, warning)
+		}
+		if tt.warn && !strings.Contains(warning, tt.detected) {
+			t.Errorf("%s: expected warning to mention %s, got %q", tt.name, tt.detected, warning)
+		}
+	}
+}
+
+// TestDetectGameVersion tests version detection from request parameters and the one-time warning
+func TestDetectGameVersion(t *testing.T) {
+	saved := events.GameVersion
+	events.GameVersion = "1.25.310"
+	defer func() { events.GameVersion = saved }()
+
+	handler := NewAlbionHandler()
+	var warnings []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			warnings = append(warnings, message)
+		}
+	})
+
+	handler.OnRequest(1, map[byte]interface{}{0: "PlayerName", 1: int32(5)})
+	handler.OnRequest(1, map[byte]interface{}{0: "1.24.100", 3: "PlayerName"}) // Version-like string in another parameter
+	if v := handler.DetectedGameVersion(); v != "" {
+		t.Errorf("expected no version yet, got %q", v)
+	}
+
+	handler.OnRequest(1, map[byte]interface{}{0: "PlayerName", 3: "1.26.001"})
+	handler.OnRequest(1, map[byte]interface{}{3: "1.27.000"})
+
+	if v := handler.DetectedGameVersion(); v != "1.26.001" {
+		t.Errorf("expected detected version 1.26.001, got %q", v)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected 1 warning, got %v", warnings)
+	}
+}
+
+// TestDetectGameVersionUnknownCodes tests that the version is detected without a
+// warning when the event codes version is unknown, and the version parameter setting
+func TestDetectGameVersionUnknownCodes(t *testing.T) {
+	saved := events.GameVersion
+	events.GameVersion = ""
+	defer func() { events.GameVersion = saved }()
+
+	handler := NewAlbionHandler()
+	handler.SetVersionParam(5)
+	var warnings []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			warnings = append(warnings, message)
+		}
+	})
+
+	handler.OnRequest(1, map[byte]interface{}{3: "1.26.001"})
+	handler.OnRequest(1, map[byte]interface{}{5: "1.27.000"})
+
+	if v := handler.DetectedGameVersion(); v != "1.27.000" {
+		t.Errorf("expected detected version 1.27.000, got %q", v)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warning without an event codes version, got %v", warnings)
+	}
+}
+
+// TestDetectGameVersionDefaultCodes tests that the default build warns about a client
+// of a newer minor version
+func TestDetectGameVersionDefaultCodes(t *testing.T) {
+	codes, ok := parseGameVersion(events.GameVersion)
+	if !ok {
+		t.Fatalf("expected a valid default event codes version, got %q", events.GameVersion)
+	}
+
+	handler := NewAlbionHandler()
+	var warnings []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			warnings = append(warnings, message)
+		}
+	})
+
+	client := fmt.Sprintf("%d.%d.0", codes[0], codes[1]+1)
+	handler.OnRequest(1, map[byte]interface{}{defaultVersionParam: client})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], client) {
+		t.Errorf("expected a warning about client %s, got %v", client, warnings)
+	}
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Content types, derived from the zone (cluster) ID
+const (
+	ContentUnknown   = "unknown"
+	ContentOpenWorld = "open_world"
+	ContentDungeon   = "dungeon"
+	ContentAvalon    = "avalon"
+	ContentMists     = "mists"
+	ContentIsland    = "island"
+	ContentHideout   = "hideout"
+)
+
+// ZoneStats contains the session gains made in one zone.
+// A zone visited several times accumulates all visits.
+type ZoneStats struct {
+	Zone        string        // Zone (cluster) ID, empty before the first zone is known
+	ContentType string        // One of the Content* values
+	Fame        int64         // Fame gained in the zone
+	Silver      int64         // Silver gained in the zone
+	Loot        int           // Items looted in the zone
+	Kills       int           // Players killed in the zone
+	Deaths      int           // Deaths in the zone
+	Duration    time.Duration // Time spent in the zone
+}
+
+// zoneCheckpoint is a snapshot of the session totals taken when entering a zone
+type zoneCheckpoint struct {
+	zone      string
+	enteredAt time.Time
+	fame      int64
+	silver    int64
+	loot      int
+	kills     int
+	deaths    int
+}
+
+// GetCurrentZone returns the zone (cluster) ID of the local player, empty if unknown
+func (h *AlbionHandler) GetCurrentZone() string {
+	h.zonesMu.Lock()
+	defer h.zonesMu.Unlock()
+
+	return h.zoneCheckpoint.zone
+}
+
+// CurrentZone returns the readable name of the local player's zone: the raw
+// cluster ID if the world database isn't loaded, empty if unknown
+func (h *AlbionHandler) CurrentZone() string {
+	return h.zoneName(h.GetCurrentZone())
+}
+
+// zoneName returns the readable name of a zone (cluster) ID, or the ID itself
+// if the world database isn't loaded or doesn't know it
+func (h *AlbionHandler) zoneName(zone string) string {
+	if zone == "" || h.worldDB == nil || !h.worldDB.IsLoaded() {
+		return zone
+	}
+	return h.worldDB.GetZoneName(zone)
+}
+
+// handleClusterInfo handles zone information updates sent when entering a zone
+// Parameters: [0]=zone (cluster) ID
+func (h *AlbionHandler) handleClusterInfo(params map[byte]interface{}) {
+	if zone := getString(params, 0); zone != "" {
+		h.changeZone(zone)
+	}
+}
+
+// changeZone moves the local player to a zone, announcing it if it changed
+func (h *AlbionHandler) changeZone(zone string) {
+	if h.enterZone(zone) {
+		h.clearChests()
+		h.notifyEvent("info", fmt.Sprintf("🗺️ Entered %s", h.zoneName(zone)), nil)
+	}
+}
+
+// GetZoneStats returns the session gains per zone, in order of first visit.
+// The current zone includes the gains made since entering it, so the
+// per-zone values always sum to the session totals.
+func (h *AlbionHandler) GetZoneStats() []ZoneStats {
+	h.zonesMu.Lock()
+	defer h.zonesMu.Unlock()
+
+	stats := make([]ZoneStats, 0, len(h.zoneOrder)+1)
+	current := h.zoneDelta(time.Now())
+	merged := false
+	for _, zone := range h.zoneOrder {
+		zs := *h.zoneStats[zone]
+		if zone == current.Zone {
+			addZoneStats(&zs, current)
+			merged = true
+		}
+		stats = append(stats, zs)
+	}
+	if !merged && (current.Zone != "" || !current.empty()) {
+		stats = append(stats, current)
+	}
+	return stats
+}
+
+// enterZone closes the current zone checkpoint and starts a new one, returning
+// false if already in the zone
+func (h *AlbionHandler) enterZone(zone string) bool {
+	h.zonesMu.Lock()
+	defer h.zonesMu.Unlock()
+
+	if zone == h.zoneCheckpoint.zone {
+		return false
+	}
+
+	now := time.Now()
+	h.closeZone(now)
+	h.zoneCheckpoint = h.checkpoint(zone, now)
+	return true
+}
+
+// closeZone adds the gains since the last checkpoint to its zone
+func (h *AlbionHandler) closeZone(now time.Time) {
+	delta := h.zoneDelta(now)
+
+	// Gains before the first zone is known are only kept if there are any
+	if delta.Zone == "" && delta.empty() {
+		return
+	}
+
+	zs, ok := h.zoneStats[delta.Zone]
+	if !ok {
+		zs = &ZoneStats{Zone: delta.Zone, ContentType: delta.ContentType}
+		h.zoneStats[delta.Zone] = zs
+		h.zoneOrder = append(h.zoneOrder, delta.Zone)
+	}
+	addZoneStats(zs, delta)
+}
+
+// zoneDelta returns the gains since the last checkpoint
+func (h *AlbionHandler) zoneDelta(now time.Time) ZoneStats {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+
+	c := h.zoneCheckpoint
+	return ZoneStats{
+		Zone:        c.zone,
+		ContentType: contentType(c.zone),
+		Fame:        h.sessionFame - c.fame,
+		Silver:      h.sessionSilver - c.silver,
+		Loot:        h.sessionLoot - c.loot,
+		Kills:       h.sessionKills - c.kills,
+		Deaths:      h.sessionDeaths - c.deaths,
+		Duration:    now.Sub(c.enteredAt),
+	}
+}
+
+// checkpoint snapshots the session totals
+func (h *AlbionHandler) checkpoint(zone string, now time.Time) zoneCheckpoint {
+	h.sessionMu.RLock()
+	defer h.sessionMu.RUnlock()
+
+	return zoneCheckpoint{
+		zone:      zone,
+		enteredAt: now,
+		fame:      h.sessionFame,
+		silver:    h.sessionSilver,
+		loot:      h.sessionLoot,
+		kills:     h.sessionKills,
+		deaths:    h.sessionDeaths,
+	}
+}
+
+// empty reports whether no gains were made
+func (zs ZoneStats) empty() bool {
+	return zs.Fame == 0 && zs.Silver == 0 && zs.Loot == 0 && zs.Kills == 0 && zs.Deaths == 0
+}
+
+// addZoneStats adds the gains of delta to zs
+func addZoneStats(zs *ZoneStats, delta ZoneStats) {
+	zs.Fame += delta.Fame
+	zs.Silver += delta.Silver
+	zs.Loot += delta.Loot
+	zs.Kills += delta.Kills
+	zs.Deaths += delta.Deaths
+	zs.Duration += delta.Duration
+}
+
+// contentType derives the content type from a zone (cluster) ID.
+// Instanced content uses "@"-prefixed IDs (e.g. "@RANDOMDUNGEON@..."), while
+// open world zones use numeric IDs (e.g. "3005").
+func contentType(zone string) string {
+	id := strings.ToUpper(zone)
+	switch {
+	case id == "":
+		return ContentUnknown
+	case strings.Contains(id, "DUNGEON"), strings.Contains(id, "CORRUPTED"), strings.Contains(id, "HELLGATE"):
+		return ContentDungeon
+	case strings.Contains(id, "AVALON"), strings.Contains(id, "ROADS"):
+		return ContentAvalon
+	case strings.Contains(id, "MISTS"):
+		return ContentMists
+	case strings.Contains(id, "ISLAND"):
+		return ContentIsland
+	case strings.Contains(id, "HIDEOUT"):
+		return ContentHideout
+	case strings.HasPrefix(id, "@"):
+		return ContentUnknown
+	}
+	return ContentOpenWorld
+}
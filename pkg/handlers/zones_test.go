@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cantalupo555/albion-lens/pkg/events"
+)
+
+// joinZone simulates the Join response when entering a zone
+func joinZone(h *AlbionHandler, zone string) {
+	h.OnResponse(operationJoin, 0, "", map[byte]interface{}{
+		0: int64(100),
+		2: "Me",
+		8: zone,
+	})
+}
+
+// gainFame simulates a detailed fame event (amount in whole fame)
+func gainFame(h *AlbionHandler, total, gained int64) {
+	h.OnEvent(0, map[byte]interface{}{
+		0:                     int64(100),
+		1:                     total * 10000,
+		2:                     gained * 10000,
+		events.ParamEventCode: int16(events.EventUpdateFame),
+	})
+}
+
+// TestZoneStatsBreakdown tests that gains are attributed to the zone they were made in
+func TestZoneStatsBreakdown(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	joinZone(handler, "3005")
+	gainFame(handler, 1000, 1000)
+	handler.OnEvent(0, map[byte]interface{}{events.ParamEventCode: int16(events.EventKilledPlayer)})
+
+	joinZone(handler, "@RANDOMDUNGEON@abc")
+	gainFame(handler, 3500, 2500)
+
+	if zone := handler.GetCurrentZone(); zone != "@RANDOMDUNGEON@abc" {
+		t.Errorf("expected current zone @RANDOMDUNGEON@abc, got %q", zone)
+	}
+
+	stats := handler.GetZoneStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 zones, got %d: %+v", len(stats), stats)
+	}
+
+	if stats[0].Zone != "3005" || stats[0].ContentType != ContentOpenWorld || stats[0].Fame != 1000 || stats[0].Kills != 1 {
+		t.Errorf("unexpected open world stats: %+v", stats[0])
+	}
+	if stats[1].ContentType != ContentDungeon || stats[1].Fame != 2500 || stats[1].Kills != 0 {
+		t.Errorf("unexpected dungeon stats: %+v", stats[1])
+	}
+
+	var fame int64
+	var kills int
+	for _, zs := range stats {
+		fame += zs.Fame
+		kills += zs.Kills
+	}
+	if fame != handler.GetSessionFame() || kills != handler.GetSessionKills() {
+		t.Errorf("expected zones to sum to session totals (fame %d, kills %d), got fame %d, kills %d",
+			handler.GetSessionFame(), handler.GetSessionKills(), fame, kills)
+	}
+}
+
+// TestZoneStatsRevisit tests that returning to a zone accumulates into the same entry
+func TestZoneStatsRevisit(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	joinZone(handler, "3005")
+	gainFame(handler, 100, 100)
+	joinZone(handler, "3006")
+	gainFame(handler, 150, 50)
+	joinZone(handler, "3005")
+	gainFame(handler, 175, 25)
+
+	stats := handler.GetZoneStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 zones, got %d: %+v", len(stats), stats)
+	}
+	if stats[0].Zone != "3005" || stats[0].Fame != 125 {
+		t.Errorf("expected 125 fame in 3005, got %+v", stats[0])
+	}
+	if stats[1].Zone != "3006" || stats[1].Fame != 50 {
+		t.Errorf("expected 50 fame in 3006, got %+v", stats[1])
+	}
+}
+
+// TestZoneStatsBeforeFirstZone tests that gains before any zone is known are kept
+func TestZoneStatsBeforeFirstZone(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	if stats := handler.GetZoneStats(); len(stats) != 0 {
+		t.Errorf("expected no zones for an empty session, got %+v", stats)
+	}
+
+	gainFame(handler, 300, 300)
+	joinZone(handler, "3005")
+
+	stats := handler.GetZoneStats()
+	if len(stats) != 2 || stats[0].Zone != "" || stats[0].ContentType != ContentUnknown || stats[0].Fame != 300 {
+		t.Errorf("expected unknown zone with 300 fame first, got %+v", stats)
+	}
+}
+
+// TestClusterInfoUpdate tests zone changes from cluster info updates, announced once per zone
+func TestClusterInfoUpdate(t *testing.T) {
+	handler := NewAlbionHandler()
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		if eventType == "info" {
+			messages = append(messages, message)
+		}
+	})
+
+	clusterInfo := func(zone string) {
+		handler.OnEvent(0, map[byte]interface{}{
+			0:                     zone,
+			events.ParamEventCode: int16(events.EventClusterInfoUpdate),
+		})
+	}
+
+	if handler.CurrentZone() != "" {
+		t.Errorf("expected no current zone, got %q", handler.CurrentZone())
+	}
+
+	clusterInfo("3005")
+	clusterInfo("3005")
+	if handler.GetCurrentZone() != "3005" {
+		t.Errorf("expected current zone 3005, got %q", handler.GetCurrentZone())
+	}
+	// Without the world database, the raw cluster ID is shown
+	if handler.CurrentZone() != "3005" {
+		t.Errorf("expected zone name 3005, got %q", handler.CurrentZone())
+	}
+	if len(messages) != 1 || messages[0] != "🗺️ Entered 3005" {
+		t.Errorf("expected a single zone change message, got %v", messages)
+	}
+
+	clusterInfo("")
+	if handler.GetCurrentZone() != "3005" {
+		t.Errorf("expected an empty cluster ID to be ignored, got %q", handler.GetCurrentZone())
+	}
+}
+
+// TestCurrentZoneName tests zone names resolved with the world database
+func TestCurrentZoneName(t *testing.T) {
+	dir := t.TempDir()
+	world := `{"world": {"clusters": {"cluster": [{"@id": "0000", "@displayname": "Thetford"}]}}}`
+	if err := os.WriteFile(filepath.Join(dir, "world.json"), []byte(world), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	handler := NewAlbionHandler()
+	if err := handler.LoadWorldDatabase(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var messages []string
+	handler.SetEventCallback(func(eventType, message string, data interface{}) {
+		messages = append(messages, message)
+	})
+
+	joinZone(handler, "0000")
+	if handler.CurrentZone() != "Thetford" {
+		t.Errorf("expected Thetford, got %q", handler.CurrentZone())
+	}
+	if len(messages) != 1 || messages[0] != "🗺️ Entered Thetford" {
+		t.Errorf("expected zone change message, got %v", messages)
+	}
+
+	joinZone(handler, "9999")
+	if handler.CurrentZone() != "9999" {
+		t.Errorf("expected unknown zone to show its ID, got %q", handler.CurrentZone())
+	}
+}
+
+// TestContentType tests deriving the content type from zone IDs
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		zone     string
+		expected string
+	}{
+		{"", ContentUnknown},
+		{"3005", ContentOpenWorld},
+		{"@RANDOMDUNGEON@f7a2", ContentDungeon},
+		{"@CORRUPTEDDUNGEON@1", ContentDungeon},
+		{"@AVALON@ROADS_1", ContentAvalon},
+		{"@MISTS@42", ContentMists},
+		{"@ISLAND@player", ContentIsland},
+		{"@HIDEOUT@3", ContentHideout},
+		{"@SOMETHINGNEW@1", ContentUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := contentType(tt.zone); got != tt.expected {
+			t.Errorf("contentType(%q): expected %q, got %q", tt.zone, tt.expected, got)
+		}
+	}
+}
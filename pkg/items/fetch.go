@@ -0,0 +1,151 @@
+package items
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultDumpsURL is the raw file URL of the community ao-bin-dumps repository
+const DefaultDumpsURL = "https://raw.githubusercontent.com/ao-data/ao-bin-dumps/master"
+
+// maxFetchSize bounds a downloaded file (items.json is a few tens of MB)
+const maxFetchSize = 512 << 20
+
+// dumpFile is a file fetched from ao-bin-dumps
+type dumpFile struct {
+	path     string // Path relative to the repository root (and the destination directory)
+	required bool   // Whether the fetch fails without it
+}
+
+// dumpFiles are the files fetched by FetchLatest
+var dumpFiles = []dumpFile{
+	{path: "items.json", required: true},
+	{path: "formatted/items.json"}, // Localized item names
+}
+
+// FetchedFile describes a file written by FetchLatest
+type FetchedFile struct {
+	Path   string // Path of the written file
+	Size   int64  // Size in bytes
+	SHA256 string // Hex-encoded SHA-256 of the content
+}
+
+// Fetcher downloads ao-bin-dumps files
+type Fetcher struct {
+	BaseURL string       // Repository raw URL (DefaultDumpsURL if empty)
+	Client  *http.Client // HTTP client (a client with a timeout if nil)
+}
+
+// FetchLatest downloads the latest items.json (and localized names) from the
+// community ao-bin-dumps repository into destDir. See Fetcher.FetchLatest.
+func FetchLatest(destDir string) ([]FetchedFile, error) {
+	return (&Fetcher{}).FetchLatest(destDir)
+}
+
+// FetchLatest downloads the ao-bin-dumps files into destDir.
+// Each file is verified (complete download, and items.json must load) before it
+// replaces the existing one, so on any failure the current files are kept.
+// Optional files that fail to download are skipped.
+func (f *Fetcher) FetchLatest(destDir string) ([]FetchedFile, error) {
+	var fetched []FetchedFile
+	for _, file := range dumpFiles {
+		result, err := f.fetch(file.path, destDir)
+		if err != nil {
+			if file.required {
+				return fetched, err
+			}
+			continue
+		}
+		fetched = append(fetched, result)
+	}
+	return fetched, nil
+}
+
+// fetch downloads, verifies and installs a single file
+func (f *Fetcher) fetch(path, destDir string) (FetchedFile, error) {
+	baseURL := f.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultDumpsURL
+	}
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	resp, err := client.Get(baseURL + "/" + path)
+	if err != nil {
+		return FetchedFile{}, fmt.Errorf("failed to download %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return FetchedFile{}, fmt.Errorf("failed to download %s: %s", path, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchSize+1))
+	if err != nil {
+		return FetchedFile{}, fmt.Errorf("failed to download %s: %w", path, err)
+	}
+	if err := verifyDownload(path, data, resp.ContentLength); err != nil {
+		return FetchedFile{}, err
+	}
+
+	dest := filepath.Join(destDir, filepath.FromSlash(path))
+	if err := writeFileAtomic(dest, data); err != nil {
+		return FetchedFile{}, fmt.Errorf("failed to save %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return FetchedFile{Path: dest, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+// verifyDownload checks that a download is complete and, for items.json, loadable
+func verifyDownload(path string, data []byte, contentLength int64) error {
+	switch {
+	case len(data) == 0:
+		return fmt.Errorf("downloaded %s is empty", path)
+	case len(data) > maxFetchSize:
+		return fmt.Errorf("downloaded %s exceeds %d bytes", path, maxFetchSize)
+	case contentLength >= 0 && int64(len(data)) != contentLength:
+		return fmt.Errorf("downloaded %s is incomplete: got %d of %d bytes", path, len(data), contentLength)
+	}
+
+	if path == "items.json" {
+		check := &ItemDatabase{
+			items:     make(map[string]ItemInfo),
+			itemsByID: make(map[int]ItemInfo),
+		}
+		if err := check.parseItemsJSON(data); err != nil {
+			return fmt.Errorf("downloaded %s is invalid: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to dest and renames it into place
+func writeFileAtomic(dest string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
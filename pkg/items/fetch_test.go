@@ -0,0 +1,105 @@
+package items
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureItemsJSON is a minimal items.json served by the test server
+const fixtureItemsJSON = `{"items": {"simpleitem": [{"@uniquename": "T4_BAG"}, {"@uniquename": "T5_CAPE"}]}}`
+
+// newDumpsServer serves files from an in-memory ao-bin-dumps repository
+func newDumpsServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestFetchLatest tests downloading items.json and loading the result
+func TestFetchLatest(t *testing.T) {
+	server := newDumpsServer(t, map[string]string{
+		"/items.json":           fixtureItemsJSON,
+		"/formatted/items.json": `[{"UniqueName": "T4_BAG"}]`,
+	})
+	destDir := t.TempDir()
+
+	fetched, err := (&Fetcher{BaseURL: server.URL}).FetchLatest(destDir)
+	if err != nil {
+		t.Fatalf("FetchLatest failed: %v", err)
+	}
+	if len(fetched) != 2 {
+		t.Fatalf("expected 2 fetched files, got %d", len(fetched))
+	}
+	if fetched[0].Size != int64(len(fixtureItemsJSON)) || len(fetched[0].SHA256) != 64 {
+		t.Errorf("unexpected fetched file: %+v", fetched[0])
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "formatted", "items.json")); err != nil {
+		t.Errorf("expected localized names to be saved: %v", err)
+	}
+
+	resetDatabase()
+	db := GetDatabase()
+	if err := db.LoadFromPath(destDir); err != nil {
+		t.Fatalf("failed to load fetched items: %v", err)
+	}
+	if db.ItemCount() != 2 {
+		t.Errorf("expected 2 items, got %d", db.ItemCount())
+	}
+}
+
+// TestFetchLatestOptionalMissing tests that a missing optional file does not fail the fetch
+func TestFetchLatestOptionalMissing(t *testing.T) {
+	server := newDumpsServer(t, map[string]string{"/items.json": fixtureItemsJSON})
+
+	fetched, err := (&Fetcher{BaseURL: server.URL}).FetchLatest(t.TempDir())
+	if err != nil {
+		t.Fatalf("FetchLatest failed: %v", err)
+	}
+	if len(fetched) != 1 {
+		t.Errorf("expected 1 fetched file, got %d", len(fetched))
+	}
+}
+
+// TestFetchLatestKeepsExistingOnFailure tests that failed or invalid downloads keep the current file
+func TestFetchLatestKeepsExistingOnFailure(t *testing.T) {
+	destDir := t.TempDir()
+	existing := filepath.Join(destDir, "items.json")
+	if err := os.WriteFile(existing, []byte(fixtureItemsJSON), 0644); err != nil {
+		t.Fatalf("failed to create existing file: %v", err)
+	}
+
+	servers := map[string]*httptest.Server{
+		"not found": newDumpsServer(t, map[string]string{}),
+		"invalid":   newDumpsServer(t, map[string]string{"/items.json": `{"not_items": {}}`}),
+		"empty":     newDumpsServer(t, map[string]string{"/items.json": ""}),
+	}
+
+	for name, server := range servers {
+		if _, err := (&Fetcher{BaseURL: server.URL}).FetchLatest(destDir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+
+		data, err := os.ReadFile(existing)
+		if err != nil || string(data) != fixtureItemsJSON {
+			t.Errorf("%s: expected existing items.json to be kept, got %q (%v)", name, data, err)
+		}
+	}
+
+	// Unreachable server
+	server := newDumpsServer(t, nil)
+	server.Close()
+	if _, err := (&Fetcher{BaseURL: server.URL}).FetchLatest(destDir); err == nil {
+		t.Error("unreachable: expected an error")
+	}
+}
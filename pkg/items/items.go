@@ -29,6 +29,25 @@ type ItemInfo struct {
 	SubCategory string // Shop subcategory
 }
 
+// itemCategories are the items.json categories loaded, in index order
+var itemCategories = []string{
+	"hideoutitem",
+	"farmableitem",
+	"simpleitem",
+	"consumableitem",
+	"consumablefrominventoryitem",
+	"equipmentitem",
+	"weapon",
+	"mount",
+	"furnitureitem",
+	"mountskin",
+	"journalitem",
+	"labourercontract",
+	"crystalleagueitem",
+	"killtrophy",
+	"trackingitem",
+}
+
 // Global database instance
 var db *ItemDatabase
 var once sync.Once
@@ -90,25 +109,7 @@ func (d *ItemDatabase) parseItemsJSON(data []byte) error {
 
 	// Process different item categories
 	itemIndex := 0
-	categoryProcessors := []string{
-		"hideoutitem",
-		"farmableitem",
-		"simpleitem",
-		"consumableitem",
-		"consumablefrominventoryitem",
-		"equipmentitem",
-		"weapon",
-		"mount",
-		"furnitureitem",
-		"mountskin",
-		"journalitem",
-		"labourercontract",
-		"crystalleagueitem",
-		"killtrophy",
-		"trackingitem",
-	}
-
-	for _, category := range categoryProcessors {
+	for _, category := range itemCategories {
 		if categoryData, exists := items[category]; exists {
 			itemIndex = d.processCategory(categoryData, category, itemIndex)
 		}
@@ -0,0 +1,135 @@
+package items
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// CategoryCount is the number of items loaded from one items.json category
+type CategoryCount struct {
+	Category string
+	Count    int
+}
+
+// ValidationReport describes the content of an items.json file
+type ValidationReport struct {
+	Path              string
+	TotalItems        int             // Items that would be loaded
+	Categories        []CategoryCount // Per-category counts, in load order
+	MissingCategories []string        // Known categories absent from the file
+	EmptyCategories   []string        // Categories present but without any item
+	Duplicates        []string        // Unique names that appear more than once
+	InvalidEntries    int             // Entries skipped (not an object, or no @uniquename)
+}
+
+// OK reports whether the file looks complete: items were found and nothing suspicious was flagged
+func (r ValidationReport) OK() bool {
+	return len(r.Problems()) == 0
+}
+
+// Problems lists the suspicious structures found in the file
+func (r ValidationReport) Problems() []string {
+	var problems []string
+	if r.TotalItems == 0 {
+		problems = append(problems, "no items found")
+	}
+	if len(r.EmptyCategories) > 0 {
+		problems = append(problems, fmt.Sprintf("empty categories: %s", strings.Join(r.EmptyCategories, ", ")))
+	}
+	if len(r.Duplicates) > 0 {
+		problems = append(problems, fmt.Sprintf("%d duplicate unique names (e.g., %s)", len(r.Duplicates), r.Duplicates[0]))
+	}
+	if r.InvalidEntries > 0 {
+		problems = append(problems, fmt.Sprintf("%d entries without a unique name", r.InvalidEntries))
+	}
+	return problems
+}
+
+// String formats the report for display
+func (r ValidationReport) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Items file: %s\n", r.Path)
+	fmt.Fprintf(&b, "Total items: %d\n", r.TotalItems)
+	for _, c := range r.Categories {
+		fmt.Fprintf(&b, "  %-28s %6d\n", c.Category, c.Count)
+	}
+	if len(r.MissingCategories) > 0 {
+		fmt.Fprintf(&b, "Missing categories: %s\n", strings.Join(r.MissingCategories, ", "))
+	}
+
+	if problems := r.Problems(); len(problems) > 0 {
+		b.WriteString("Problems:\n")
+		for _, p := range problems {
+			fmt.Fprintf(&b, "  - %s\n", p)
+		}
+	} else {
+		b.WriteString("OK\n")
+	}
+	return b.String()
+}
+
+// Validate loads an items.json file without touching the global database and reports
+// its coverage. An error is returned if the file cannot be read or parsed.
+func Validate(path string) (ValidationReport, error) {
+	report := ValidationReport{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, fmt.Errorf("failed to read items file: %w", err)
+	}
+
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return report, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	items, ok := root["items"].(map[string]interface{})
+	if !ok {
+		return report, fmt.Errorf("invalid items.json structure: missing 'items' key")
+	}
+
+	seen := make(map[string]int)
+	for _, category := range itemCategories {
+		categoryData, exists := items[category]
+		if !exists {
+			report.MissingCategories = append(report.MissingCategories, category)
+			continue
+		}
+
+		// A category holds a list of items, or a single item object
+		entries, isList := categoryData.([]interface{})
+		if !isList {
+			entries = []interface{}{categoryData}
+		}
+
+		count := 0
+		for _, entry := range entries {
+			itemMap, ok := entry.(map[string]interface{})
+			name, _ := itemMap["@uniquename"].(string)
+			if !ok || name == "" {
+				report.InvalidEntries++
+				continue
+			}
+			seen[name]++
+			count++
+		}
+
+		report.Categories = append(report.Categories, CategoryCount{Category: category, Count: count})
+		report.TotalItems += count
+		if count == 0 {
+			report.EmptyCategories = append(report.EmptyCategories, category)
+		}
+	}
+
+	for name, n := range seen {
+		if n > 1 {
+			report.Duplicates = append(report.Duplicates, name)
+		}
+	}
+	slices.Sort(report.Duplicates)
+
+	return report, nil
+}
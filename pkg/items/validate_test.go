@@ -0,0 +1,110 @@
+package items
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeItemsFixture writes an items.json fixture and returns its path
+func writeItemsFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "items.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	return path
+}
+
+// TestValidateValid tests the report of a well-formed file
+func TestValidateValid(t *testing.T) {
+	path := writeItemsFixture(t, `{
+		"items": {
+			"simpleitem": [{"@uniquename": "T4_BAG"}, {"@uniquename": "T5_CAPE"}],
+			"equipmentitem": [{"@uniquename": "T6_ARMOR"}],
+			"mount": {"@uniquename": "T3_MOUNT_HORSE"}
+		}
+	}`)
+
+	report, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if report.TotalItems != 4 {
+		t.Errorf("expected 4 items, got %d", report.TotalItems)
+	}
+	expected := []CategoryCount{{"simpleitem", 2}, {"equipmentitem", 1}, {"mount", 1}}
+	if len(report.Categories) != len(expected) {
+		t.Fatalf("expected categories %v, got %v", expected, report.Categories)
+	}
+	for i, c := range expected {
+		if report.Categories[i] != c {
+			t.Errorf("expected category %v, got %v", c, report.Categories[i])
+		}
+	}
+	if len(report.MissingCategories) != len(itemCategories)-3 {
+		t.Errorf("expected %d missing categories, got %v", len(itemCategories)-3, report.MissingCategories)
+	}
+	if !report.OK() {
+		t.Errorf("expected valid report, got problems %v", report.Problems())
+	}
+	if !strings.Contains(report.String(), "Total items: 4") {
+		t.Errorf("expected total in report, got:\n%s", report)
+	}
+
+	// The global database is untouched
+	resetDatabase()
+	if GetDatabase().IsLoaded() {
+		t.Error("expected Validate not to load the global database")
+	}
+}
+
+// TestValidateSuspicious tests that suspicious structures are flagged
+func TestValidateSuspicious(t *testing.T) {
+	path := writeItemsFixture(t, `{
+		"items": {
+			"simpleitem": [{"@uniquename": "T4_BAG"}, {"@uniquename": "T4_BAG"}, {"@shopcategory": "x"}, "junk"],
+			"weapon": []
+		}
+	}`)
+
+	report, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if report.TotalItems != 2 {
+		t.Errorf("expected 2 items, got %d", report.TotalItems)
+	}
+	if len(report.Duplicates) != 1 || report.Duplicates[0] != "T4_BAG" {
+		t.Errorf("expected duplicate T4_BAG, got %v", report.Duplicates)
+	}
+	if len(report.EmptyCategories) != 1 || report.EmptyCategories[0] != "weapon" {
+		t.Errorf("expected empty category weapon, got %v", report.EmptyCategories)
+	}
+	if report.InvalidEntries != 2 {
+		t.Errorf("expected 2 invalid entries, got %d", report.InvalidEntries)
+	}
+	if report.OK() || len(report.Problems()) != 3 {
+		t.Errorf("expected 3 problems, got %v", report.Problems())
+	}
+}
+
+// TestValidateMalformed tests that unreadable and malformed files return an error
+func TestValidateMalformed(t *testing.T) {
+	files := map[string]string{
+		"truncated":   `{"items": {"simpleitem": [{"@uniquename": "T4_BAG"}`,
+		"missing key": `{"other": {}}`,
+	}
+	for name, content := range files {
+		if _, err := Validate(writeItemsFixture(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := Validate(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing file: expected an error")
+	}
+}
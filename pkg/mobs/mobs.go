@@ -0,0 +1,174 @@
+// Package mobs provides mob (creature) type ID to name translation using ao-bin-dumps data
+package mobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MobDatabase holds the loaded mobs data
+type MobDatabase struct {
+	mobs     map[string]MobInfo // key: uniquename (e.g., "T6_MOB_HERETIC_ARCHER")
+	mobsByID map[int]MobInfo    // key: numeric index
+	mu       sync.RWMutex
+	loaded   bool
+}
+
+// MobInfo contains mob information
+type MobInfo struct {
+	UniqueName string // Mob unique name (e.g., "T6_MOB_HERETIC_ARCHER")
+	Index      int    // Numeric index based on position, the type ID sent by the server
+	Tier       int    // Tier (1-8), 0 if none
+}
+
+// Global database instance
+var db *MobDatabase
+var once sync.Once
+
+// GetDatabase returns the global mob database
+func GetDatabase() *MobDatabase {
+	once.Do(func() {
+		db = &MobDatabase{
+			mobs:     make(map[string]MobInfo),
+			mobsByID: make(map[int]MobInfo),
+		}
+	})
+	return db
+}
+
+// LoadFromFile loads mobs from a mobs.json file
+func (d *MobDatabase) LoadFromFile(filePath string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read mobs file: %w", err)
+	}
+
+	return d.parseMobsJSON(data)
+}
+
+// LoadFromPath tries to find and load mobs.json from common paths
+func (d *MobDatabase) LoadFromPath(basePath string) error {
+	paths := []string{
+		filepath.Join(basePath, "mobs.json"),
+		filepath.Join(basePath, "ao-bin-dumps", "mobs.json"),
+		filepath.Join(basePath, "..", "ao-bin-dumps", "mobs.json"),
+		"mobs.json",
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return d.LoadFromFile(path)
+		}
+	}
+
+	return fmt.Errorf("mobs.json not found in any of the expected locations")
+}
+
+// parseMobsJSON parses the mobs.json structure: Mobs.Mob is the list of mobs (an
+// object instead of an array when there is only one), indexed by position
+func (d *MobDatabase) parseMobsJSON(data []byte) error {
+	var root struct {
+		Mobs struct {
+			Mob json.RawMessage `json:"Mob"`
+		} `json:"Mobs"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if len(root.Mobs.Mob) == 0 {
+		return fmt.Errorf("invalid mobs.json structure: missing 'Mobs.Mob' key")
+	}
+
+	type rawMob struct {
+		UniqueName string `json:"@uniquename"`
+		Tier       string `json:"@tier"`
+	}
+	var mobs []rawMob
+	if err := json.Unmarshal(root.Mobs.Mob, &mobs); err != nil {
+		var single rawMob
+		if err := json.Unmarshal(root.Mobs.Mob, &single); err != nil {
+			return fmt.Errorf("failed to parse mobs: %w", err)
+		}
+		mobs = []rawMob{single}
+	}
+
+	// Entries without a unique name keep their index, so later IDs stay aligned
+	for index, m := range mobs {
+		if m.UniqueName == "" {
+			continue
+		}
+		tier, _ := strconv.Atoi(m.Tier)
+		info := MobInfo{UniqueName: m.UniqueName, Index: index, Tier: tier}
+		d.mobs[info.UniqueName] = info
+		d.mobsByID[index] = info
+	}
+
+	d.loaded = true
+	return nil
+}
+
+// GetByUniqueName returns mob info by unique name
+func (d *MobDatabase) GetByUniqueName(name string) (MobInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.mobs[name]
+	return info, ok
+}
+
+// GetByID returns mob info by numeric type ID
+func (d *MobDatabase) GetByID(id int) (MobInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.mobsByID[id]
+	return info, ok
+}
+
+// GetMobName returns a human-readable name for a mob type ID, or "Mob#<id>" if
+// it is unknown
+func (d *MobDatabase) GetMobName(id int) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if info, ok := d.mobsByID[id]; ok {
+		return formatMobName(info.UniqueName)
+	}
+	return fmt.Sprintf("Mob#%d", id)
+}
+
+// formatMobName converts internal name to readable format
+// T6_MOB_HERETIC_ARCHER -> "T6 Heretic Archer"
+// MOB_KEEPER_BEAR -> "Keeper Bear"
+func formatMobName(name string) string {
+	if name == "" {
+		return "Unknown"
+	}
+
+	var tier string
+	if prefix, rest, ok := strings.Cut(name, "_"); ok && len(prefix) == 2 && prefix[0] == 'T' && prefix[1] >= '0' && prefix[1] <= '9' {
+		tier, name = prefix+" ", rest
+	}
+	name = strings.TrimPrefix(name, "MOB_")
+	return tier + strings.Title(strings.ToLower(strings.ReplaceAll(name, "_", " ")))
+}
+
+// IsLoaded returns whether the database has been loaded
+func (d *MobDatabase) IsLoaded() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.loaded
+}
+
+// MobCount returns the number of loaded mobs
+func (d *MobDatabase) MobCount() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.mobs)
+}
@@ -0,0 +1,176 @@
+package mobs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// resetDatabase resets the global database for testing
+func resetDatabase() {
+	db = nil
+	once = sync.Once{}
+}
+
+// fixtureMobsJSON is a minimal mobs.json in the ao-bin-dumps layout
+const fixtureMobsJSON = `{
+	"Mobs": {
+		"Mob": [
+			{"@uniquename": "T1_MOB_RABBIT", "@tier": "1"},
+			{"@namelocatag": "MISSING_NAME"},
+			{"@uniquename": "T6_MOB_HERETIC_ARCHER", "@tier": "6"},
+			{"@uniquename": "MOB_KEEPER_BEAR"}
+		]
+	}
+}`
+
+// loadFixture loads fixtureMobsJSON into a fresh global database
+func loadFixture(t *testing.T) *MobDatabase {
+	t.Helper()
+	resetDatabase()
+
+	jsonPath := filepath.Join(t.TempDir(), "mobs.json")
+	if err := os.WriteFile(jsonPath, []byte(fixtureMobsJSON), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	db := GetDatabase()
+	if err := db.LoadFromFile(jsonPath); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	return db
+}
+
+// TestGetDatabase tests singleton database creation
+func TestGetDatabase(t *testing.T) {
+	resetDatabase()
+
+	db1 := GetDatabase()
+	if db1 == nil {
+		t.Fatal("GetDatabase returned nil")
+	}
+	if db1 != GetDatabase() {
+		t.Error("GetDatabase should return the same instance")
+	}
+	if db1.IsLoaded() || db1.MobCount() != 0 {
+		t.Error("database should start empty and not loaded")
+	}
+}
+
+// TestLoadFromFile tests loading mobs, keeping the index of entries without a name
+func TestLoadFromFile(t *testing.T) {
+	db := loadFixture(t)
+
+	if !db.IsLoaded() {
+		t.Error("database should be loaded")
+	}
+	if db.MobCount() != 3 {
+		t.Errorf("expected 3 mobs, got %d", db.MobCount())
+	}
+
+	info, ok := db.GetByID(2)
+	if !ok || info.UniqueName != "T6_MOB_HERETIC_ARCHER" || info.Tier != 6 {
+		t.Errorf("unexpected mob 2: %+v, %v", info, ok)
+	}
+	if _, ok := db.GetByID(1); ok {
+		t.Error("expected no mob with ID 1")
+	}
+	info, ok = db.GetByUniqueName("MOB_KEEPER_BEAR")
+	if !ok || info.Index != 3 || info.Tier != 0 {
+		t.Errorf("unexpected MOB_KEEPER_BEAR info: %+v, %v", info, ok)
+	}
+}
+
+// TestLoadSingleMob tests a mob list with a single entry, sent as an object
+func TestLoadSingleMob(t *testing.T) {
+	resetDatabase()
+	path := filepath.Join(t.TempDir(), "mobs.json")
+	if err := os.WriteFile(path, []byte(`{"Mobs": {"Mob": {"@uniquename": "T4_MOB_WOLF", "@tier": "4"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db := GetDatabase()
+	if err := db.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if name := db.GetMobName(0); name != "T4 Wolf" {
+		t.Errorf("expected T4 Wolf, got %q", name)
+	}
+}
+
+// TestGetMobName tests name resolution by type ID
+func TestGetMobName(t *testing.T) {
+	db := loadFixture(t)
+
+	tests := []struct {
+		id       int
+		expected string
+	}{
+		{0, "T1 Rabbit"},
+		{2, "T6 Heretic Archer"},
+		{3, "Keeper Bear"},
+		{1, "Mob#1"},
+		{99, "Mob#99"},
+	}
+	for _, tt := range tests {
+		if name := db.GetMobName(tt.id); name != tt.expected {
+			t.Errorf("GetMobName(%d) = %q, expected %q", tt.id, name, tt.expected)
+		}
+	}
+}
+
+// TestLoadFromFileErrors tests missing, invalid and malformed files
+func TestLoadFromFileErrors(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+	tmpDir := t.TempDir()
+
+	if err := db.LoadFromFile(filepath.Join(tmpDir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	for name, content := range map[string]string{
+		"invalid.json":  `{not json`,
+		"no_mobs.json":  `{"items": {}}`,
+		"bad_mobs.json": `{"Mobs": {"Mob": "T4_MOB_WOLF"}}`,
+	} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		if err := db.LoadFromFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if db.IsLoaded() {
+		t.Error("database should not be loaded after errors")
+	}
+}
+
+// TestLoadFromPath tests finding mobs.json in an ao-bin-dumps directory
+func TestLoadFromPath(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+
+	baseDir := t.TempDir()
+	dumpsDir := filepath.Join(baseDir, "ao-bin-dumps")
+	if err := os.MkdirAll(dumpsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dumpsDir, "mobs.json"), []byte(fixtureMobsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.LoadFromPath(baseDir); err != nil {
+		t.Fatalf("LoadFromPath failed: %v", err)
+	}
+	if db.MobCount() != 3 {
+		t.Errorf("expected 3 mobs, got %d", db.MobCount())
+	}
+
+	resetDatabase()
+	if err := GetDatabase().LoadFromPath(t.TempDir()); err == nil {
+		t.Error("expected error when mobs.json is not found")
+	}
+}
@@ -5,16 +5,26 @@ import (
 	"encoding/binary"
 	"errors"
 	"math"
+	"sync"
 )
 
 // ErrBufferUnderflow is returned when there are not enough bytes to read.
 var ErrBufferUnderflow = errors.New("buffer underflow: not enough data to read")
 
+// ErrVarIntOverflow is returned when a variable-length integer doesn't fit in 32 bits.
+var ErrVarIntOverflow = errors.New("varint overflows 32 bits")
+
+// maxVarIntBytes is the length of the longest 7-bit encoded 32-bit integer
+const maxVarIntBytes = 5
+
 // BufferReader provides sequential reading of a byte buffer
 // with automatic offset management and bounds checking.
 type BufferReader struct {
 	data   []byte
 	offset int
+
+	// Decode TypeString values with ReadCompactString instead of ReadString
+	compactStrings bool
 }
 
 // NewBufferReader creates a new BufferReader.
@@ -25,6 +35,26 @@ func NewBufferReader(data []byte) *BufferReader {
 	}
 }
 
+// readerPool recycles the BufferReaders used while parsing packets
+var readerPool = sync.Pool{
+	New: func() interface{} { return new(BufferReader) },
+}
+
+// acquireReader returns a pooled BufferReader reading data.
+// Release it with releaseReader once nothing references it anymore.
+func acquireReader(data []byte) *BufferReader {
+	r := readerPool.Get().(*BufferReader)
+	r.ResetData(data)
+	return r
+}
+
+// releaseReader returns r to the pool, dropping its reference to the data
+func releaseReader(r *BufferReader) {
+	r.ResetData(nil)
+	r.compactStrings = false
+	readerPool.Put(r)
+}
+
 // ============================================
 // Information methods
 // ============================================
@@ -72,6 +102,13 @@ func (r *BufferReader) Reset() {
 	r.offset = 0
 }
 
+// ResetData replaces the buffer and moves the offset back to the beginning,
+// so the reader can be reused for another buffer.
+func (r *BufferReader) ResetData(data []byte) {
+	r.data = data
+	r.offset = 0
+}
+
 // Seek moves the offset to a specific position.
 func (r *BufferReader) Seek(pos int) error {
 	if pos < 0 || pos > len(r.data) {
@@ -190,6 +227,18 @@ func (r *BufferReader) ReadBytes(n int) ([]byte, error) {
 	return result, nil
 }
 
+// ReadGUID reads a 16-byte GUID, as sent for persistent player and guild identities.
+// The bytes are returned in wire order (.NET Guid.ToByteArray layout).
+func (r *BufferReader) ReadGUID() ([16]byte, error) {
+	var guid [16]byte
+	if !r.CanRead(16) {
+		return guid, ErrBufferUnderflow
+	}
+	copy(guid[:], r.data[r.offset:r.offset+16])
+	r.offset += 16
+	return guid, nil
+}
+
 // ReadBytesNoCopy reads n bytes without copying (slice of original buffer).
 // Warning: modifying the result affects the original buffer.
 func (r *BufferReader) ReadBytesNoCopy(n int) ([]byte, error) {
@@ -216,6 +265,38 @@ func (r *BufferReader) ReadString() (string, error) {
 	return str, nil
 }
 
+// ReadVarInt reads a variable-length integer: 7 bits per byte, least significant
+// group first, with the high bit set on every byte except the last.
+func (r *BufferReader) ReadVarInt() (int, error) {
+	var value uint32
+	for i := 0; i < maxVarIntBytes; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint32(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			return int(value), nil
+		}
+	}
+	return 0, ErrVarIntOverflow
+}
+
+// ReadCompactString reads a string with a variable-length (7-bit) length prefix.
+// Format: [length varint][data bytes]
+func (r *BufferReader) ReadCompactString() (string, error) {
+	length, err := r.ReadVarInt()
+	if err != nil {
+		return "", err
+	}
+	if !r.CanRead(length) {
+		return "", ErrBufferUnderflow
+	}
+	str := string(r.data[r.offset : r.offset+length])
+	r.offset += length
+	return str, nil
+}
+
 // ReadBool reads 1 byte as boolean (0 = false, != 0 = true).
 func (r *BufferReader) ReadBool() (bool, error) {
 	val, err := r.ReadByte()
@@ -1,6 +1,7 @@
 package photon
 
 import (
+	"math"
 	"testing"
 )
 
@@ -73,6 +74,19 @@ func TestBufferReaderReset(t *testing.T) {
 	}
 }
 
+func TestBufferReaderResetData(t *testing.T) {
+	r := NewBufferReader([]byte{1, 2, 3})
+	r.Skip(2)
+	r.ResetData([]byte{9, 8})
+
+	if r.Offset() != 0 || r.Len() != 2 {
+		t.Errorf("After ResetData(), expected Offset()=0 and Len()=2, got %d and %d", r.Offset(), r.Len())
+	}
+	if b, _ := r.ReadByte(); b != 9 {
+		t.Errorf("Expected 9, got %d", b)
+	}
+}
+
 func TestBufferReaderSeek(t *testing.T) {
 	data := []byte{1, 2, 3, 4, 5}
 	r := NewBufferReader(data)
@@ -234,6 +248,33 @@ func TestBufferReaderReadBytes(t *testing.T) {
 	}
 }
 
+func TestBufferReaderReadGUID(t *testing.T) {
+	data := make([]byte, 17)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+	r := NewBufferReader(data)
+
+	guid, err := r.ReadGUID()
+	if err != nil {
+		t.Fatalf("ReadGUID failed: %v", err)
+	}
+	if guid[0] != 1 || guid[15] != 16 {
+		t.Errorf("Expected bytes 1..16, got %v", guid)
+	}
+	if r.Offset() != 16 {
+		t.Errorf("Expected offset 16, got %d", r.Offset())
+	}
+
+	// Only one byte left
+	if _, err := r.ReadGUID(); err != ErrBufferUnderflow {
+		t.Errorf("Expected ErrBufferUnderflow, got %v", err)
+	}
+	if r.Offset() != 16 {
+		t.Errorf("Offset should not move on underflow, got %d", r.Offset())
+	}
+}
+
 func TestBufferReaderReadBytesNoCopy(t *testing.T) {
 	data := []byte{1, 2, 3, 4, 5}
 	r := NewBufferReader(data)
@@ -262,6 +303,63 @@ func TestBufferReaderReadString(t *testing.T) {
 	}
 }
 
+func TestBufferReaderReadVarInt(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected int
+	}{
+		{[]byte{0x00}, 0},
+		{[]byte{0x7F}, 127},
+		{[]byte{0x80, 0x01}, 128},
+		{[]byte{0xAC, 0x02}, 300},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}, math.MaxUint32},
+	}
+	for _, tt := range tests {
+		r := NewBufferReader(tt.data)
+		val, err := r.ReadVarInt()
+		if err != nil || val != tt.expected {
+			t.Errorf("ReadVarInt(%x) = %d, %v; expected %d", tt.data, val, err, tt.expected)
+		}
+		if !r.IsEmpty() {
+			t.Errorf("ReadVarInt(%x) left %d bytes", tt.data, r.Remaining())
+		}
+	}
+
+	r := NewBufferReader([]byte{0x80, 0x80})
+	if _, err := r.ReadVarInt(); err != ErrBufferUnderflow {
+		t.Errorf("Expected ErrBufferUnderflow, got %v", err)
+	}
+
+	r = NewBufferReader([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01})
+	if _, err := r.ReadVarInt(); err != ErrVarIntOverflow {
+		t.Errorf("Expected ErrVarIntOverflow, got %v", err)
+	}
+}
+
+func TestBufferReaderReadCompactString(t *testing.T) {
+	// Length prefix (varint) + string data
+	data := []byte{0x05, 'h', 'e', 'l', 'l', 'o'}
+	r := NewBufferReader(data)
+
+	str, err := r.ReadCompactString()
+	if err != nil {
+		t.Errorf("ReadCompactString failed: %v", err)
+	}
+	if str != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", str)
+	}
+
+	long := append([]byte{0x80, 0x01}, make([]byte, 128)...)
+	if str, err := NewBufferReader(long).ReadCompactString(); err != nil || len(str) != 128 {
+		t.Errorf("Expected 128-byte string, got %d bytes, %v", len(str), err)
+	}
+
+	r = NewBufferReader([]byte{0x05, 'h', 'i'})
+	if _, err := r.ReadCompactString(); err != ErrBufferUnderflow {
+		t.Errorf("Expected ErrBufferUnderflow, got %v", err)
+	}
+}
+
 func TestBufferReaderReadBool(t *testing.T) {
 	data := []byte{0x00, 0x01, 0xFF}
 	r := NewBufferReader(data)
@@ -0,0 +1,113 @@
+package photon
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// latencySubBucketBits is the number of significant bits kept per power of two:
+// 3 bits (8 sub-buckets) bound the error of a reported value to 12.5%
+const latencySubBucketBits = 3
+
+const (
+	latencySubBuckets = 1 << latencySubBucketBits
+	latencyBuckets    = (64 - latencySubBucketBits + 1) * latencySubBuckets
+)
+
+// latencyHistogram is a log-linear (HDR-style) histogram of durations in
+// nanoseconds. Recording is a couple of atomic operations, with no lock, so it
+// can be called for every packet; reading walks the buckets.
+type latencyHistogram struct {
+	counts [latencyBuckets]uint64
+	max    uint64
+}
+
+// latencyBucket returns the bucket of a value: values below latencySubBuckets have
+// their own bucket, larger ones are grouped by power of two and top bits
+func latencyBucket(v uint64) int {
+	if v < latencySubBuckets {
+		return int(v)
+	}
+	exp := bits.Len64(v) - 1
+	mantissa := (v >> (exp - latencySubBucketBits)) & (latencySubBuckets - 1)
+	return (exp-latencySubBucketBits+1)*latencySubBuckets + int(mantissa)
+}
+
+// latencyBucketUpper returns the largest value falling in a bucket
+func latencyBucketUpper(i int) uint64 {
+	if i < latencySubBuckets {
+		return uint64(i)
+	}
+	exp := i/latencySubBuckets + latencySubBucketBits - 1
+	mantissa := uint64(i % latencySubBuckets)
+	lower := (latencySubBuckets + mantissa) << (exp - latencySubBucketBits)
+	return lower + (1 << (exp - latencySubBucketBits)) - 1
+}
+
+// record adds a duration to the histogram (negative durations count as 0)
+func (h *latencyHistogram) record(d time.Duration) {
+	v := uint64(0)
+	if d > 0 {
+		v = uint64(d)
+	}
+	atomic.AddUint64(&h.counts[latencyBucket(v)], 1)
+
+	for {
+		old := atomic.LoadUint64(&h.max)
+		if v <= old || atomic.CompareAndSwapUint64(&h.max, old, v) {
+			return
+		}
+	}
+}
+
+// count returns the number of recorded durations
+func (h *latencyHistogram) count() uint64 {
+	var total uint64
+	for i := range h.counts {
+		total += atomic.LoadUint64(&h.counts[i])
+	}
+	return total
+}
+
+// quantile returns the duration below which a fraction q (0-1) of the recorded
+// durations fall, rounded up to its bucket and capped at the maximum; 0 if empty
+func (h *latencyHistogram) quantile(q float64) time.Duration {
+	var counts [latencyBuckets]uint64
+	var total uint64
+	for i := range h.counts {
+		counts[i] = atomic.LoadUint64(&h.counts[i])
+		total += counts[i]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	// Nearest rank
+	rank := uint64(math.Ceil(q * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, c := range counts {
+		seen += c
+		if seen >= rank {
+			return time.Duration(min(latencyBucketUpper(i), atomic.LoadUint64(&h.max)))
+		}
+	}
+	return h.maximum()
+}
+
+// maximum returns the longest recorded duration
+func (h *latencyHistogram) maximum() time.Duration {
+	return time.Duration(atomic.LoadUint64(&h.max))
+}
+
+// reset clears the histogram
+func (h *latencyHistogram) reset() {
+	for i := range h.counts {
+		atomic.StoreUint64(&h.counts[i], 0)
+	}
+	atomic.StoreUint64(&h.max, 0)
+}
@@ -0,0 +1,63 @@
+package photon
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLatencyBucketBounds tests that every value falls in a bucket whose bounds contain it
+func TestLatencyBucketBounds(t *testing.T) {
+	values := []uint64{0, 1, 7, 8, 9, 15, 16, 100, 1000, 12345, 1 << 20, 1<<40 + 3, 1<<63 + 1<<62}
+	for _, v := range values {
+		i := latencyBucket(v)
+		if i < 0 || i >= latencyBuckets {
+			t.Fatalf("value %d: bucket %d out of range", v, i)
+		}
+		if upper := latencyBucketUpper(i); upper < v {
+			t.Errorf("value %d: expected bucket upper bound >= value, got %d", v, upper)
+		}
+		if i > 0 && latencyBucketUpper(i-1) >= v {
+			t.Errorf("value %d: expected previous bucket to end below value, got %d", v, latencyBucketUpper(i-1))
+		}
+	}
+}
+
+// TestLatencyHistogramQuantiles tests percentiles within the bucket precision
+func TestLatencyHistogramQuantiles(t *testing.T) {
+	var h latencyHistogram
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Microsecond)
+	}
+
+	within := func(name string, got, want time.Duration) {
+		t.Helper()
+		if got < want || float64(got) > float64(want)*1.125 {
+			t.Errorf("expected %s within 12.5%% above %v, got %v", name, want, got)
+		}
+	}
+	within("p50", h.quantile(0.50), 50*time.Microsecond)
+	within("p99", h.quantile(0.99), 99*time.Microsecond)
+
+	if h.maximum() != 100*time.Microsecond {
+		t.Errorf("expected max 100µs, got %v", h.maximum())
+	}
+	if h.quantile(1) != 100*time.Microsecond {
+		t.Errorf("expected p100 capped at the max, got %v", h.quantile(1))
+	}
+	if h.count() != 100 {
+		t.Errorf("expected 100 recorded, got %d", h.count())
+	}
+}
+
+// TestLatencyHistogramEmpty tests that an empty histogram reports zeros
+func TestLatencyHistogramEmpty(t *testing.T) {
+	var h latencyHistogram
+	if h.quantile(0.5) != 0 || h.maximum() != 0 || h.count() != 0 {
+		t.Errorf("expected zeros, got p50 %v, max %v, count %d", h.quantile(0.5), h.maximum(), h.count())
+	}
+
+	h.record(-time.Second)
+	if h.maximum() != 0 || h.count() != 1 {
+		t.Errorf("expected a negative duration recorded as 0, got max %v, count %d", h.maximum(), h.count())
+	}
+}
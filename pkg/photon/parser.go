@@ -5,6 +5,7 @@ package photon
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,6 +21,9 @@ const (
 	CommandTypeSendUnreliable = 7
 	CommandTypeSendFragment   = 8
 
+	// Unreliable fragments (newer Photon versions) carry a 4-byte unreliable sequence before the fragment header
+	CommandTypeSendUnreliableFragment = 15
+
 	// Message types
 	MessageTypeOperationRequest  = 2
 	MessageTypeOperationResponse = 3
@@ -30,6 +34,10 @@ const (
 	// Fragment cleanup settings
 	FragmentTTL             = 30 * time.Second // Fragments expire after 30s
 	FragmentCleanupInterval = 10 * time.Second // Cleanup runs every 10s
+
+	// MaxFragmentedLength bounds the total length of a fragmented message; fragments
+	// announcing a larger (or negative) length are malformed
+	MaxFragmentedLength = 16 << 20
 )
 
 // PhotonHandler is called when a Photon message is decoded
@@ -39,22 +47,87 @@ type PhotonHandler interface {
 	OnEvent(eventCode byte, parameters map[byte]interface{})
 }
 
+// MessageInfo describes how a decoded message was delivered
+type MessageInfo struct {
+	ReceivedAt time.Time // When the message was received (the first fragment for fragmented messages)
+	Reliable   bool      // Whether it was delivered by a reliable command (reliable, fragmented or TCP)
+}
+
+// MessageInfoHandler is a PhotonHandler that also receives the MessageInfo of each
+// message. The parser calls these methods instead of the PhotonHandler ones.
+type MessageInfoHandler interface {
+	PhotonHandler
+	OnRequestInfo(info MessageInfo, operationCode byte, parameters map[byte]interface{})
+	OnResponseInfo(info MessageInfo, operationCode byte, returnCode int16, debugMessage string, parameters map[byte]interface{})
+	OnEventInfo(info MessageInfo, eventCode byte, parameters map[byte]interface{})
+}
+
 // Parser parses Photon protocol packets
 type Parser struct {
 	handler          PhotonHandler
 	pendingFragments map[int32]*fragmentedPacket
-	fragmentsMu      sync.RWMutex  // Protects pendingFragments
+	fragmentsMu      sync.RWMutex     // Protects pendingFragments
 	debug            bool
-	stopCleanup      chan struct{} // Signal to stop cleanup goroutine
-	Stats            *Stats        // Parser statistics
+	strict           bool             // Reject packets that fail any validation
+	checkCRC         bool             // Drop packets with CRC enabled whose CRC doesn't match
+	compactStrings   bool             // Strings have a 7-bit variable-length prefix (Protocol16.5)
+	profiling        bool             // Record ParsePacket processing times in Stats
+	stopCleanup      chan struct{}    // Signal to stop cleanup goroutine
+	now              func() time.Time // Current time (see SetClock)
+	Stats            *Stats           // Parser statistics
+
+	// One-shot packet trace callback (see TraceNextPacket)
+	traceNext atomic.Pointer[func(trace string)]
+
+	// Expired fragmented message callback (see SetFragmentExpiredCallback)
+	fragmentExpired atomic.Pointer[func(seq int32, received, total int)]
 }
 
 // fragmentedPacket holds data for reassembling fragmented packets
 type fragmentedPacket struct {
-	totalLength  int32
-	payload      []byte
-	bytesWritten int
-	createdAt    time.Time // When the fragment was first received
+	totalLength    int32
+	payload        []byte
+	bytesWritten   int
+	fragmentCount  int       // Number of fragments the message was split into
+	fragmentsFound int       // Number of fragments received so far
+	createdAt      time.Time // When the fragment was first received
+	reliable       bool      // Whether the first fragment was sent by a reliable command
+}
+
+// maxPooledPayload is the largest reassembly buffer kept for reuse, larger (rare)
+// messages leave theirs to the garbage collector
+const maxPooledPayload = 64 << 10
+
+// fragmentPool recycles fragmentedPackets along with their payload buffers
+var fragmentPool = sync.Pool{
+	New: func() interface{} { return new(fragmentedPacket) },
+}
+
+// newFragmentedPacket returns a pooled fragmentedPacket with a zeroed payload of totalLength bytes
+func newFragmentedPacket(totalLength int32, fragmentCount int, reliable bool, createdAt time.Time) *fragmentedPacket {
+	frag := fragmentPool.Get().(*fragmentedPacket)
+	if cap(frag.payload) >= int(totalLength) {
+		frag.payload = frag.payload[:totalLength]
+		clear(frag.payload)
+	} else {
+		frag.payload = make([]byte, totalLength)
+	}
+	frag.totalLength = totalLength
+	frag.bytesWritten = 0
+	frag.fragmentCount = fragmentCount
+	frag.fragmentsFound = 0
+	frag.createdAt = createdAt
+	frag.reliable = reliable
+	return frag
+}
+
+// releaseFragmentedPacket returns frag to the pool. Decoded values never reference the
+// payload (byte arrays and strings are copied), so it can be reused once decoded.
+func releaseFragmentedPacket(frag *fragmentedPacket) {
+	if cap(frag.payload) > maxPooledPayload {
+		frag.payload = nil
+	}
+	fragmentPool.Put(frag)
 }
 
 // NewParser creates a new Photon parser
@@ -64,6 +137,7 @@ func NewParser(handler PhotonHandler) *Parser {
 		pendingFragments: make(map[int32]*fragmentedPacket),
 		debug:            false,
 		stopCleanup:      make(chan struct{}),
+		now:              time.Now,
 		Stats:            NewStats(),
 	}
 
@@ -78,6 +152,52 @@ func (p *Parser) SetDebug(debug bool) {
 	p.debug = debug
 }
 
+// SetStrictMode enables or disables strict mode.
+// In strict mode, CRC and length checks are enforced and any packet that does not
+// fully validate is dropped instead of being parsed on a best-effort basis.
+func (p *Parser) SetStrictMode(strict bool) {
+	p.strict = strict
+}
+
+// SetValidateCRC enables or disables CRC validation of packets with CRC enabled.
+// Packets whose CRC doesn't match are dropped and counted as CRC failures.
+// Disabled by default; strict mode always validates the CRC.
+func (p *Parser) SetValidateCRC(validate bool) {
+	p.checkCRC = validate
+}
+
+// SetCompactStrings enables or disables decoding strings with a variable-length (7-bit)
+// length prefix, used by servers that migrated to the newer "Protocol16.5" serialization.
+// Disabled by default (2-byte big-endian length prefix).
+func (p *Parser) SetCompactStrings(compact bool) {
+	p.compactStrings = compact
+}
+
+// SetProfiling enables or disables timing ParsePacket, recording processing times in
+// Stats (see Stats.ProcessingP50). Disabled by default.
+func (p *Parser) SetProfiling(profiling bool) {
+	p.profiling = profiling
+}
+
+// SetFragmentExpiredCallback sets a function called for each fragmented message dropped
+// incomplete after FragmentTTL, with its start sequence number and the number of
+// fragments received out of its total: a sign of packet loss (nil to disable).
+// It is called from the cleanup goroutine, without any parser lock held.
+func (p *Parser) SetFragmentExpiredCallback(fn func(seq int32, received, total int)) {
+	if fn == nil {
+		p.fragmentExpired.Store(nil)
+		return
+	}
+	p.fragmentExpired.Store(&fn)
+}
+
+// SetClock sets the function the parser reads the current time from (time.Now by
+// default), for packet receive times and fragment expiry. Tests use it to expire
+// fragments without waiting for FragmentTTL.
+func (p *Parser) SetClock(now func() time.Time) {
+	p.now = now
+}
+
 // Close stops the cleanup goroutine and releases resources.
 // Should be called when the parser is no longer needed.
 func (p *Parser) Close() {
@@ -101,22 +221,34 @@ func (p *Parser) cleanupLoop() {
 
 // cleanupExpiredFragments removes fragments older than FragmentTTL
 func (p *Parser) cleanupExpiredFragments() {
-	p.fragmentsMu.Lock()
-	defer p.fragmentsMu.Unlock()
-
-	now := time.Now()
-	expired := 0
+	// expiredFragment describes a dropped message for the callback
+	type expiredFragment struct {
+		seq             int32
+		received, total int
+	}
+	var expired []expiredFragment
 
+	p.fragmentsMu.Lock()
+	now := p.now()
 	for seqNum, frag := range p.pendingFragments {
 		if now.Sub(frag.createdAt) > FragmentTTL {
+			expired = append(expired, expiredFragment{seqNum, frag.fragmentsFound, frag.fragmentCount})
 			delete(p.pendingFragments, seqNum)
-			expired++
+			releaseFragmentedPacket(frag)
 			p.Stats.IncrFragmentsExpired()
 		}
 	}
+	p.fragmentsMu.Unlock()
+
+	if p.debug && len(expired) > 0 {
+		fmt.Printf("  [Photon] Cleaned up %d expired fragments\n", len(expired))
+	}
 
-	if p.debug && expired > 0 {
-		fmt.Printf("  [Photon] Cleaned up %d expired fragments\n", expired)
+	// Called without the lock, so the callback may use the parser
+	if fn := p.fragmentExpired.Load(); fn != nil {
+		for _, e := range expired {
+			(*fn)(e.seq, e.received, e.total)
+		}
 	}
 }
 
@@ -129,16 +261,36 @@ func (p *Parser) PendingFragmentsCount() int {
 
 // ParsePacket parses a raw UDP payload as a Photon packet
 func (p *Parser) ParsePacket(payload []byte) error {
+	receivedAt := p.now()
 	p.Stats.IncrPacketsReceived()
 	p.Stats.AddBytesReceived(uint64(len(payload)))
-	p.Stats.LastPacketTime = time.Now()
+	p.Stats.LastPacketTime = receivedAt
+
+	p.traceIfArmed(payload)
+
+	if p.profiling {
+		// Wall time, not p.now: a test clock would make every duration 0
+		start := time.Now()
+		defer func() { p.Stats.RecordProcessingTime(time.Since(start)) }()
+	}
 
 	if len(payload) < PhotonHeaderLength {
 		p.Stats.IncrPacketsMalformed()
 		return fmt.Errorf("packet too short: %d bytes", len(payload))
 	}
 
-	r := NewBufferReader(payload)
+	if p.strict {
+		if err := validatePacket(payload); err != nil {
+			p.Stats.IncrPacketsStrictRejected()
+			if p.debug {
+				fmt.Printf("  [Photon] Strict mode rejected packet: %v\n", err)
+			}
+			return fmt.Errorf("strict mode: %w", err)
+		}
+	}
+
+	r := acquireReader(payload)
+	defer releaseReader(r)
 
 	// Read Photon header
 	_ = r.Skip(2) // peerId (ignored)
@@ -163,10 +315,18 @@ func (p *Parser) ParsePacket(payload []byte) error {
 
 	if isCrcEnabled {
 		p.Stats.IncrPacketsWithCRC()
-		// Skip CRC field and validate (for now, just skip)
-		// In a full implementation, we'd validate the CRC
+		if p.checkCRC && !p.strict {
+			if err := validateCRC(payload); err != nil {
+				p.Stats.IncrPacketsCRCFailed()
+				if p.debug {
+					fmt.Printf("  [Photon] Dropped packet: %v\n", err)
+				}
+				return err
+			}
+		}
+		// Skip CRC field (validated above or beforehand in strict mode)
 		_ = r.Skip(4)
-		if p.debug {
+		if p.debug && !p.strict && !p.checkCRC {
 			fmt.Println("  [Photon] Packet has CRC enabled (skipping validation)")
 		}
 	}
@@ -186,6 +346,10 @@ func (p *Parser) ParsePacket(payload []byte) error {
 		sequenceNumber, _ := r.ReadInt32()
 
 		dataLength := int(commandLength) - CommandHeaderLength
+		if dataLength < 0 {
+			p.rejectMalformed("command", fmt.Errorf("command length %d shorter than header", commandLength))
+			break
+		}
 
 		if r.Remaining() < dataLength {
 			if p.debug {
@@ -203,18 +367,34 @@ func (p *Parser) ParsePacket(payload []byte) error {
 
 		case CommandTypeSendUnreliable:
 			// Skip 4 bytes for unreliable sequence
+			if dataLength < 4 {
+				p.rejectMalformed("command", fmt.Errorf("unreliable command too short: %d bytes", dataLength))
+				return nil
+			}
 			_ = r.Skip(4)
 			dataLength -= 4
 			commandData, _ := r.ReadBytesNoCopy(dataLength)
-			p.handleSendReliable(commandData)
+			p.handleSendReliable(commandData, MessageInfo{ReceivedAt: receivedAt})
 
 		case CommandTypeSendReliable:
 			commandData, _ := r.ReadBytesNoCopy(dataLength)
-			p.handleSendReliable(commandData)
+			p.handleSendReliable(commandData, MessageInfo{ReceivedAt: receivedAt, Reliable: true})
 
 		case CommandTypeSendFragment:
 			commandData, _ := r.ReadBytesNoCopy(dataLength)
-			p.handleSendFragment(commandData, sequenceNumber)
+			p.handleSendFragment(commandData, sequenceNumber, true)
+
+		case CommandTypeSendUnreliableFragment:
+			// Skip 4 bytes for unreliable sequence
+			if dataLength < 4 {
+				p.rejectMalformed("command", fmt.Errorf("unreliable command too short: %d bytes", dataLength))
+				return nil
+			}
+			_ = r.Skip(4)
+			dataLength -= 4
+			commandData, _ := r.ReadBytesNoCopy(dataLength)
+			p.Stats.IncrFragmentsUnreliable()
+			p.handleSendFragment(commandData, sequenceNumber, false)
 
 		default:
 			_ = r.Skip(dataLength)
@@ -226,13 +406,21 @@ func (p *Parser) ParsePacket(payload []byte) error {
 	return nil
 }
 
+// ParseMessage parses a single Photon message (signal byte, message type and body)
+// delivered outside of a UDP command, e.g. framed in the TCP chat stream.
+func (p *Parser) ParseMessage(data []byte) {
+	p.handleSendReliable(data, MessageInfo{ReceivedAt: p.now(), Reliable: true})
+}
+
 // handleSendReliable processes a reliable command payload
-func (p *Parser) handleSendReliable(data []byte) {
+func (p *Parser) handleSendReliable(data []byte, info MessageInfo) {
 	if len(data) < 2 {
 		return
 	}
 
-	r := NewBufferReader(data)
+	r := acquireReader(data)
+	defer releaseReader(r)
+	r.compactStrings = p.compactStrings
 
 	// Read signal byte
 	signalByte, _ := r.ReadByte()
@@ -252,39 +440,46 @@ func (p *Parser) handleSendReliable(data []byte) {
 		return
 	}
 
-	// Get remaining data as a new BufferReader for decoding
-	remaining := NewBufferReader(r.RemainingBytes())
-
+	// Decode the rest of the message from the same reader
 	switch messageType {
 	case MessageTypeOperationRequest, MessageTypeInternalRequest:
-		p.decodeOperationRequest(remaining)
+		p.decodeOperationRequest(r, info)
 
 	case MessageTypeOperationResponse, MessageTypeInternalResponse:
-		p.decodeOperationResponse(remaining)
+		p.decodeOperationResponse(r, info)
 
 	case MessageTypeEventData:
-		p.decodeEventData(remaining)
+		p.decodeEventData(r, info)
 	}
 }
 
 // handleSendFragment processes a fragmented packet
-func (p *Parser) handleSendFragment(data []byte, sequenceNumber int32) {
+func (p *Parser) handleSendFragment(data []byte, sequenceNumber int32, reliable bool) {
 	if len(data) < FragmentHeaderLength {
 		return
 	}
 
 	p.Stats.IncrFragmentsReceived()
 
-	r := NewBufferReader(data)
+	r := acquireReader(data)
+	defer releaseReader(r)
 
 	startSequenceNumber, _ := r.ReadInt32()
-	_ = r.Skip(4) // fragmentCount (ignored)
+	fragmentCount, _ := r.ReadInt32()
 	_ = r.Skip(4) // fragmentNumber (ignored)
 	totalLength, _ := r.ReadInt32()
 	fragmentOffset, _ := r.ReadUint32()
 
 	fragmentLength := len(data) - FragmentHeaderLength
 
+	if totalLength < 0 || totalLength > MaxFragmentedLength {
+		p.Stats.IncrPacketsMalformed()
+		if p.debug {
+			fmt.Printf("  [Photon] Fragment total length out of range: %d\n", totalLength)
+		}
+		return
+	}
+
 	// Validate we have enough data
 	if r.Remaining() < fragmentLength {
 		if p.debug {
@@ -299,11 +494,7 @@ func (p *Parser) handleSendFragment(data []byte, sequenceNumber int32) {
 	// Get or create pending fragment
 	frag, exists := p.pendingFragments[startSequenceNumber]
 	if !exists {
-		frag = &fragmentedPacket{
-			totalLength: totalLength,
-			payload:     make([]byte, totalLength),
-			createdAt:   time.Now(),
-		}
+		frag = newFragmentedPacket(totalLength, int(fragmentCount), reliable, p.now())
 		p.pendingFragments[startSequenceNumber] = frag
 	}
 
@@ -313,6 +504,7 @@ func (p *Parser) handleSendFragment(data []byte, sequenceNumber int32) {
 		fragmentData, _ := r.ReadBytesNoCopy(fragmentLength)
 		copy(frag.payload[fragOff:], fragmentData)
 		frag.bytesWritten += fragmentLength
+		frag.fragmentsFound++
 	}
 
 	// Check if complete
@@ -326,20 +518,33 @@ func (p *Parser) handleSendFragment(data []byte, sequenceNumber int32) {
 			fmt.Printf("  [Photon] Reassembled fragmented packet: %d bytes\n", frag.totalLength)
 		}
 
-		p.handleSendReliable(frag.payload)
+		p.handleSendReliable(frag.payload, MessageInfo{ReceivedAt: frag.createdAt, Reliable: frag.reliable})
+		releaseFragmentedPacket(frag)
 	} else {
 		p.fragmentsMu.Unlock()
 	}
 }
 
+// rejectMalformed counts a message whose parameters couldn't be decoded
+func (p *Parser) rejectMalformed(kind string, err error) {
+	p.Stats.IncrPacketsMalformed()
+	if p.debug {
+		fmt.Printf("  [Photon] Dropped malformed %s: %v\n", kind, err)
+	}
+}
+
 // decodeOperationRequest decodes an operation request
-func (p *Parser) decodeOperationRequest(r *BufferReader) {
+func (p *Parser) decodeOperationRequest(r *BufferReader, info MessageInfo) {
 	if r.Remaining() < 1 {
 		return
 	}
 
 	operationCode, _ := r.ReadByte()
-	parameters := decodeParameterTable(r)
+	parameters, err := decodeParameterTable(r)
+	if err != nil {
+		p.rejectMalformed("request", err)
+		return
+	}
 
 	p.Stats.IncrRequestsDecoded()
 
@@ -347,13 +552,15 @@ func (p *Parser) decodeOperationRequest(r *BufferReader) {
 		fmt.Printf("  [Photon] Request: code=%d, params=%d\n", operationCode, len(parameters))
 	}
 
-	if p.handler != nil {
+	if h, ok := p.handler.(MessageInfoHandler); ok {
+		h.OnRequestInfo(info, operationCode, parameters)
+	} else if p.handler != nil {
 		p.handler.OnRequest(operationCode, parameters)
 	}
 }
 
 // decodeOperationResponse decodes an operation response
-func (p *Parser) decodeOperationResponse(r *BufferReader) {
+func (p *Parser) decodeOperationResponse(r *BufferReader, info MessageInfo) {
 	if r.Remaining() < 4 {
 		return
 	}
@@ -369,7 +576,7 @@ func (p *Parser) decodeOperationResponse(r *BufferReader) {
 			// Read type byte
 			_, _ = r.ReadByte()
 			// Read string value
-			if msg, err := r.ReadString(); err == nil {
+			if msg, err := readString(r); err == nil {
 				debugMessage = msg
 			}
 		} else {
@@ -378,7 +585,11 @@ func (p *Parser) decodeOperationResponse(r *BufferReader) {
 		}
 	}
 
-	parameters := decodeParameterTable(r)
+	parameters, err := decodeParameterTable(r)
+	if err != nil {
+		p.rejectMalformed("response", err)
+		return
+	}
 
 	p.Stats.IncrResponsesDecoded()
 
@@ -386,27 +597,53 @@ func (p *Parser) decodeOperationResponse(r *BufferReader) {
 		fmt.Printf("  [Photon] Response: code=%d, return=%d, params=%d\n", operationCode, returnCode, len(parameters))
 	}
 
-	if p.handler != nil {
+	if h, ok := p.handler.(MessageInfoHandler); ok {
+		h.OnResponseInfo(info, operationCode, returnCode, debugMessage, parameters)
+	} else if p.handler != nil {
 		p.handler.OnResponse(operationCode, returnCode, debugMessage, parameters)
 	}
 }
 
+// paramEventCode is the parameter holding the game's own event code; the Photon
+// event code is the same for most game events
+const paramEventCode = 252
+
+// gameEventCode returns the game event code of an event, the Photon code if it has none
+func gameEventCode(eventCode byte, parameters map[byte]interface{}) int32 {
+	switch v := parameters[paramEventCode].(type) {
+	case int16:
+		return int32(v)
+	case int32:
+		return v
+	case int64:
+		return int32(v)
+	}
+	return int32(eventCode)
+}
+
 // decodeEventData decodes an event
-func (p *Parser) decodeEventData(r *BufferReader) {
+func (p *Parser) decodeEventData(r *BufferReader, info MessageInfo) {
 	if r.Remaining() < 1 {
 		return
 	}
 
 	eventCode, _ := r.ReadByte()
-	parameters := decodeParameterTable(r)
+	parameters, err := decodeParameterTable(r)
+	if err != nil {
+		p.rejectMalformed("event", err)
+		return
+	}
 
 	p.Stats.IncrEventsDecoded()
+	p.Stats.IncrEventCode(gameEventCode(eventCode, parameters))
 
 	if p.debug {
 		fmt.Printf("  [Photon] Event: code=%d, params=%d\n", eventCode, len(parameters))
 	}
 
-	if p.handler != nil {
+	if h, ok := p.handler.(MessageInfoHandler); ok {
+		h.OnEventInfo(info, eventCode, parameters)
+	} else if p.handler != nil {
 		p.handler.OnEvent(eventCode, parameters)
 	}
 }
@@ -1,6 +1,7 @@
 package photon
 
 import (
+	"encoding/binary"
 	"testing"
 	"time"
 )
@@ -150,3 +151,443 @@ func TestFragmentTTLConstants(t *testing.T) {
 		t.Errorf("expected FragmentCleanupInterval to be 10s, got %v", FragmentCleanupInterval)
 	}
 }
+
+// infoHandler records the MessageInfo passed with each event
+type infoHandler struct {
+	mockHandler
+	infos []MessageInfo
+}
+
+func (h *infoHandler) OnRequestInfo(info MessageInfo, operationCode byte, parameters map[byte]interface{}) {
+}
+
+func (h *infoHandler) OnResponseInfo(info MessageInfo, operationCode byte, returnCode int16, debugMessage string, parameters map[byte]interface{}) {
+}
+
+func (h *infoHandler) OnEventInfo(info MessageInfo, eventCode byte, parameters map[byte]interface{}) {
+	h.infos = append(h.infos, info)
+}
+
+// TestMessageInfoReceivedAt tests that events are passed the time their message was
+// received, the first fragment's for fragmented messages
+func TestMessageInfoReceivedAt(t *testing.T) {
+	handler := &infoHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	parser.SetClock(func() time.Time { return now })
+
+	first := buildFragment(7, 2, 0, eventMessage, 0, 2)
+	second := buildFragment(7, 2, 1, eventMessage, 2, len(eventMessage)-2)
+	_ = parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, first)))
+	fragmentStart := now
+
+	now = now.Add(time.Second)
+	_ = parser.ParsePacket(buildPacket(0,
+		buildCommand(CommandTypeSendFragment, second),
+		buildCommand(CommandTypeSendReliable, eventMessage),
+	))
+
+	expected := []time.Time{fragmentStart, now}
+	if len(handler.infos) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(handler.infos))
+	}
+	for i, want := range expected {
+		if got := handler.infos[i].ReceivedAt; !got.Equal(want) {
+			t.Errorf("event %d: expected received at %v, got %v", i, want, got)
+		}
+	}
+	if handler.events != 0 {
+		t.Errorf("expected OnEventInfo to be called instead of OnEvent, got %d OnEvent calls", handler.events)
+	}
+}
+
+// TestMessageReliable tests the reliability flag for each command type
+func TestMessageReliable(t *testing.T) {
+	handler := &infoHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	// Single fragment carrying the whole message
+	fragment := make([]byte, FragmentHeaderLength, FragmentHeaderLength+len(eventMessage))
+	binary.BigEndian.PutUint32(fragment[4:8], 1)
+	binary.BigEndian.PutUint32(fragment[12:16], uint32(len(eventMessage)))
+	fragment = append(fragment, eventMessage...)
+
+	packet := buildPacket(0,
+		buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 1}, eventMessage...)),
+		buildCommand(CommandTypeSendReliable, eventMessage),
+		buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 2}, eventMessage...)),
+		buildCommand(CommandTypeSendFragment, fragment),
+		buildCommand(CommandTypeSendUnreliableFragment, append([]byte{0, 0, 0, 3}, fragment...)),
+	)
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []bool{false, true, false, true, false}
+	if len(handler.infos) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(handler.infos))
+	}
+	for i, want := range expected {
+		if handler.infos[i].Reliable != want {
+			t.Errorf("event %d: expected reliable=%v, got %v", i, want, handler.infos[i].Reliable)
+		}
+	}
+}
+
+// buildFragment builds the fragment at offset of a message split into count fragments
+func buildFragment(startSequence int32, count, number int, message []byte, offset, size int) []byte {
+	fragment := make([]byte, FragmentHeaderLength, FragmentHeaderLength+size)
+	binary.BigEndian.PutUint32(fragment[0:4], uint32(startSequence))
+	binary.BigEndian.PutUint32(fragment[4:8], uint32(count))
+	binary.BigEndian.PutUint32(fragment[8:12], uint32(number))
+	binary.BigEndian.PutUint32(fragment[12:16], uint32(len(message)))
+	binary.BigEndian.PutUint32(fragment[16:20], uint32(offset))
+	return append(fragment, message[offset:offset+size]...)
+}
+
+// TestMixedFragmentReassembly tests that reliable and unreliable fragments of the same
+// message are reassembled together and counted separately
+func TestMixedFragmentReassembly(t *testing.T) {
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	first := buildFragment(7, 2, 0, eventMessage, 0, 2)
+	second := buildFragment(7, 2, 1, eventMessage, 2, len(eventMessage)-2)
+
+	if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, first))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.events != 0 || parser.PendingFragmentsCount() != 1 {
+		t.Fatalf("expected 1 pending fragment and no events, got %d pending, %d events", parser.PendingFragmentsCount(), handler.events)
+	}
+
+	unreliable := append([]byte{0, 0, 0, 1}, second...)
+	if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendUnreliableFragment, unreliable))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.events != 1 {
+		t.Errorf("expected 1 reassembled event, got %d", handler.events)
+	}
+	if parser.PendingFragmentsCount() != 0 {
+		t.Errorf("expected no pending fragments, got %d", parser.PendingFragmentsCount())
+	}
+	if parser.Stats.GetFragmentsReceived() != 2 || parser.Stats.GetFragmentsUnreliable() != 1 {
+		t.Errorf("expected 2 fragments (1 unreliable), got %d (%d unreliable)",
+			parser.Stats.GetFragmentsReceived(), parser.Stats.GetFragmentsUnreliable())
+	}
+}
+
+// TestFragmentExpiryWithClock tests fragment expiry and receive times with a fake clock
+func TestFragmentExpiryWithClock(t *testing.T) {
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	parser.SetClock(func() time.Time { return now })
+
+	first := buildFragment(9, 2, 0, eventMessage, 0, 2)
+	if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, first))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parser.Stats.LastPacketTime.Equal(now) {
+		t.Errorf("expected last packet time %v, got %v", now, parser.Stats.LastPacketTime)
+	}
+
+	// Not expired yet at exactly the TTL
+	now = now.Add(FragmentTTL)
+	parser.cleanupExpiredFragments()
+	if parser.PendingFragmentsCount() != 1 {
+		t.Fatalf("expected fragment pending at the TTL, got %d pending", parser.PendingFragmentsCount())
+	}
+
+	now = now.Add(time.Millisecond)
+	parser.cleanupExpiredFragments()
+	if parser.PendingFragmentsCount() != 0 {
+		t.Errorf("expected fragment expired past the TTL, got %d pending", parser.PendingFragmentsCount())
+	}
+	if parser.Stats.GetFragmentsExpired() != 1 {
+		t.Errorf("expected 1 expired fragment, got %d", parser.Stats.GetFragmentsExpired())
+	}
+}
+
+// TestFragmentExpiredCallback tests that dropped fragmented messages are reported
+// with their received and total fragment counts, without the parser lock held
+func TestFragmentExpiredCallback(t *testing.T) {
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	parser.SetClock(func() time.Time { return now })
+
+	type expired struct {
+		seq             int32
+		received, total int
+	}
+	var reported []expired
+	parser.SetFragmentExpiredCallback(func(seq int32, received, total int) {
+		// Would deadlock if the callback ran under the fragments lock
+		_ = parser.PendingFragmentsCount()
+		reported = append(reported, expired{seq, received, total})
+	})
+
+	message := make([]byte, 30)
+	for i := 0; i < 2; i++ {
+		fragment := buildFragment(9, 3, i, message, i*10, 10)
+		if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, fragment))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	now = now.Add(FragmentTTL + time.Millisecond)
+	parser.cleanupExpiredFragments()
+
+	if len(reported) != 1 {
+		t.Fatalf("expected 1 reported message, got %d", len(reported))
+	}
+	if want := (expired{9, 2, 3}); reported[0] != want {
+		t.Errorf("expected %+v, got %+v", want, reported[0])
+	}
+
+	// Disabled callback
+	parser.SetFragmentExpiredCallback(nil)
+	if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, buildFragment(20, 3, 0, message, 0, 10)))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(FragmentTTL + time.Millisecond)
+	parser.cleanupExpiredFragments()
+	if len(reported) != 1 {
+		t.Errorf("expected no report with the callback disabled, got %d", len(reported))
+	}
+}
+
+// paramsHandler records the parameters of each event
+type paramsHandler struct {
+	mockHandler
+	params []map[byte]interface{}
+}
+
+func (h *paramsHandler) OnEvent(eventCode byte, parameters map[byte]interface{}) {
+	h.params = append(h.params, parameters)
+}
+
+// TestFragmentPayloadReuse tests that recycled reassembly buffers don't corrupt
+// the values of earlier messages or leak into later ones
+func TestFragmentPayloadReuse(t *testing.T) {
+	handler := &paramsHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	messages := [][]byte{
+		{243, MessageTypeEventData, 1, 0, 1, 0, TypeString, 0, 5, 'f', 'i', 'r', 's', 't'},
+		{243, MessageTypeEventData, 1, 0, 1, 0, TypeString, 0, 2, 'n', 'o'},
+	}
+	for i, message := range messages {
+		packet := buildPacket(0,
+			buildCommand(CommandTypeSendFragment, buildFragment(int32(i), 2, 0, message, 0, 4)),
+			buildCommand(CommandTypeSendFragment, buildFragment(int32(i), 2, 1, message, 4, len(message)-4)),
+		)
+		if err := parser.ParsePacket(packet); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(handler.params) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(handler.params))
+	}
+	if handler.params[0][0] != "first" || handler.params[1][0] != "no" {
+		t.Errorf("expected \"first\" and \"no\", got %v and %v", handler.params[0][0], handler.params[1][0])
+	}
+}
+
+// TestCompactStrings tests decoding event strings with 7-bit length prefixes
+func TestCompactStrings(t *testing.T) {
+	handler := &paramsHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	message := []byte{243, MessageTypeEventData, 1, 0, 1, 0, TypeString, 0x04, 'n', 'a', 'm', 'e'}
+	packet := buildPacket(0, buildCommand(CommandTypeSendReliable, message))
+
+	// The default 2-byte prefix misreads the compact length
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parser.SetCompactStrings(true)
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handler.params) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(handler.params))
+	}
+	if handler.params[0][0] == "name" {
+		t.Error("expected the default decoder to garble the compact string")
+	}
+	if handler.params[1][0] != "name" {
+		t.Errorf("expected \"name\", got %v", handler.params[1][0])
+	}
+}
+
+// TestEventCodeCounted tests that decoded events are counted by their game event code
+func TestEventCodeCounted(t *testing.T) {
+	parser := NewParser(&mockHandler{})
+	defer parser.Close()
+
+	withCode := []byte{243, MessageTypeEventData, 1, 0, 1, paramEventCode, TypeShort, 0, 82}
+	// Code 40000, beyond the int16 range
+	withLargeCode := []byte{243, MessageTypeEventData, 1, 0, 1, paramEventCode, TypeInteger, 0, 0, 0x9C, 0x40}
+	packet := buildPacket(0,
+		buildCommand(CommandTypeSendReliable, withCode),
+		buildCommand(CommandTypeSendReliable, withCode),
+		buildCommand(CommandTypeSendReliable, eventMessage),
+		buildCommand(CommandTypeSendReliable, withLargeCode),
+	)
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := parser.Stats.EventCodeCounts()
+	if counts[82] != 2 || counts[1] != 1 || counts[40000] != 1 || len(counts) != 3 {
+		t.Errorf("expected {1:1 82:2 40000:1}, got %v", counts)
+	}
+}
+
+// TestProfiling tests that processing times are only recorded when profiling is enabled
+func TestProfiling(t *testing.T) {
+	parser := NewParser(&mockHandler{})
+	defer parser.Close()
+
+	packet := buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage))
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parser.Stats.ProcessingCount() != 0 {
+		t.Errorf("expected no processing times without profiling, got %d", parser.Stats.ProcessingCount())
+	}
+
+	parser.SetProfiling(true)
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = parser.ParsePacket([]byte{1}) // Malformed packets are timed too
+	if parser.Stats.ProcessingCount() != 2 {
+		t.Errorf("expected 2 processing times, got %d", parser.Stats.ProcessingCount())
+	}
+}
+
+// TestMalformedParameterTable tests that messages with bogus lengths are dropped and counted
+func TestMalformedParameterTable(t *testing.T) {
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	// One int array parameter announcing ~4 billion elements
+	hugeArray := []byte{243, MessageTypeEventData, 1, 0, 1, 0, TypeIntegerArray, 0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 1}
+
+	// Arrays nested deeper than maxValueDepth
+	nested := []byte{243, MessageTypeEventData, 1, 0, 1, 0}
+	for i := 0; i <= maxValueDepth+1; i++ {
+		nested = append(nested, TypeObjectArray, 0, 1)
+	}
+	nested = append(nested, TypeByte, 1)
+
+	for name, message := range map[string][]byte{"huge array": hugeArray, "deep nesting": nested} {
+		before := parser.Stats.GetPacketsMalformed()
+		if err := parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendReliable, message))); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if parser.Stats.GetPacketsMalformed() != before+1 {
+			t.Errorf("%s: expected the message to be counted as malformed", name)
+		}
+	}
+	if handler.events != 0 {
+		t.Errorf("expected malformed events not to be dispatched, got %d", handler.events)
+	}
+}
+
+// TestFragmentLengthOutOfRange tests that fragments announcing an absurd total length are dropped
+func TestFragmentLengthOutOfRange(t *testing.T) {
+	parser := NewParser(&mockHandler{})
+	defer parser.Close()
+
+	fragment := buildFragment(1, 2, 0, eventMessage, 0, 2)
+	binary.BigEndian.PutUint32(fragment[12:16], 0xFFFFFFF0) // Negative as int32
+	_ = parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, fragment)))
+
+	binary.BigEndian.PutUint32(fragment[12:16], MaxFragmentedLength+1)
+	_ = parser.ParsePacket(buildPacket(0, buildCommand(CommandTypeSendFragment, fragment)))
+
+	if parser.PendingFragmentsCount() != 0 {
+		t.Errorf("expected no pending fragments, got %d", parser.PendingFragmentsCount())
+	}
+	if parser.Stats.GetPacketsMalformed() != 2 {
+		t.Errorf("expected 2 malformed packets, got %d", parser.Stats.GetPacketsMalformed())
+	}
+}
+
+// fuzzTimeout bounds a single fuzz input, longer means the parser is looping
+const fuzzTimeout = time.Second
+
+// mustReturn fails the test if fn doesn't return within fuzzTimeout
+func mustReturn(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(fuzzTimeout):
+		t.Fatalf("did not return within %v", fuzzTimeout)
+	}
+}
+
+// FuzzParsePacket tests that arbitrary packets never panic or hang the parser
+func FuzzParsePacket(f *testing.F) {
+	f.Add(buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage)))
+	f.Add(buildPacket(0, buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 1}, eventMessage...))))
+	f.Add(buildPacket(0, buildCommand(CommandTypeSendFragment, buildFragment(1, 2, 0, eventMessage, 0, 2))))
+	f.Add(buildPacket(0, buildCommand(CommandTypeSendReliable, []byte{243, MessageTypeEventData, 1, 0, 1, 0, TypeArray, 0, 2, TypeString, 0, 1, 'a', 0, 0})))
+
+	f.Fuzz(func(t *testing.T, packet []byte) {
+		parser := NewParser(&mockHandler{})
+		defer parser.Close()
+		mustReturn(t, func() {
+			_ = parser.ParsePacket(packet)
+		})
+	})
+}
+
+// BenchmarkParsePacket measures parsing a packet with reliable, unreliable and fragmented events
+func BenchmarkParsePacket(b *testing.B) {
+	message := []byte{243, MessageTypeEventData, 1, 0, 2, 0, TypeInteger, 0, 0, 0, 42, 1, TypeString, 0, 3, 'a', 'b', 'c'}
+	half := len(message) / 2
+	packet := buildPacket(0,
+		buildCommand(CommandTypeSendReliable, message),
+		buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 1}, message...)),
+		buildCommand(CommandTypeSendFragment, buildFragment(1, 2, 0, message, 0, half)),
+		buildCommand(CommandTypeSendFragment, buildFragment(1, 2, 1, message, half, len(message)-half)),
+	)
+
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = parser.ParsePacket(packet)
+	}
+
+	if handler.events == 0 {
+		b.Fatal("expected events to be decoded")
+	}
+}
@@ -1,5 +1,10 @@
 package photon
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // Protocol16 data types - ASCII character codes defined by Photon protocol
 const (
 	TypeUnknown        = 0    // Unknown type
@@ -7,6 +12,7 @@ const (
 	TypeDictionary     = 'D'  // 68 - Typed dictionary
 	TypeStringArray    = 'a'  // 97 - Array of strings
 	TypeByte           = 'b'  // 98 - Byte (uint8)
+	TypeCustom         = 'c'  // 99 - Custom type (see CustomTypeVector2...)
 	TypeDouble         = 'd'  // 100 - Float64
 	TypeEventData      = 'e'  // 101 - Event data
 	TypeFloat          = 'f'  // 102 - Float32
@@ -24,18 +30,50 @@ const (
 	TypeObjectArray    = 'z'  // 122 - Array of objects
 )
 
-// decodeParameterTable decodes a Protocol16 parameter table using BufferReader
-func decodeParameterTable(r *BufferReader) map[byte]interface{} {
-	params := make(map[byte]interface{})
+// Protocol16 custom type codes. Custom values are serialized as the type code,
+// a uint16 payload length and the payload; these payloads are big-endian float32s.
+const (
+	CustomTypeQuaternion = 'Q' // 81 - X, Y, Z, W
+	CustomTypeVector3    = 'V' // 86 - X, Y, Z
+	CustomTypeVector2    = 'W' // 87 - X, Y
+)
+
+// customTypeFloats is the number of float32s in the payload of known custom types
+var customTypeFloats = map[byte]int{
+	CustomTypeQuaternion: 4,
+	CustomTypeVector3:    3,
+	CustomTypeVector2:    2,
+}
+
+// maxValueDepth bounds the nesting of arrays and dictionaries in a value
+const maxValueDepth = 32
+
+// malformedValue is panicked by readValue when a value can't be valid (a length larger
+// than the remaining data, or nesting deeper than maxValueDepth), and recovered by
+// decodeParameterTable. Checking lengths up front avoids huge allocations from bogus
+// length fields.
+type malformedValue string
+
+// decodeParameterTable decodes a Protocol16 parameter table using BufferReader.
+// Malformed tables (see malformedValue) and any panic while decoding return an error.
+func decodeParameterTable(r *BufferReader) (params map[byte]interface{}, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			params = nil
+			err = fmt.Errorf("malformed parameter table: %v", rec)
+		}
+	}()
+
+	params = make(map[byte]interface{})
 
 	if r.Remaining() < 2 {
-		return params
+		return params, nil
 	}
 
 	// Read parameter count
 	paramCount, err := r.ReadUint16()
 	if err != nil {
-		return params
+		return params, nil
 	}
 
 	for i := 0; i < int(paramCount) && !r.IsEmpty(); i++ {
@@ -56,14 +94,39 @@ func decodeParameterTable(r *BufferReader) map[byte]interface{} {
 		params[paramKey] = value
 	}
 
-	return params
+	return params, nil
 }
 
 // readValue reads a Protocol16 typed value using BufferReader
 func readValue(r *BufferReader, paramType byte) interface{} {
+	return readNestedValue(r, paramType, 0)
+}
+
+// checkLength panics with malformedValue if length elements of at least minSize bytes
+// can't fit in the remaining data
+func checkLength(r *BufferReader, length, minSize int) {
+	if length*minSize > r.Remaining() {
+		panic(malformedValue(fmt.Sprintf("length %d exceeds remaining %d bytes", length, r.Remaining())))
+	}
+}
+
+// readString reads a string value in the encoding the reader was set up for
+// (see Parser.SetCompactStrings)
+func readString(r *BufferReader) (string, error) {
+	if r.compactStrings {
+		return r.ReadCompactString()
+	}
+	return r.ReadString()
+}
+
+// readNestedValue reads a value nested depth containers deep
+func readNestedValue(r *BufferReader, paramType byte, depth int) interface{} {
 	if r.IsEmpty() {
 		return nil
 	}
+	if depth > maxValueDepth {
+		panic(malformedValue(fmt.Sprintf("values nested deeper than %d", maxValueDepth)))
+	}
 
 	switch paramType {
 	case 0, TypeNull:
@@ -119,7 +182,7 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 		return val
 
 	case TypeString:
-		val, err := r.ReadString()
+		val, err := readString(r)
 		if err != nil {
 			return ""
 		}
@@ -146,9 +209,23 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			return nil
 		}
 
+		checkLength(r, int(length), 1)
+
+		// Custom type arrays write the custom type code once, not per element
+		var customCode byte
+		if elemType == TypeCustom {
+			if customCode, err = r.ReadByte(); err != nil {
+				return nil
+			}
+		}
+
 		arr := make([]interface{}, length)
 		for i := 0; i < int(length) && !r.IsEmpty(); i++ {
-			arr[i] = readValue(r, elemType)
+			if elemType == TypeCustom {
+				arr[i] = readCustom(r, customCode)
+			} else {
+				arr[i] = readNestedValue(r, elemType, depth+1)
+			}
 		}
 		return arr
 
@@ -158,6 +235,8 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			return nil
 		}
 
+		checkLength(r, int(length), 4)
+
 		arr := make([]int32, length)
 		for i := 0; i < int(length); i++ {
 			val, err := r.ReadInt32()
@@ -174,9 +253,15 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			return nil
 		}
 
+		minSize := 2
+		if r.compactStrings {
+			minSize = 1
+		}
+		checkLength(r, int(length), minSize)
+
 		arr := make([]string, length)
 		for i := 0; i < int(length) && !r.IsEmpty(); i++ {
-			str := readValue(r, TypeString)
+			str := readNestedValue(r, TypeString, depth+1)
 			if s, ok := str.(string); ok {
 				arr[i] = s
 			}
@@ -197,6 +282,8 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			return nil
 		}
 
+		checkLength(r, int(length), 1)
+
 		dict := make(map[interface{}]interface{})
 		for i := 0; i < int(length) && !r.IsEmpty(); i++ {
 			// Read key
@@ -207,9 +294,13 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 				if err != nil {
 					break
 				}
-				key = readValue(r, actualKeyType)
+				key = readNestedValue(r, actualKeyType, depth+1)
 			} else {
-				key = readValue(r, keyType)
+				key = readNestedValue(r, keyType, depth+1)
+			}
+			// Arrays, dictionaries and custom values can't be map keys
+			if key != nil && !reflect.TypeOf(key).Comparable() {
+				panic(malformedValue(fmt.Sprintf("dictionary key of type %T", key)))
 			}
 
 			// Read value
@@ -220,9 +311,9 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 				if err != nil {
 					break
 				}
-				val = readValue(r, actualValueType)
+				val = readNestedValue(r, actualValueType, depth+1)
 			} else {
-				val = readValue(r, valueType)
+				val = readNestedValue(r, valueType, depth+1)
 			}
 
 			dict[key] = val
@@ -235,6 +326,8 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			return nil
 		}
 
+		checkLength(r, int(length), 1)
+
 		arr := make([]interface{}, length)
 		for i := 0; i < int(length) && !r.IsEmpty(); i++ {
 			// Each element has its own type
@@ -242,12 +335,44 @@ func readValue(r *BufferReader, paramType byte) interface{} {
 			if err != nil {
 				break
 			}
-			arr[i] = readValue(r, elemType)
+			arr[i] = readNestedValue(r, elemType, depth+1)
 		}
 		return arr
 
+	case TypeCustom:
+		code, err := r.ReadByte()
+		if err != nil {
+			return nil
+		}
+		return readCustom(r, code)
+
 	default:
 		// Unknown type, skip
 		return nil
 	}
 }
+
+// readCustom reads the length-prefixed payload of a custom type value.
+// Known types (see CustomTypeVector2...) are returned as []float32, others as raw bytes.
+func readCustom(r *BufferReader, code byte) interface{} {
+	size, err := r.ReadUint16()
+	if err != nil {
+		return nil
+	}
+	payload, err := r.ReadBytes(int(size))
+	if err != nil {
+		return nil
+	}
+
+	count, known := customTypeFloats[code]
+	if !known || len(payload) != count*4 {
+		return payload
+	}
+
+	values := make([]float32, count)
+	pr := NewBufferReader(payload)
+	for i := range values {
+		values[i], _ = pr.ReadFloat32()
+	}
+	return values
+}
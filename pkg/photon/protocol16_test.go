@@ -423,7 +423,7 @@ func TestDecodeParameterTable(t *testing.T) {
 	}
 
 	r := newTestReader(data)
-	params := decodeParameterTable(r)
+	params, _ := decodeParameterTable(r)
 
 	if len(params) != 2 {
 		t.Fatalf("expected 2 params, got %d", len(params))
@@ -443,7 +443,7 @@ func TestDecodeParameterTableEmpty(t *testing.T) {
 	data := []byte{0x00, 0x00} // Count: 0
 
 	r := newTestReader(data)
-	params := decodeParameterTable(r)
+	params, _ := decodeParameterTable(r)
 
 	if len(params) != 0 {
 		t.Errorf("expected 0 params, got %d", len(params))
@@ -454,14 +454,14 @@ func TestDecodeParameterTableEmpty(t *testing.T) {
 func TestDecodeParameterTableInsufficientData(t *testing.T) {
 	// Not enough data for the count field
 	r := newTestReader([]byte{0x00})
-	params := decodeParameterTable(r)
+	params, _ := decodeParameterTable(r)
 	if len(params) != 0 {
 		t.Errorf("expected empty map for insufficient data, got %d params", len(params))
 	}
 
 	// Empty buffer
 	r = newTestReader([]byte{})
-	params = decodeParameterTable(r)
+	params, _ = decodeParameterTable(r)
 	if len(params) != 0 {
 		t.Errorf("expected empty map for empty buffer, got %d params", len(params))
 	}
@@ -477,7 +477,7 @@ func TestDecodeParameterTableTruncatedEntry(t *testing.T) {
 	}
 
 	r := newTestReader(data)
-	params := decodeParameterTable(r)
+	params, _ := decodeParameterTable(r)
 
 	// Should have parsed at least the first entry
 	if params[1] != byte(0x42) {
@@ -496,6 +496,7 @@ func TestTypeConstants(t *testing.T) {
 		{"TypeDictionary", TypeDictionary, 'D'},
 		{"TypeStringArray", TypeStringArray, 'a'},
 		{"TypeByte", TypeByte, 'b'},
+		{"TypeCustom", TypeCustom, 'c'},
 		{"TypeDouble", TypeDouble, 'd'},
 		{"TypeEventData", TypeEventData, 'e'},
 		{"TypeFloat", TypeFloat, 'f'},
@@ -565,7 +566,7 @@ func TestDecodeParameterTableWithAllTypes(t *testing.T) {
 	}
 
 	r := newTestReader(data)
-	params := decodeParameterTable(r)
+	params, _ := decodeParameterTable(r)
 
 	if len(params) != 4 {
 		t.Fatalf("expected 4 params, got %d", len(params))
@@ -587,3 +588,160 @@ func TestDecodeParameterTableWithAllTypes(t *testing.T) {
 		t.Errorf("params[4]: expected nil, got %v", params[4])
 	}
 }
+
+// customBytes encodes a custom type payload of big-endian float32s
+func customBytes(values ...float32) []byte {
+	data := binary.BigEndian.AppendUint16(nil, uint16(len(values)*4))
+	for _, v := range values {
+		data = binary.BigEndian.AppendUint32(data, math.Float32bits(v))
+	}
+	return data
+}
+
+// TestReadValueCustomVectors tests decoding of known custom types into float32 slices
+func TestReadValueCustomVectors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     byte
+		expected []float32
+	}{
+		{"Vector2", CustomTypeVector2, []float32{1.5, -2}},
+		{"Vector3", CustomTypeVector3, []float32{10, 20.25, -30}},
+		{"Quaternion", CustomTypeQuaternion, []float32{0, 0, 0.7071, 0.7071}},
+	}
+
+	for _, tc := range testCases {
+		data := append([]byte{tc.code}, customBytes(tc.expected...)...)
+		result := readValue(newTestReader(data), TypeCustom)
+
+		values, ok := result.([]float32)
+		if !ok {
+			t.Errorf("%s: expected []float32, got %T", tc.name, result)
+			continue
+		}
+		if len(values) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, values)
+			continue
+		}
+		for i := range values {
+			if values[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, values)
+				break
+			}
+		}
+	}
+}
+
+// TestReadValueCustomUnknown tests that unknown custom types are returned as raw bytes
+func TestReadValueCustomUnknown(t *testing.T) {
+	data := []byte{'X', 0x00, 0x03, 0x01, 0x02, 0x03}
+	result := readValue(newTestReader(data), TypeCustom)
+
+	raw, ok := result.([]byte)
+	if !ok || len(raw) != 3 || raw[0] != 0x01 || raw[2] != 0x03 {
+		t.Errorf("expected raw bytes [1 2 3], got %v (%T)", result, result)
+	}
+
+	// A known code with an unexpected size is also returned raw
+	data = []byte{CustomTypeVector2, 0x00, 0x02, 0x01, 0x02}
+	if _, ok := readValue(newTestReader(data), TypeCustom).([]byte); !ok {
+		t.Error("expected raw bytes for a Vector2 with the wrong size")
+	}
+}
+
+// TestDecodeParameterTableCustomMidTable tests that parameters after a custom value are read correctly
+func TestDecodeParameterTableCustomMidTable(t *testing.T) {
+	data := []byte{0x00, 0x03} // 3 parameters
+	data = append(data, 0x00, TypeInteger, 0x00, 0x00, 0x00, 0x2A)
+	data = append(data, 0x01, TypeCustom, CustomTypeVector2)
+	data = append(data, customBytes(3, 4)...)
+	data = append(data, 0x02, TypeShort, 0x00, 0x07)
+
+	params, _ := decodeParameterTable(newTestReader(data))
+
+	if params[0] != int32(42) {
+		t.Errorf("param 0: expected 42, got %v", params[0])
+	}
+	if pos, ok := params[1].([]float32); !ok || len(pos) != 2 || pos[0] != 3 || pos[1] != 4 {
+		t.Errorf("param 1: expected [3 4], got %v", params[1])
+	}
+	if params[2] != int16(7) {
+		t.Errorf("param 2: expected 7, got %v (%T)", params[2], params[2])
+	}
+}
+
+// TestReadValueCustomArray tests typed arrays of custom values (type code written once)
+func TestReadValueCustomArray(t *testing.T) {
+	data := []byte{0x00, 0x02, TypeCustom, CustomTypeVector2}
+	data = append(data, customBytes(1, 2)...)
+	data = append(data, customBytes(3, 4)...)
+
+	arr, ok := readValue(newTestReader(data), TypeArray).([]interface{})
+	if !ok || len(arr) != 2 {
+		t.Fatalf("expected 2 elements, got %v", arr)
+	}
+	if second, ok := arr[1].([]float32); !ok || second[0] != 3 || second[1] != 4 {
+		t.Errorf("element 1: expected [3 4], got %v", arr[1])
+	}
+}
+
+// TestDecodeParameterTableUnhashableKey tests that dictionaries keyed by arrays are rejected
+func TestDecodeParameterTableUnhashableKey(t *testing.T) {
+	data := []byte{
+		0x00, 0x01, // Count: 1
+		0x01, TypeDictionary, // Key 1, dictionary
+		TypeStringArray, TypeByte, 0x00, 0x01, // String array keys, byte values, 1 entry
+		0x00, 0x01, 0x00, 0x01, 'a', 0x05, // Key ["a"], value 5
+	}
+
+	params, err := decodeParameterTable(newTestReader(data))
+	if err == nil || params != nil {
+		t.Errorf("expected error and nil params, got %v, %v", params, err)
+	}
+}
+
+// TestReadValueCompactStrings tests strings and string arrays with 7-bit length prefixes
+func TestReadValueCompactStrings(t *testing.T) {
+	r := newTestReader([]byte{0x02, 'h', 'i'})
+	r.compactStrings = true
+	if val := readValue(r, TypeString); val != "hi" {
+		t.Errorf("expected \"hi\", got %v", val)
+	}
+
+	r = newTestReader([]byte{0x00, 0x02, 0x01, 'a', 0x02, 'b', 'c'})
+	r.compactStrings = true
+	arr, ok := readValue(r, TypeStringArray).([]string)
+	if !ok || len(arr) != 2 || arr[0] != "a" || arr[1] != "bc" {
+		t.Errorf("expected [a bc], got %v", arr)
+	}
+}
+
+// FuzzReadValue tests that arbitrary values either decode or are rejected as malformed,
+// without any other panic, hang or read past the end of the buffer
+func FuzzReadValue(f *testing.F) {
+	f.Add(byte(TypeInteger), []byte{0x00, 0x00, 0x00, 0x2A})
+	f.Add(byte(TypeString), []byte{0x00, 0x02, 'h', 'i'})
+	f.Add(byte(TypeIntegerArray), []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x07})
+	f.Add(byte(TypeArray), []byte{0x00, 0x01, TypeArray, 0x00, 0x01, TypeByte, 0x05})
+	f.Add(byte(TypeDictionary), []byte{TypeByte, TypeString, 0x00, 0x01, 0x01, 0x00, 0x01, 'a'})
+	f.Add(byte(TypeHashtable), []byte{0x00, 0x01, TypeByte, 0x01, TypeNull})
+	f.Add(byte(TypeCustom), append([]byte{CustomTypeVector2}, customBytes(1, 2)...))
+
+	f.Fuzz(func(t *testing.T, paramType byte, data []byte) {
+		r := newTestReader(data)
+		mustReturn(t, func() {
+			defer func() {
+				if rec := recover(); rec != nil {
+					if _, ok := rec.(malformedValue); !ok {
+						panic(rec)
+					}
+				}
+			}()
+			_ = readValue(r, paramType)
+		})
+
+		if r.Remaining() < 0 {
+			t.Errorf("read past the end of the buffer: remaining %d", r.Remaining())
+		}
+	})
+}
@@ -3,6 +3,7 @@ package photon
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -11,23 +12,29 @@ import (
 // All counters are thread-safe and can be accessed concurrently.
 type Stats struct {
 	// Packet counters
-	PacketsReceived  uint64 // Total UDP packets received
-	PacketsProcessed uint64 // Packets successfully processed
-	PacketsEncrypted uint64 // Encrypted packets (skipped)
-	PacketsWithCRC   uint64 // Packets with CRC enabled
-	PacketsMalformed uint64 // Malformed/corrupted packets
-	BytesReceived    uint64 // Total bytes received
+	PacketsReceived       uint64 // Total UDP packets received
+	PacketsProcessed      uint64 // Packets successfully processed
+	PacketsEncrypted      uint64 // Encrypted packets (skipped)
+	PacketsWithCRC        uint64 // Packets with CRC enabled
+	PacketsCRCFailed      uint64 // Packets dropped because their CRC didn't match
+	PacketsMalformed      uint64 // Malformed/corrupted packets
+	PacketsStrictRejected uint64 // Packets rejected by strict mode validation
+	PacketsDeduplicated   uint64 // Packets dropped as duplicates captured on another interface
+	BytesReceived         uint64 // Total bytes received
 
 	// Fragment counters
-	FragmentsReceived  uint64 // Individual fragments received
-	FragmentsCompleted uint64 // Fragmented packets successfully reassembled
-	FragmentsExpired   uint64 // Fragments expired by TTL cleanup
+	FragmentsReceived   uint64 // Individual fragments received
+	FragmentsUnreliable uint64 // Fragments received by unreliable fragment commands
+	FragmentsCompleted  uint64 // Fragmented packets successfully reassembled
+	FragmentsExpired    uint64 // Fragments expired by TTL cleanup
 
 	// Message counters
 	EventsDecoded    uint64 // Game events decoded
 	RequestsDecoded  uint64 // Operation requests decoded
 	ResponsesDecoded uint64 // Operation responses decoded
 	EventsDropped    uint64 // Events dropped due to full channels
+	EventLogDropped  uint64 // Events not written because the event log (or SQLite store) writer fell behind
+	EventsThrottled  uint64 // Events dropped by a per-type rate limit (see AlbionHandler.SetEventRateLimit)
 
 	// Buffer Metrics
 	// BufferPeakDisplay is the peak buffer usage from the last snapshot interval.
@@ -37,6 +44,13 @@ type Stats struct {
 
 	bufferPeakInternal int64 // Internal accumulator for peak usage
 
+	// ParsePacket processing times, recorded when profiling (see Parser.SetProfiling)
+	processing latencyHistogram
+
+	// Events decoded per game event code (see IncrEventCode)
+	eventCodes   map[int32]uint64
+	eventCodesMu sync.Mutex
+
 	// Internal state
 	StartTime      time.Time
 	LastPacketTime time.Time
@@ -102,16 +116,36 @@ func (s *Stats) IncrPacketsWithCRC() {
 	atomic.AddUint64(&s.PacketsWithCRC, 1)
 }
 
+// IncrPacketsCRCFailed increments the CRC failures counter.
+func (s *Stats) IncrPacketsCRCFailed() {
+	atomic.AddUint64(&s.PacketsCRCFailed, 1)
+}
+
 // IncrPacketsMalformed increments the malformed packets counter.
 func (s *Stats) IncrPacketsMalformed() {
 	atomic.AddUint64(&s.PacketsMalformed, 1)
 }
 
+// IncrPacketsStrictRejected increments the strict mode rejections counter.
+func (s *Stats) IncrPacketsStrictRejected() {
+	atomic.AddUint64(&s.PacketsStrictRejected, 1)
+}
+
+// IncrPacketsDeduplicated increments the deduplicated packets counter.
+func (s *Stats) IncrPacketsDeduplicated() {
+	atomic.AddUint64(&s.PacketsDeduplicated, 1)
+}
+
 // IncrFragmentsReceived increments the fragments received counter.
 func (s *Stats) IncrFragmentsReceived() {
 	atomic.AddUint64(&s.FragmentsReceived, 1)
 }
 
+// IncrFragmentsUnreliable increments the unreliable fragments counter.
+func (s *Stats) IncrFragmentsUnreliable() {
+	atomic.AddUint64(&s.FragmentsUnreliable, 1)
+}
+
 // IncrFragmentsCompleted increments the fragments completed counter.
 func (s *Stats) IncrFragmentsCompleted() {
 	atomic.AddUint64(&s.FragmentsCompleted, 1)
@@ -142,6 +176,31 @@ func (s *Stats) IncrEventsDropped() {
 	atomic.AddUint64(&s.EventsDropped, 1)
 }
 
+// IncrEventLogDropped increments the event log dropped counter.
+func (s *Stats) IncrEventLogDropped() {
+	atomic.AddUint64(&s.EventLogDropped, 1)
+}
+
+// IncrEventsThrottled increments the events throttled counter.
+func (s *Stats) IncrEventsThrottled() {
+	atomic.AddUint64(&s.EventsThrottled, 1)
+}
+
+// RecordProcessingTime records how long a packet took to process.
+func (s *Stats) RecordProcessingTime(d time.Duration) {
+	s.processing.record(d)
+}
+
+// IncrEventCode increments the counter of events decoded with the given game event code.
+func (s *Stats) IncrEventCode(code int32) {
+	s.eventCodesMu.Lock()
+	if s.eventCodes == nil {
+		s.eventCodes = make(map[int32]uint64)
+	}
+	s.eventCodes[code]++
+	s.eventCodesMu.Unlock()
+}
+
 // AddBytesReceived adds n bytes to the bytes received counter.
 func (s *Stats) AddBytesReceived(n uint64) {
 	atomic.AddUint64(&s.BytesReceived, n)
@@ -176,11 +235,32 @@ func (s *Stats) GetPacketsMalformed() uint64 {
 	return atomic.LoadUint64(&s.PacketsMalformed)
 }
 
+// GetPacketsCRCFailed returns the CRC failures count.
+func (s *Stats) GetPacketsCRCFailed() uint64 {
+	return atomic.LoadUint64(&s.PacketsCRCFailed)
+}
+
+// GetPacketsStrictRejected returns the strict mode rejections count.
+func (s *Stats) GetPacketsStrictRejected() uint64 {
+	return atomic.LoadUint64(&s.PacketsStrictRejected)
+}
+
+// GetPacketsDeduplicated returns the deduplicated packets count.
+func (s *Stats) GetPacketsDeduplicated() uint64 {
+	return atomic.LoadUint64(&s.PacketsDeduplicated)
+}
+
 // GetFragmentsReceived returns the fragments received count.
 func (s *Stats) GetFragmentsReceived() uint64 {
 	return atomic.LoadUint64(&s.FragmentsReceived)
 }
 
+// GetFragmentsUnreliable returns the unreliable fragments count.
+// Fragments from reliable commands are GetFragmentsReceived minus this count.
+func (s *Stats) GetFragmentsUnreliable() uint64 {
+	return atomic.LoadUint64(&s.FragmentsUnreliable)
+}
+
 // GetFragmentsCompleted returns the fragments completed count.
 func (s *Stats) GetFragmentsCompleted() uint64 {
 	return atomic.LoadUint64(&s.FragmentsCompleted)
@@ -211,11 +291,54 @@ func (s *Stats) GetEventsDropped() uint64 {
 	return atomic.LoadUint64(&s.EventsDropped)
 }
 
+// GetEventLogDropped returns the count of events dropped by the event log.
+func (s *Stats) GetEventLogDropped() uint64 {
+	return atomic.LoadUint64(&s.EventLogDropped)
+}
+
+// GetEventsThrottled returns the count of events dropped by a rate limit.
+func (s *Stats) GetEventsThrottled() uint64 {
+	return atomic.LoadUint64(&s.EventsThrottled)
+}
+
+// ProcessingCount returns the number of packets with a recorded processing time
+// (0 unless profiling is enabled).
+func (s *Stats) ProcessingCount() uint64 {
+	return s.processing.count()
+}
+
+// ProcessingP50 returns the median packet processing time.
+func (s *Stats) ProcessingP50() time.Duration {
+	return s.processing.quantile(0.50)
+}
+
+// ProcessingP99 returns the 99th percentile packet processing time.
+func (s *Stats) ProcessingP99() time.Duration {
+	return s.processing.quantile(0.99)
+}
+
+// ProcessingMax returns the longest packet processing time.
+func (s *Stats) ProcessingMax() time.Duration {
+	return s.processing.maximum()
+}
+
 // GetBytesReceived returns the bytes received count.
 func (s *Stats) GetBytesReceived() uint64 {
 	return atomic.LoadUint64(&s.BytesReceived)
 }
 
+// EventCodeCounts returns a copy of the number of events decoded per game event code.
+func (s *Stats) EventCodeCounts() map[int32]uint64 {
+	s.eventCodesMu.Lock()
+	defer s.eventCodesMu.Unlock()
+
+	counts := make(map[int32]uint64, len(s.eventCodes))
+	for code, count := range s.eventCodes {
+		counts[code] = count
+	}
+	return counts
+}
+
 // ============================================
 // Calculation methods
 // ============================================
@@ -275,16 +398,28 @@ func (s *Stats) Reset() {
 	atomic.StoreUint64(&s.PacketsProcessed, 0)
 	atomic.StoreUint64(&s.PacketsEncrypted, 0)
 	atomic.StoreUint64(&s.PacketsWithCRC, 0)
+	atomic.StoreUint64(&s.PacketsCRCFailed, 0)
 	atomic.StoreUint64(&s.PacketsMalformed, 0)
+	atomic.StoreUint64(&s.PacketsStrictRejected, 0)
+	atomic.StoreUint64(&s.PacketsDeduplicated, 0)
 	atomic.StoreUint64(&s.FragmentsReceived, 0)
+	atomic.StoreUint64(&s.FragmentsUnreliable, 0)
 	atomic.StoreUint64(&s.FragmentsCompleted, 0)
 	atomic.StoreUint64(&s.FragmentsExpired, 0)
 	atomic.StoreUint64(&s.EventsDecoded, 0)
 	atomic.StoreUint64(&s.RequestsDecoded, 0)
 	atomic.StoreUint64(&s.ResponsesDecoded, 0)
 	atomic.StoreUint64(&s.EventsDropped, 0)
+	atomic.StoreUint64(&s.EventLogDropped, 0)
+	atomic.StoreUint64(&s.EventsThrottled, 0)
 	atomic.StoreUint64(&s.BytesReceived, 0)
 
+	s.eventCodesMu.Lock()
+	s.eventCodes = nil
+	s.eventCodesMu.Unlock()
+
+	s.processing.reset()
+
 	// Reset buffer metrics
 	atomic.StoreInt64(&s.BufferPeakDisplay, 0)
 	atomic.StoreInt64(&s.bufferPeakInternal, 0)
@@ -245,6 +245,135 @@ func TestEventsDropped(t *testing.T) {
 	}
 }
 
+// TestEventLogDropped tests the event log dropped counter
+func TestEventLogDropped(t *testing.T) {
+	stats := NewStats()
+
+	stats.IncrEventLogDropped()
+	stats.IncrEventLogDropped()
+	if stats.GetEventLogDropped() != 2 {
+		t.Errorf("Event log dropped should be 2, got %d", stats.GetEventLogDropped())
+	}
+
+	stats.Reset()
+	if stats.GetEventLogDropped() != 0 {
+		t.Error("Event log dropped should be 0 after reset")
+	}
+}
+
+// TestEventsThrottled tests that throttled events are counted apart from dropped events
+func TestEventsThrottled(t *testing.T) {
+	stats := NewStats()
+
+	stats.IncrEventsThrottled()
+	if stats.GetEventsThrottled() != 1 {
+		t.Errorf("Events throttled should be 1, got %d", stats.GetEventsThrottled())
+	}
+	if stats.GetEventsDropped() != 0 {
+		t.Errorf("Events dropped should be 0, got %d", stats.GetEventsDropped())
+	}
+
+	stats.Reset()
+	if stats.GetEventsThrottled() != 0 {
+		t.Error("Events throttled should be 0 after reset")
+	}
+}
+
+// TestProcessingTimes tests the processing time percentiles and their reset
+func TestProcessingTimes(t *testing.T) {
+	stats := NewStats()
+	if stats.ProcessingCount() != 0 || stats.ProcessingP99() != 0 {
+		t.Error("Processing times should be empty initially")
+	}
+
+	stats.RecordProcessingTime(10 * time.Microsecond)
+	stats.RecordProcessingTime(20 * time.Microsecond)
+	stats.RecordProcessingTime(5 * time.Millisecond)
+	if stats.ProcessingCount() != 3 {
+		t.Errorf("Processing count should be 3, got %d", stats.ProcessingCount())
+	}
+	if p50 := stats.ProcessingP50(); p50 < 20*time.Microsecond || p50 > 23*time.Microsecond {
+		t.Errorf("Processing p50 should be about 20µs, got %v", p50)
+	}
+	if stats.ProcessingMax() != 5*time.Millisecond || stats.ProcessingP99() != 5*time.Millisecond {
+		t.Errorf("Processing p99 and max should be 5ms, got %v and %v", stats.ProcessingP99(), stats.ProcessingMax())
+	}
+
+	stats.Reset()
+	if stats.ProcessingCount() != 0 || stats.ProcessingMax() != 0 {
+		t.Error("Processing times should be empty after reset")
+	}
+}
+
+// TestPacketsDeduplicated tests the deduplicated packets counter
+func TestPacketsDeduplicated(t *testing.T) {
+	stats := NewStats()
+
+	stats.IncrPacketsDeduplicated()
+	stats.IncrPacketsDeduplicated()
+	if stats.GetPacketsDeduplicated() != 2 {
+		t.Errorf("Packets deduplicated should be 2, got %d", stats.GetPacketsDeduplicated())
+	}
+
+	stats.Reset()
+	if stats.GetPacketsDeduplicated() != 0 {
+		t.Error("Packets deduplicated should be 0 after reset")
+	}
+}
+
+// TestEventCodeCounts tests per event code counters, the returned copy and reset
+func TestEventCodeCounts(t *testing.T) {
+	stats := NewStats()
+	if len(stats.EventCodeCounts()) != 0 {
+		t.Errorf("Expected no event codes, got %v", stats.EventCodeCounts())
+	}
+
+	stats.IncrEventCode(1)
+	stats.IncrEventCode(82)
+	stats.IncrEventCode(82)
+
+	counts := stats.EventCodeCounts()
+	if counts[1] != 1 || counts[82] != 2 || len(counts) != 2 {
+		t.Errorf("Expected {1:1 82:2}, got %v", counts)
+	}
+
+	counts[82] = 100
+	if stats.EventCodeCounts()[82] != 2 {
+		t.Error("Modifying the returned map should not change the counters")
+	}
+
+	stats.Reset()
+	if len(stats.EventCodeCounts()) != 0 {
+		t.Errorf("Expected no event codes after reset, got %v", stats.EventCodeCounts())
+	}
+}
+
+func TestEventCodeCountsConcurrent(t *testing.T) {
+	stats := NewStats()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(code int32) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				stats.IncrEventCode(code % 3)
+				_ = stats.EventCodeCounts()
+			}
+		}(int32(i))
+	}
+
+	wg.Wait()
+
+	var total uint64
+	for _, count := range stats.EventCodeCounts() {
+		total += count
+	}
+	if total != 1000 {
+		t.Errorf("Expected 1000 events, got %d", total)
+	}
+}
+
 func TestEventsDroppedConcurrent(t *testing.T) {
 	stats := NewStats()
 	var wg sync.WaitGroup
@@ -0,0 +1,214 @@
+package photon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TraceNextPacket arms a one-shot trace: the next packet passed to ParsePacket
+// is formatted with FormatPacketTrace and handed to fn (from the capture goroutine).
+// Calling it again before a packet arrives replaces the previous callback.
+func (p *Parser) TraceNextPacket(fn func(trace string)) {
+	p.traceNext.Store(&fn)
+}
+
+// traceIfArmed delivers the trace of payload if a trace was requested
+func (p *Parser) traceIfArmed(payload []byte) {
+	if fn := p.traceNext.Swap(nil); fn != nil {
+		(*fn)(FormatPacketTrace(payload))
+	}
+}
+
+// FormatPacketTrace pretty-prints the command-by-command structure of a Photon packet:
+// header flags, each command's type, length and sequence number and, for data commands,
+// the message type and decoded parameter table with value types.
+// Malformed packets are traced as far as they can be read.
+func FormatPacketTrace(payload []byte) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Packet: %d bytes\n", len(payload))
+	if len(payload) < PhotonHeaderLength {
+		b.WriteString("  truncated header\n")
+		return b.String()
+	}
+
+	flags := payload[2]
+	commandCount := int(payload[3])
+	fmt.Fprintf(&b, "  Header: peer=%d flags=0x%02X (%s) commands=%d\n",
+		binary.BigEndian.Uint16(payload[0:2]), flags, traceFlagsName(flags), commandCount)
+
+	if flags == 1 {
+		return b.String()
+	}
+
+	offset := PhotonHeaderLength
+	if flags == 0xCC {
+		offset += crcFieldLength
+	}
+
+	for i := 0; i < commandCount; i++ {
+		if len(payload)-offset < CommandHeaderLength {
+			fmt.Fprintf(&b, "  Command %d: truncated header at offset %d\n", i, offset)
+			return b.String()
+		}
+
+		commandType := payload[offset]
+		commandLength := int(binary.BigEndian.Uint32(payload[offset+4 : offset+8]))
+		sequenceNumber := int32(binary.BigEndian.Uint32(payload[offset+8 : offset+12]))
+
+		fmt.Fprintf(&b, "  Command %d: type=%d (%s) channel=%d length=%d seq=%d\n",
+			i, commandType, traceCommandName(commandType), payload[offset+1], commandLength, sequenceNumber)
+
+		if commandLength < CommandHeaderLength || commandLength > len(payload)-offset {
+			fmt.Fprintf(&b, "    invalid length (remaining %d)\n", len(payload)-offset)
+			return b.String()
+		}
+
+		data := payload[offset+CommandHeaderLength : offset+commandLength]
+		switch commandType {
+		case CommandTypeSendUnreliable:
+			if len(data) >= 4 {
+				fmt.Fprintf(&b, "    unreliable seq=%d\n", int32(binary.BigEndian.Uint32(data[:4])))
+				traceMessage(&b, data[4:])
+			}
+		case CommandTypeSendReliable:
+			traceMessage(&b, data)
+		case CommandTypeSendFragment, CommandTypeSendUnreliableFragment:
+			if commandType == CommandTypeSendUnreliableFragment && len(data) >= 4 {
+				fmt.Fprintf(&b, "    unreliable seq=%d\n", int32(binary.BigEndian.Uint32(data[:4])))
+				data = data[4:]
+			}
+			if len(data) >= FragmentHeaderLength {
+				fmt.Fprintf(&b, "    fragment start=%d count=%d number=%d total=%d offset=%d size=%d\n",
+					int32(binary.BigEndian.Uint32(data[0:4])),
+					int32(binary.BigEndian.Uint32(data[4:8])),
+					int32(binary.BigEndian.Uint32(data[8:12])),
+					int32(binary.BigEndian.Uint32(data[12:16])),
+					binary.BigEndian.Uint32(data[16:20]),
+					len(data)-FragmentHeaderLength)
+			}
+		}
+
+		offset += commandLength
+	}
+
+	if offset < len(payload) {
+		fmt.Fprintf(&b, "  %d trailing bytes\n", len(payload)-offset)
+	}
+	return b.String()
+}
+
+// traceMessage formats a message (signal byte, message type and body)
+func traceMessage(b *strings.Builder, data []byte) {
+	if len(data) < 2 {
+		fmt.Fprintf(b, "    message: too short (%d bytes)\n", len(data))
+		return
+	}
+
+	messageType := data[1]
+	fmt.Fprintf(b, "    message: signal=%d type=%d (%s)\n", data[0], messageType, traceMessageName(messageType))
+	if messageType > 128 {
+		return
+	}
+
+	r := NewBufferReader(data[2:])
+	switch messageType {
+	case MessageTypeOperationRequest, MessageTypeInternalRequest:
+		code, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(b, "    operation=%d\n", code)
+
+	case MessageTypeOperationResponse, MessageTypeInternalResponse:
+		if r.Remaining() < 4 {
+			return
+		}
+		code, _ := r.ReadByte()
+		returnCode, _ := r.ReadInt16()
+		debugType, _ := r.ReadByte()
+		debugMessage := ""
+		if debugType != 0 && debugType != TypeNull {
+			debugMessage, _ = r.ReadString()
+		}
+		fmt.Fprintf(b, "    operation=%d return=%d debug=%q\n", code, returnCode, debugMessage)
+
+	case MessageTypeEventData:
+		code, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(b, "    event=%d\n", code)
+
+	default:
+		return
+	}
+
+	params, err := decodeParameterTable(r)
+	if err != nil {
+		fmt.Fprintf(b, "    %v\n", err)
+		return
+	}
+	keys := make([]byte, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	fmt.Fprintf(b, "    params: %d\n", len(params))
+	for _, key := range keys {
+		fmt.Fprintf(b, "      [%d] %T = %v\n", key, params[key], params[key])
+	}
+}
+
+// traceFlagsName names the packet header flags
+func traceFlagsName(flags byte) string {
+	switch flags {
+	case 0:
+		return "plain"
+	case 1:
+		return "encrypted"
+	case 0xCC:
+		return "crc"
+	}
+	return "unknown"
+}
+
+// traceCommandName names a command type
+func traceCommandName(commandType byte) string {
+	switch commandType {
+	case CommandTypeDisconnect:
+		return "disconnect"
+	case CommandTypeSendReliable:
+		return "reliable"
+	case CommandTypeSendUnreliable:
+		return "unreliable"
+	case CommandTypeSendFragment:
+		return "fragment"
+	case CommandTypeSendUnreliableFragment:
+		return "unreliable fragment"
+	}
+	return "other"
+}
+
+// traceMessageName names a message type
+func traceMessageName(messageType byte) string {
+	switch messageType {
+	case MessageTypeOperationRequest:
+		return "request"
+	case MessageTypeOperationResponse:
+		return "response"
+	case MessageTypeEventData:
+		return "event"
+	case MessageTypeInternalRequest:
+		return "internal request"
+	case MessageTypeInternalResponse:
+		return "internal response"
+	}
+	if messageType > 128 {
+		return "encrypted"
+	}
+	return "unknown"
+}
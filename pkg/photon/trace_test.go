@@ -0,0 +1,89 @@
+package photon
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFormatPacketTrace tests the trace of a synthetic multi-command packet
+func TestFormatPacketTrace(t *testing.T) {
+	// Event 1 with params {0: int32 7, 1: "abc"}
+	event := []byte{243, MessageTypeEventData, 1, 0, 2,
+		0, TypeInteger, 0, 0, 0, 7,
+		1, TypeString, 0, 3, 'a', 'b', 'c',
+	}
+	// Response to operation 2, return code 0, null debug message, no params
+	response := []byte{243, MessageTypeOperationResponse, 2, 0, 0, TypeNull, 0, 0}
+	fragment := make([]byte, FragmentHeaderLength+3)
+
+	packet := buildPacket(0xCC,
+		buildCommand(CommandTypeSendReliable, event),
+		buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 9}, response...)),
+		buildCommand(CommandTypeSendFragment, fragment),
+	)
+
+	trace := FormatPacketTrace(packet)
+
+	expected := []string{
+		"flags=0xCC (crc) commands=3",
+		"Command 0: type=6 (reliable)",
+		"type=4 (event)",
+		"event=1",
+		"params: 2",
+		"[0] int32 = 7",
+		"[1] string = abc",
+		"Command 1: type=7 (unreliable)",
+		"unreliable seq=9",
+		"operation=2 return=0 debug=\"\"",
+		"params: 0",
+		"Command 2: type=8 (fragment)",
+		"size=3",
+	}
+	for _, want := range expected {
+		if !strings.Contains(trace, want) {
+			t.Errorf("expected trace to contain %q, got:\n%s", want, trace)
+		}
+	}
+	if strings.Index(trace, "[0] int32") > strings.Index(trace, "[1] string") {
+		t.Errorf("expected params sorted by key, got:\n%s", trace)
+	}
+}
+
+// TestFormatPacketTraceMalformed tests that malformed packets are traced as far as possible
+func TestFormatPacketTraceMalformed(t *testing.T) {
+	if trace := FormatPacketTrace([]byte{1, 2, 3}); !strings.Contains(trace, "truncated header") {
+		t.Errorf("expected truncated header, got:\n%s", trace)
+	}
+
+	packet := buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage))
+	packet[3] = 2
+	if trace := FormatPacketTrace(packet); !strings.Contains(trace, "Command 1: truncated header") {
+		t.Errorf("expected truncated second command, got:\n%s", trace)
+	}
+
+	if trace := FormatPacketTrace(buildPacket(1)); !strings.Contains(trace, "(encrypted)") {
+		t.Errorf("expected encrypted flags, got:\n%s", trace)
+	}
+}
+
+// TestTraceNextPacket tests that only the next packet is traced
+func TestTraceNextPacket(t *testing.T) {
+	parser := NewParser(&mockHandler{})
+	defer parser.Close()
+
+	var traces []string
+	parser.TraceNextPacket(func(trace string) {
+		traces = append(traces, trace)
+	})
+
+	packet := buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage))
+	_ = parser.ParsePacket(packet)
+	_ = parser.ParsePacket(packet)
+
+	if len(traces) != 1 {
+		t.Fatalf("expected 1 trace, got %d", len(traces))
+	}
+	if !strings.Contains(traces[0], "event=1") {
+		t.Errorf("expected event in trace, got:\n%s", traces[0])
+	}
+}
@@ -0,0 +1,159 @@
+package photon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// crcFieldLength is the size of the CRC field that follows the header when CRC is enabled
+const crcFieldLength = 4
+
+// validatePacket performs a full structural validation of a Photon packet.
+// It checks the CRC (when enabled), every command header and length, and that the
+// number of commands matches the header with no trailing bytes left over.
+func validatePacket(payload []byte) error {
+	if len(payload) < PhotonHeaderLength {
+		return fmt.Errorf("packet too short: %d bytes", len(payload))
+	}
+
+	flags := payload[2]
+	commandCount := int(payload[3])
+
+	// Encrypted packets are skipped by the parser, nothing to validate
+	if flags == 1 {
+		return nil
+	}
+
+	offset := PhotonHeaderLength
+	if flags == 0xCC {
+		if err := validateCRC(payload); err != nil {
+			return err
+		}
+		offset += crcFieldLength
+	}
+
+	for i := 0; i < commandCount; i++ {
+		if len(payload)-offset < CommandHeaderLength {
+			return fmt.Errorf("command %d: truncated header at offset %d", i, offset)
+		}
+
+		commandType := payload[offset]
+		commandLength := int(binary.BigEndian.Uint32(payload[offset+4 : offset+8]))
+
+		if commandLength < CommandHeaderLength {
+			return fmt.Errorf("command %d: invalid length %d", i, commandLength)
+		}
+		if commandLength > len(payload)-offset {
+			return fmt.Errorf("command %d: length %d exceeds packet (remaining %d)", i, commandLength, len(payload)-offset)
+		}
+
+		data := payload[offset+CommandHeaderLength : offset+commandLength]
+
+		switch commandType {
+		case CommandTypeSendUnreliable:
+			if len(data) < 4 {
+				return fmt.Errorf("command %d: unreliable command too short", i)
+			}
+			if err := validateMessage(data[4:]); err != nil {
+				return fmt.Errorf("command %d: %w", i, err)
+			}
+		case CommandTypeSendReliable:
+			if err := validateMessage(data); err != nil {
+				return fmt.Errorf("command %d: %w", i, err)
+			}
+		case CommandTypeSendFragment:
+			if len(data) < FragmentHeaderLength {
+				return fmt.Errorf("command %d: fragment too short", i)
+			}
+			if err := validateFragment(data); err != nil {
+				return fmt.Errorf("command %d: %w", i, err)
+			}
+		case CommandTypeSendUnreliableFragment:
+			if len(data) < 4+FragmentHeaderLength {
+				return fmt.Errorf("command %d: unreliable fragment too short", i)
+			}
+			if err := validateFragment(data[4:]); err != nil {
+				return fmt.Errorf("command %d: %w", i, err)
+			}
+		}
+
+		offset += commandLength
+	}
+
+	if offset != len(payload) {
+		return fmt.Errorf("%d trailing bytes after %d commands", len(payload)-offset, commandCount)
+	}
+
+	return nil
+}
+
+// validateCRC checks the CRC of a packet with CRC enabled.
+// The CRC is computed over the whole packet with the CRC field zeroed.
+func validateCRC(payload []byte) error {
+	if len(payload) < PhotonHeaderLength+crcFieldLength {
+		return fmt.Errorf("packet too short for CRC: %d bytes", len(payload))
+	}
+
+	expected := binary.BigEndian.Uint32(payload[PhotonHeaderLength : PhotonHeaderLength+crcFieldLength])
+
+	buf := make([]byte, len(payload))
+	copy(buf, payload)
+	clear(buf[PhotonHeaderLength : PhotonHeaderLength+crcFieldLength])
+
+	if actual := photonCRC(buf); actual != expected {
+		return fmt.Errorf("CRC mismatch: expected 0x%08X, got 0x%08X", expected, actual)
+	}
+	return nil
+}
+
+// photonCRC computes the Photon CRC32 (IEEE polynomial, no final XOR)
+func photonCRC(data []byte) uint32 {
+	return ^crc32.ChecksumIEEE(data)
+}
+
+// validateMessage checks the signal byte and message type of a reliable command payload
+func validateMessage(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("message too short: %d bytes", len(data))
+	}
+	if data[0] != 243 && data[0] != 253 {
+		return fmt.Errorf("invalid signal byte %d", data[0])
+	}
+
+	// Encrypted messages are skipped by the parser
+	if data[1] > 128 {
+		return nil
+	}
+
+	switch data[1] {
+	case MessageTypeOperationRequest, MessageTypeInternalRequest:
+		if len(data) < 3 {
+			return fmt.Errorf("request too short")
+		}
+	case MessageTypeOperationResponse, MessageTypeInternalResponse:
+		if len(data) < 6 {
+			return fmt.Errorf("response too short")
+		}
+	case MessageTypeEventData:
+		if len(data) < 3 {
+			return fmt.Errorf("event too short")
+		}
+	}
+	return nil
+}
+
+// validateFragment checks that a fragment fits within its declared total length
+func validateFragment(data []byte) error {
+	totalLength := int32(binary.BigEndian.Uint32(data[12:16]))
+	fragmentOffset := int64(binary.BigEndian.Uint32(data[16:20]))
+	fragmentLength := int64(len(data) - FragmentHeaderLength)
+
+	if totalLength <= 0 {
+		return fmt.Errorf("invalid fragment total length %d", totalLength)
+	}
+	if fragmentOffset+fragmentLength > int64(totalLength) {
+		return fmt.Errorf("fragment exceeds total length: offset=%d, length=%d, total=%d", fragmentOffset, fragmentLength, totalLength)
+	}
+	return nil
+}
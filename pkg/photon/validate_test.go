@@ -0,0 +1,186 @@
+package photon
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// buildCommand builds a Photon command with the given type and data
+func buildCommand(commandType byte, data []byte) []byte {
+	cmd := make([]byte, CommandHeaderLength, CommandHeaderLength+len(data))
+	cmd[0] = commandType
+	binary.BigEndian.PutUint32(cmd[4:8], uint32(CommandHeaderLength+len(data)))
+	return append(cmd, data...)
+}
+
+// buildPacket builds a Photon packet with the given flags and commands.
+// When flags is 0xCC, a valid CRC is computed and written.
+func buildPacket(flags byte, commands ...[]byte) []byte {
+	packet := make([]byte, PhotonHeaderLength)
+	packet[2] = flags
+	packet[3] = byte(len(commands))
+	if flags == 0xCC {
+		packet = append(packet, 0, 0, 0, 0)
+	}
+	for _, cmd := range commands {
+		packet = append(packet, cmd...)
+	}
+	if flags == 0xCC {
+		binary.BigEndian.PutUint32(packet[PhotonHeaderLength:], photonCRC(packet))
+	}
+	return packet
+}
+
+// eventMessage is a reliable command payload carrying an event with no parameters
+var eventMessage = []byte{243, MessageTypeEventData, 1, 0, 0}
+
+// TestValidatePacketValid tests that well-formed packets pass validation
+func TestValidatePacketValid(t *testing.T) {
+	packets := map[string][]byte{
+		"reliable":   buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage)),
+		"unreliable": buildPacket(0, buildCommand(CommandTypeSendUnreliable, append([]byte{0, 0, 0, 1}, eventMessage...))),
+		"crc":        buildPacket(0xCC, buildCommand(CommandTypeSendReliable, eventMessage)),
+		"encrypted":  buildPacket(1),
+		"unreliable fragment": buildPacket(0, buildCommand(CommandTypeSendUnreliableFragment,
+			append([]byte{0, 0, 0, 1}, buildFragment(1, 1, 0, eventMessage, 0, len(eventMessage))...))),
+	}
+
+	for name, packet := range packets {
+		if err := validatePacket(packet); err != nil {
+			t.Errorf("%s: expected valid packet, got error: %v", name, err)
+		}
+	}
+}
+
+// TestValidatePacketInvalid tests that malformed packets fail validation
+func TestValidatePacketInvalid(t *testing.T) {
+	valid := buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage))
+
+	trailing := append(append([]byte{}, valid...), 0xFF)
+
+	missingCommand := append([]byte{}, valid...)
+	missingCommand[3] = 2
+
+	badLength := append([]byte{}, valid...)
+	binary.BigEndian.PutUint32(badLength[PhotonHeaderLength+4:], 4)
+
+	badSignal := buildPacket(0, buildCommand(CommandTypeSendReliable, []byte{99, MessageTypeEventData, 1, 0, 0}))
+
+	badCRC := buildPacket(0xCC, buildCommand(CommandTypeSendReliable, eventMessage))
+	badCRC[len(badCRC)-1] ^= 0xFF
+
+	fragment := make([]byte, FragmentHeaderLength+10)
+	binary.BigEndian.PutUint32(fragment[12:16], 5) // total length smaller than fragment
+	badFragment := buildPacket(0, buildCommand(CommandTypeSendFragment, fragment))
+
+	packets := map[string][]byte{
+		"trailing bytes":  trailing,
+		"missing command": missingCommand,
+		"bad length":      badLength,
+		"bad signal":      badSignal,
+		"bad crc":         badCRC,
+		"bad fragment":    badFragment,
+		"too short":       valid[:PhotonHeaderLength-1],
+	}
+
+	for name, packet := range packets {
+		if err := validatePacket(packet); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+}
+
+// TestStrictModeRejectsMalformedPacket tests that a slightly malformed packet is
+// accepted in default mode but rejected in strict mode
+func TestStrictModeRejectsMalformedPacket(t *testing.T) {
+	packet := buildPacket(0, buildCommand(CommandTypeSendReliable, eventMessage))
+	packet = append(packet, 0xFF) // trailing garbage byte
+
+	// Default mode: best-effort parsing
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Errorf("default mode: expected no error, got %v", err)
+	}
+	if handler.events != 1 {
+		t.Errorf("default mode: expected 1 event, got %d", handler.events)
+	}
+
+	// Strict mode: packet is dropped
+	strictHandler := &mockHandler{}
+	strictParser := NewParser(strictHandler)
+	defer strictParser.Close()
+	strictParser.SetStrictMode(true)
+
+	if err := strictParser.ParsePacket(packet); err == nil {
+		t.Error("strict mode: expected error, got nil")
+	}
+	if strictHandler.events != 0 {
+		t.Errorf("strict mode: expected 0 events, got %d", strictHandler.events)
+	}
+	if strictParser.Stats.GetPacketsStrictRejected() != 1 {
+		t.Errorf("strict mode: expected 1 rejection, got %d", strictParser.Stats.GetPacketsStrictRejected())
+	}
+	if strictParser.Stats.GetPacketsProcessed() != 0 {
+		t.Errorf("strict mode: expected 0 processed, got %d", strictParser.Stats.GetPacketsProcessed())
+	}
+}
+
+// TestStrictModeAcceptsValidPacket tests that valid packets are parsed in strict mode
+func TestStrictModeAcceptsValidPacket(t *testing.T) {
+	handler := &mockHandler{}
+	parser := NewParser(handler)
+	defer parser.Close()
+	parser.SetStrictMode(true)
+
+	packet := buildPacket(0xCC, buildCommand(CommandTypeSendReliable, eventMessage))
+
+	if err := parser.ParsePacket(packet); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if handler.events != 1 {
+		t.Errorf("expected 1 event, got %d", handler.events)
+	}
+	if parser.Stats.GetPacketsStrictRejected() != 0 {
+		t.Errorf("expected 0 rejections, got %d", parser.Stats.GetPacketsStrictRejected())
+	}
+}
+
+// TestValidateCRC tests that packets with a bad CRC are dropped only when CRC validation is enabled
+func TestValidateCRC(t *testing.T) {
+	valid := buildPacket(0xCC, buildCommand(CommandTypeSendReliable, eventMessage))
+	corrupted := append([]byte{}, valid...)
+	corrupted[len(corrupted)-1] ^= 0xFF
+
+	tests := []struct {
+		name     string
+		validate bool
+		packet   []byte
+		events   int
+		failures uint64
+	}{
+		{"disabled, corrupted", false, corrupted, 1, 0},
+		{"enabled, valid", true, valid, 1, 0},
+		{"enabled, corrupted", true, corrupted, 0, 1},
+	}
+
+	for _, tt := range tests {
+		handler := &mockHandler{}
+		parser := NewParser(handler)
+		parser.SetValidateCRC(tt.validate)
+
+		err := parser.ParsePacket(tt.packet)
+		if (err != nil) != (tt.failures > 0) {
+			t.Errorf("%s: unexpected error result: %v", tt.name, err)
+		}
+		if handler.events != tt.events {
+			t.Errorf("%s: expected %d events, got %d", tt.name, tt.events, handler.events)
+		}
+		if got := parser.Stats.GetPacketsCRCFailed(); got != tt.failures {
+			t.Errorf("%s: expected %d CRC failures, got %d", tt.name, tt.failures, got)
+		}
+		parser.Close()
+	}
+}
@@ -0,0 +1,217 @@
+// Package spells provides spell ID to name translation using ao-bin-dumps data
+package spells
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SpellDatabase holds the loaded spells data
+type SpellDatabase struct {
+	spells     map[string]SpellInfo // key: uniquename (e.g., "FLAMESHIELD")
+	spellsByID map[int]SpellInfo    // key: numeric index
+	mu         sync.RWMutex
+	loaded     bool
+}
+
+// SpellInfo contains spell information
+type SpellInfo struct {
+	UniqueName string `json:"@uniquename"`
+	Index      int    // Numeric index based on position
+	Category   string // spells.json category (passivespell, activespell, togglespell)
+	Target     string // Target type (e.g., "enemy", "self", "ground"), empty if none
+}
+
+// spellCategories are the spells.json categories loaded, in index order
+var spellCategories = []string{
+	"passivespell",
+	"activespell",
+	"togglespell",
+}
+
+// Global database instance
+var db *SpellDatabase
+var once sync.Once
+
+// GetDatabase returns the global spell database
+func GetDatabase() *SpellDatabase {
+	once.Do(func() {
+		db = &SpellDatabase{
+			spells:     make(map[string]SpellInfo),
+			spellsByID: make(map[int]SpellInfo),
+		}
+	})
+	return db
+}
+
+// LoadFromFile loads spells from a spells.json file
+func (d *SpellDatabase) LoadFromFile(filePath string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read spells file: %w", err)
+	}
+
+	return d.parseSpellsJSON(data)
+}
+
+// LoadFromPath tries to find and load spells.json from common paths
+func (d *SpellDatabase) LoadFromPath(basePath string) error {
+	paths := []string{
+		filepath.Join(basePath, "spells.json"),
+		filepath.Join(basePath, "ao-bin-dumps", "spells.json"),
+		filepath.Join(basePath, "..", "ao-bin-dumps", "spells.json"),
+		"spells.json",
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return d.LoadFromFile(path)
+		}
+	}
+
+	return fmt.Errorf("spells.json not found in any of the expected locations")
+}
+
+// parseSpellsJSON parses the spells.json structure
+func (d *SpellDatabase) parseSpellsJSON(data []byte) error {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	spells, ok := root["spells"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid spells.json structure: missing 'spells' key")
+	}
+
+	spellIndex := 0
+	for _, category := range spellCategories {
+		if categoryData, exists := spells[category]; exists {
+			spellIndex = d.processCategory(categoryData, category, spellIndex)
+		}
+	}
+
+	d.loaded = true
+	return nil
+}
+
+// processCategory processes spells from a specific category.
+// A category with a single spell is an object instead of an array.
+func (d *SpellDatabase) processCategory(data interface{}, category string, startIndex int) int {
+	index := startIndex
+
+	switch spells := data.(type) {
+	case []interface{}:
+		for _, spell := range spells {
+			if spellMap, ok := spell.(map[string]interface{}); ok {
+				if info := extractSpellInfo(spellMap, category, index); info != nil {
+					d.spells[info.UniqueName] = *info
+					d.spellsByID[index] = *info
+					index++
+				}
+			}
+		}
+	case map[string]interface{}:
+		if info := extractSpellInfo(spells, category, index); info != nil {
+			d.spells[info.UniqueName] = *info
+			d.spellsByID[index] = *info
+			index++
+		}
+	}
+
+	return index
+}
+
+// extractSpellInfo extracts spell info from a spell entry, nil if it has no unique name
+func extractSpellInfo(spellMap map[string]interface{}, category string, index int) *SpellInfo {
+	uniqueName, ok := spellMap["@uniquename"].(string)
+	if !ok || uniqueName == "" {
+		return nil
+	}
+
+	info := &SpellInfo{
+		UniqueName: uniqueName,
+		Index:      index,
+		Category:   category,
+	}
+	if target, ok := spellMap["@target"].(string); ok {
+		info.Target = target
+	}
+
+	return info
+}
+
+// GetByUniqueName returns spell info by unique name
+func (d *SpellDatabase) GetByUniqueName(name string) (SpellInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.spells[name]
+	return info, ok
+}
+
+// GetByID returns spell info by numeric ID
+func (d *SpellDatabase) GetByID(id int) (SpellInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.spellsByID[id]
+	return info, ok
+}
+
+// GetSpellName returns a human-readable name for a spell
+// It accepts either a numeric ID or a string unique name
+func (d *SpellDatabase) GetSpellName(spellID interface{}) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var id int
+	switch v := spellID.(type) {
+	case int:
+		id = v
+	case int16:
+		id = int(v)
+	case int32:
+		id = int(v)
+	case int64:
+		id = int(v)
+	case string:
+		return formatSpellName(v)
+	default:
+		return fmt.Sprintf("Spell<%v>", spellID)
+	}
+
+	if info, ok := d.spellsByID[id]; ok {
+		return formatSpellName(info.UniqueName)
+	}
+	return fmt.Sprintf("Spell#%d", id)
+}
+
+// formatSpellName converts internal name to readable format
+// FLAMESHIELD -> "Flameshield"
+// MULTISHOT_CURSED -> "Multishot Cursed"
+func formatSpellName(name string) string {
+	if name == "" {
+		return "Unknown"
+	}
+	return strings.Title(strings.ToLower(strings.ReplaceAll(name, "_", " ")))
+}
+
+// IsLoaded returns whether the database has been loaded
+func (d *SpellDatabase) IsLoaded() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.loaded
+}
+
+// SpellCount returns the number of loaded spells
+func (d *SpellDatabase) SpellCount() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.spells)
+}
@@ -0,0 +1,179 @@
+package spells
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// resetDatabase resets the global database for testing
+func resetDatabase() {
+	db = nil
+	once = sync.Once{}
+}
+
+// fixtureSpellsJSON is a minimal spells.json in the ao-bin-dumps layout
+const fixtureSpellsJSON = `{
+	"spells": {
+		"passivespell": [
+			{"@uniquename": "PASSIVE_HEALTH"},
+			{"@uniquename": "PASSIVE_ENERGY"}
+		],
+		"activespell": [
+			{"@uniquename": "FLAMESHIELD", "@target": "self"},
+			{"@namelocatag": "MISSING_NAME"},
+			{"@uniquename": "MULTISHOT_CURSED", "@target": "enemy"}
+		],
+		"togglespell": {"@uniquename": "TOGGLE_STANCE"}
+	}
+}`
+
+// loadFixture loads fixtureSpellsJSON into a fresh global database
+func loadFixture(t *testing.T) *SpellDatabase {
+	t.Helper()
+	resetDatabase()
+
+	jsonPath := filepath.Join(t.TempDir(), "spells.json")
+	if err := os.WriteFile(jsonPath, []byte(fixtureSpellsJSON), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	db := GetDatabase()
+	if err := db.LoadFromFile(jsonPath); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	return db
+}
+
+// TestGetDatabase tests singleton database creation
+func TestGetDatabase(t *testing.T) {
+	resetDatabase()
+
+	db1 := GetDatabase()
+	if db1 == nil {
+		t.Fatal("GetDatabase returned nil")
+	}
+	if db1 != GetDatabase() {
+		t.Error("GetDatabase should return the same instance")
+	}
+	if db1.IsLoaded() || db1.SpellCount() != 0 {
+		t.Error("database should start empty and not loaded")
+	}
+}
+
+// TestLoadFromFile tests loading spells and their index order across categories
+func TestLoadFromFile(t *testing.T) {
+	db := loadFixture(t)
+
+	if !db.IsLoaded() {
+		t.Error("database should be loaded")
+	}
+	if db.SpellCount() != 5 {
+		t.Errorf("expected 5 spells, got %d", db.SpellCount())
+	}
+
+	tests := []struct {
+		id       int
+		name     string
+		category string
+	}{
+		{0, "PASSIVE_HEALTH", "passivespell"},
+		{2, "FLAMESHIELD", "activespell"},
+		{3, "MULTISHOT_CURSED", "activespell"},
+		{4, "TOGGLE_STANCE", "togglespell"},
+	}
+	for _, tt := range tests {
+		info, ok := db.GetByID(tt.id)
+		if !ok || info.UniqueName != tt.name || info.Category != tt.category {
+			t.Errorf("GetByID(%d) = %+v, %v; expected %s in %s", tt.id, info, ok, tt.name, tt.category)
+		}
+	}
+
+	info, ok := db.GetByUniqueName("FLAMESHIELD")
+	if !ok || info.Index != 2 || info.Target != "self" {
+		t.Errorf("unexpected FLAMESHIELD info: %+v, %v", info, ok)
+	}
+	if _, ok := db.GetByID(5); ok {
+		t.Error("expected no spell with ID 5")
+	}
+}
+
+// TestGetSpellName tests name resolution by ID and unique name
+func TestGetSpellName(t *testing.T) {
+	db := loadFixture(t)
+
+	tests := []struct {
+		id       interface{}
+		expected string
+	}{
+		{2, "Flameshield"},
+		{int16(3), "Multishot Cursed"},
+		{int32(0), "Passive Health"},
+		{int64(4), "Toggle Stance"},
+		{99, "Spell#99"},
+		{"FLAMESHIELD", "Flameshield"},
+		{"", "Unknown"},
+		{1.5, "Spell<1.5>"},
+	}
+	for _, tt := range tests {
+		if name := db.GetSpellName(tt.id); name != tt.expected {
+			t.Errorf("GetSpellName(%v) = %q, expected %q", tt.id, name, tt.expected)
+		}
+	}
+}
+
+// TestLoadFromFileErrors tests missing, invalid and malformed files
+func TestLoadFromFileErrors(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+	tmpDir := t.TempDir()
+
+	if err := db.LoadFromFile(filepath.Join(tmpDir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	for name, content := range map[string]string{
+		"invalid.json":    `{not json`,
+		"no_spells.json":  `{"items": {}}`,
+		"bad_spells.json": `{"spells": []}`,
+	} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		if err := db.LoadFromFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if db.IsLoaded() {
+		t.Error("database should not be loaded after errors")
+	}
+}
+
+// TestLoadFromPath tests finding spells.json in an ao-bin-dumps directory
+func TestLoadFromPath(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+
+	baseDir := t.TempDir()
+	dumpsDir := filepath.Join(baseDir, "ao-bin-dumps")
+	if err := os.MkdirAll(dumpsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dumpsDir, "spells.json"), []byte(fixtureSpellsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.LoadFromPath(baseDir); err != nil {
+		t.Fatalf("LoadFromPath failed: %v", err)
+	}
+	if db.SpellCount() != 5 {
+		t.Errorf("expected 5 spells, got %d", db.SpellCount())
+	}
+
+	resetDatabase()
+	if err := GetDatabase().LoadFromPath(t.TempDir()); err == nil {
+		t.Error("expected error when spells.json is not found")
+	}
+}
@@ -0,0 +1,147 @@
+// Package world provides zone (cluster) ID to name translation using ao-bin-dumps data
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// WorldDatabase holds the loaded world.json clusters
+type WorldDatabase struct {
+	clusters map[string]ClusterInfo // key: cluster ID (e.g., "0000")
+	mu       sync.RWMutex
+	loaded   bool
+}
+
+// ClusterInfo contains zone (cluster) information
+type ClusterInfo struct {
+	ID          string // Cluster ID, as sent by the server (e.g., "0000")
+	DisplayName string // Readable zone name (e.g., "Thetford"), empty if none
+	Type        string // Cluster type (e.g., "SAFEAREA", "OPENPVP_BLACK_1"), empty if none
+}
+
+// Global database instance
+var db *WorldDatabase
+var once sync.Once
+
+// GetDatabase returns the global world database
+func GetDatabase() *WorldDatabase {
+	once.Do(func() {
+		db = &WorldDatabase{
+			clusters: make(map[string]ClusterInfo),
+		}
+	})
+	return db
+}
+
+// LoadFromFile loads clusters from a world.json file
+func (d *WorldDatabase) LoadFromFile(filePath string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read world file: %w", err)
+	}
+
+	return d.parseWorldJSON(data)
+}
+
+// LoadFromPath tries to find and load world.json from common paths
+// (ao-bin-dumps keeps it in the cluster directory)
+func (d *WorldDatabase) LoadFromPath(basePath string) error {
+	paths := []string{
+		filepath.Join(basePath, "world.json"),
+		filepath.Join(basePath, "cluster", "world.json"),
+		filepath.Join(basePath, "ao-bin-dumps", "cluster", "world.json"),
+		filepath.Join(basePath, "..", "ao-bin-dumps", "cluster", "world.json"),
+		"world.json",
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return d.LoadFromFile(path)
+		}
+	}
+
+	return fmt.Errorf("world.json not found in any of the expected locations")
+}
+
+// parseWorldJSON parses the world.json structure: world.clusters.cluster is the
+// list of clusters (an object instead of an array when there is only one)
+func (d *WorldDatabase) parseWorldJSON(data []byte) error {
+	var root struct {
+		World struct {
+			Clusters struct {
+				Cluster json.RawMessage `json:"cluster"`
+			} `json:"clusters"`
+		} `json:"world"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if len(root.World.Clusters.Cluster) == 0 {
+		return fmt.Errorf("invalid world.json structure: missing 'world.clusters.cluster' key")
+	}
+
+	type rawCluster struct {
+		ID          string `json:"@id"`
+		DisplayName string `json:"@displayname"`
+		Type        string `json:"@type"`
+	}
+	var clusters []rawCluster
+	if err := json.Unmarshal(root.World.Clusters.Cluster, &clusters); err != nil {
+		var single rawCluster
+		if err := json.Unmarshal(root.World.Clusters.Cluster, &single); err != nil {
+			return fmt.Errorf("failed to parse clusters: %w", err)
+		}
+		clusters = []rawCluster{single}
+	}
+
+	for _, c := range clusters {
+		if c.ID == "" {
+			continue
+		}
+		d.clusters[c.ID] = ClusterInfo{ID: c.ID, DisplayName: c.DisplayName, Type: c.Type}
+	}
+
+	d.loaded = true
+	return nil
+}
+
+// GetByID returns cluster info by cluster ID
+func (d *WorldDatabase) GetByID(id string) (ClusterInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.clusters[id]
+	return info, ok
+}
+
+// GetZoneName returns a readable name for a cluster ID, the ID itself if it is
+// unknown or has no display name
+func (d *WorldDatabase) GetZoneName(id string) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if info, ok := d.clusters[id]; ok && info.DisplayName != "" {
+		return info.DisplayName
+	}
+	return id
+}
+
+// IsLoaded returns whether the database has been loaded
+func (d *WorldDatabase) IsLoaded() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.loaded
+}
+
+// ClusterCount returns the number of loaded clusters
+func (d *WorldDatabase) ClusterCount() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.clusters)
+}
@@ -0,0 +1,174 @@
+package world
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// resetDatabase resets the global database for testing
+func resetDatabase() {
+	db = nil
+	once = sync.Once{}
+}
+
+// fixtureWorldJSON is a minimal world.json in the ao-bin-dumps layout
+const fixtureWorldJSON = `{
+	"world": {
+		"clusters": {
+			"cluster": [
+				{"@id": "0000", "@displayname": "Thetford", "@type": "SAFEAREA"},
+				{"@id": "1012", "@displayname": "Sunfang Cliffs", "@type": "OPENPVP_BLACK_1"},
+				{"@id": "TNL-001"},
+				{"@displayname": "No ID"}
+			]
+		}
+	}
+}`
+
+// loadFixture loads fixtureWorldJSON into a fresh global database
+func loadFixture(t *testing.T) *WorldDatabase {
+	t.Helper()
+	resetDatabase()
+
+	jsonPath := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(jsonPath, []byte(fixtureWorldJSON), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	db := GetDatabase()
+	if err := db.LoadFromFile(jsonPath); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	return db
+}
+
+// TestGetDatabase tests singleton database creation
+func TestGetDatabase(t *testing.T) {
+	resetDatabase()
+
+	db1 := GetDatabase()
+	if db1 == nil {
+		t.Fatal("GetDatabase returned nil")
+	}
+	if db1 != GetDatabase() {
+		t.Error("GetDatabase should return the same instance")
+	}
+	if db1.IsLoaded() || db1.ClusterCount() != 0 {
+		t.Error("database should start empty and not loaded")
+	}
+}
+
+// TestLoadFromFile tests loading clusters, skipping those without an ID
+func TestLoadFromFile(t *testing.T) {
+	db := loadFixture(t)
+
+	if !db.IsLoaded() {
+		t.Error("database should be loaded")
+	}
+	if db.ClusterCount() != 3 {
+		t.Errorf("expected 3 clusters, got %d", db.ClusterCount())
+	}
+
+	info, ok := db.GetByID("1012")
+	if !ok || info.DisplayName != "Sunfang Cliffs" || info.Type != "OPENPVP_BLACK_1" {
+		t.Errorf("unexpected 1012 info: %+v, %v", info, ok)
+	}
+	if _, ok := db.GetByID("9999"); ok {
+		t.Error("expected no cluster 9999")
+	}
+}
+
+// TestGetZoneName tests name resolution, falling back to the raw cluster ID
+func TestGetZoneName(t *testing.T) {
+	db := loadFixture(t)
+
+	tests := []struct {
+		id       string
+		expected string
+	}{
+		{"0000", "Thetford"},
+		{"1012", "Sunfang Cliffs"},
+		{"TNL-001", "TNL-001"},
+		{"9999", "9999"},
+	}
+	for _, tt := range tests {
+		if name := db.GetZoneName(tt.id); name != tt.expected {
+			t.Errorf("GetZoneName(%q) = %q, expected %q", tt.id, name, tt.expected)
+		}
+	}
+}
+
+// TestLoadSingleCluster tests a cluster list holding a single object instead of an array
+func TestLoadSingleCluster(t *testing.T) {
+	resetDatabase()
+	path := filepath.Join(t.TempDir(), "world.json")
+	content := `{"world": {"clusters": {"cluster": {"@id": "0000", "@displayname": "Thetford"}}}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	db := GetDatabase()
+	if err := db.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if db.GetZoneName("0000") != "Thetford" {
+		t.Errorf("expected Thetford, got %q", db.GetZoneName("0000"))
+	}
+}
+
+// TestLoadFromFileErrors tests missing, invalid and malformed files
+func TestLoadFromFileErrors(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+	tmpDir := t.TempDir()
+
+	if err := db.LoadFromFile(filepath.Join(tmpDir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	for name, content := range map[string]string{
+		"invalid.json":      `{not json`,
+		"no_world.json":     `{"items": {}}`,
+		"bad_clusters.json": `{"world": {"clusters": {"cluster": "0000"}}}`,
+	} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		if err := db.LoadFromFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if db.IsLoaded() {
+		t.Error("database should not be loaded after errors")
+	}
+}
+
+// TestLoadFromPath tests finding world.json in the cluster directory of ao-bin-dumps
+func TestLoadFromPath(t *testing.T) {
+	resetDatabase()
+	db := GetDatabase()
+
+	baseDir := t.TempDir()
+	clusterDir := filepath.Join(baseDir, "ao-bin-dumps", "cluster")
+	if err := os.MkdirAll(clusterDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(clusterDir, "world.json"), []byte(fixtureWorldJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.LoadFromPath(baseDir); err != nil {
+		t.Fatalf("LoadFromPath failed: %v", err)
+	}
+	if db.ClusterCount() != 3 {
+		t.Errorf("expected 3 clusters, got %d", db.ClusterCount())
+	}
+
+	resetDatabase()
+	if err := GetDatabase().LoadFromPath(t.TempDir()); err == nil {
+		t.Error("expected error when world.json is not found")
+	}
+}